@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 )
 
 // formatComponentName turns backend component identifiers into friendly names.
@@ -43,3 +45,57 @@ func loggerForState(state *runtimeState) *slog.Logger {
 	}
 	return slog.Default()
 }
+
+// formatRelativeTime renders an RFC3339 timestamp relative to now with the absolute value in
+// parentheses, e.g. "3 days ago (2024-11-01T12:00:00Z)". Unparseable values are returned unchanged.
+func formatRelativeTime(raw string, now time.Time) string {
+	stamp, err := time.Parse(time.RFC3339, strings.TrimSpace(raw))
+	if err != nil {
+		return raw
+	}
+
+	age := now.Sub(stamp)
+	if age < 0 {
+		return fmt.Sprintf("in %s (%s)", relativeSpan(-age), raw)
+	}
+	if age < time.Minute {
+		return fmt.Sprintf("just now (%s)", raw)
+	}
+	return fmt.Sprintf("%s ago (%s)", relativeSpan(age), raw)
+}
+
+// relativeSpan describes a duration in its largest whole calendar-ish unit.
+func relativeSpan(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Minute:
+		return pluralize(int(d/time.Second), "second")
+	case d < time.Hour:
+		return pluralize(int(d/time.Minute), "minute")
+	case d < day:
+		return pluralize(int(d/time.Hour), "hour")
+	case d < 30*day:
+		return pluralize(int(d/day), "day")
+	case d < 365*day:
+		return pluralize(int(d/(30*day)), "month")
+	default:
+		return pluralize(int(d/(365*day)), "year")
+	}
+}
+
+func pluralize(count int, unit string) string {
+	if count == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
+
+// timestampFormatter returns the formatter table renderers apply to timestamps: relative to the
+// current time when enabled, otherwise the raw value.
+func timestampFormatter(relative bool) func(string) string {
+	if !relative {
+		return func(raw string) string { return raw }
+	}
+	now := time.Now()
+	return func(raw string) string { return formatRelativeTime(raw, now) }
+}
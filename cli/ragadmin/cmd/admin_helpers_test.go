@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2024, 11, 4, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		raw  string
+		want string
+	}{
+		{raw: "2024-11-04T11:59:30Z", want: "just now (2024-11-04T11:59:30Z)"},
+		{raw: "2024-11-04T11:59:00Z", want: "1 minute ago (2024-11-04T11:59:00Z)"},
+		{raw: "2024-11-04T09:00:00Z", want: "3 hours ago (2024-11-04T09:00:00Z)"},
+		{raw: "2024-11-01T12:00:00Z", want: "3 days ago (2024-11-01T12:00:00Z)"},
+		{raw: "2024-09-15T09:30:00Z", want: "1 month ago (2024-09-15T09:30:00Z)"},
+		{raw: "2022-11-01T12:00:00Z", want: "2 years ago (2022-11-01T12:00:00Z)"},
+		{raw: "2024-11-04T14:00:00+01:00", want: "in 1 hour (2024-11-04T14:00:00+01:00)"},
+		{raw: "yesterday", want: "yesterday"},
+		{raw: "", want: ""},
+	}
+	for _, tc := range cases {
+		if got := formatRelativeTime(tc.raw, now); got != tc.want {
+			t.Fatalf("formatRelativeTime(%q) = %q, want %q", tc.raw, got, tc.want)
+		}
+	}
+}
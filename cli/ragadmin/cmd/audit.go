@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/audit"
+	"github.com/spf13/cobra"
+)
+
+// auditFollowInterval is how often `audit tail --follow` polls the log for new lines.
+const auditFollowInterval = 500 * time.Millisecond
+
+func newAuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the local audit trail",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newAuditTailCommand())
+	return cmd
+}
+
+func newAuditTailCommand() *cobra.Command {
+	var opts struct {
+		follow bool
+		limit  int
+		action string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print recent audit entries, optionally following new ones",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.limit < 0 {
+				return fmt.Errorf("limit must be zero or positive, got %d", opts.limit)
+			}
+			state, err := obtainState(cmd)
+			if err != nil {
+				return err
+			}
+			if state.AuditLogger == nil {
+				return fmt.Errorf("audit log is not configured")
+			}
+
+			action := strings.TrimSpace(opts.action)
+			reader := audit.NewReader(state.AuditLogger.Path())
+			entries, err := reader.Next()
+			if err != nil {
+				return err
+			}
+			entries = filterAuditEntries(entries, action)
+			if opts.limit > 0 && len(entries) > opts.limit {
+				entries = entries[len(entries)-opts.limit:]
+			}
+
+			out := cmd.OutOrStdout()
+			if err := renderAuditEntries(out, state.OutputFormat, entries, true); err != nil {
+				return err
+			}
+			if !opts.follow {
+				return nil
+			}
+
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return reader.Follow(ctx, auditFollowInterval, func(entry audit.Entry) error {
+				if !auditEntryMatches(entry, action) {
+					return nil
+				}
+				return renderAuditEntries(out, state.OutputFormat, []audit.Entry{entry}, false)
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.follow, "follow", false, "Keep printing entries as they are appended")
+	cmd.Flags().IntVar(&opts.limit, "limit", 20, "Number of most recent entries to print (0 prints all)")
+	cmd.Flags().StringVar(&opts.action, "action", "", "Only show entries with this action (e.g. source_add)")
+	return cmd
+}
+
+func filterAuditEntries(entries []audit.Entry, action string) []audit.Entry {
+	if action == "" {
+		return entries
+	}
+	filtered := make([]audit.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if auditEntryMatches(entry, action) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+func auditEntryMatches(entry audit.Entry, action string) bool {
+	return action == "" || strings.EqualFold(entry.Action, action)
+}
+
+// renderAuditEntries prints entries as a table or as JSON lines so followed output can stream.
+func renderAuditEntries(out io.Writer, format string, entries []audit.Entry, header bool) error {
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	if header {
+		if _, err := fmt.Fprintln(tw, "TIMESTAMP\tACTION\tTARGET\tSTATUS\tTRACE ID\tDETAILS"); err != nil {
+			return err
+		}
+	}
+	for _, entry := range entries {
+		if _, err := fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp,
+			entry.Action,
+			entry.Target,
+			entry.Status,
+			entry.TraceID,
+			entry.Details,
+		); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/linux-rag-t2/cli/ragadmin/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// newConfigCommand groups configuration helpers. It replaces the root pre-run hook so a config file
+// that fails to load can still be inspected.
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "config",
+		Short:             "Inspect the ragcli configuration file",
+		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newConfigValidateCommand())
+	return cmd
+}
+
+// newConfigValidateCommand constructs `config validate`, which strictly checks the ragadmin settings.
+func newConfigValidateCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Report unknown keys and invalid values in the configuration file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path := strings.TrimSpace(file)
+			if path == "" {
+				resolved, err := resolveConfigPath(rootOpts.configPath)
+				if err != nil {
+					return err
+				}
+				path = resolved
+			}
+
+			problems, err := config.Validate(path)
+			if err != nil {
+				return err
+			}
+			return reportConfigValidation(cmd.OutOrStdout(), path, problems)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Configuration file to validate (defaults to --config)")
+	return cmd
+}
+
+// reportConfigValidation prints a pass/fail line followed by each problem, failing when any were found.
+func reportConfigValidation(out io.Writer, path string, problems []string) error {
+	if len(problems) == 0 {
+		_, err := fmt.Fprintf(out, "PASS %s\n", path)
+		return err
+	}
+
+	if _, err := fmt.Fprintf(out, "FAIL %s\n", path); err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		if _, err := fmt.Fprintf(out, "  - %s\n", problem); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("configuration has %d problem(s)", len(problems))
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/linux-rag-t2/cli/shared/cliflags"
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+// errorEnvelope is written to stdout when a command fails under `--output json`, so scripts
+// parsing stdout see a JSON document on failure as well as on success.
+type errorEnvelope struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Message string `json:"message"`
+	TraceID string `json:"trace_id,omitempty"`
+	Code    string `json:"code"`
+}
+
+// outputTracker records whether a command wrote anything to stdout, so the error envelope is
+// only emitted when the command has not already produced its own JSON payload.
+type outputTracker struct {
+	w     io.Writer
+	wrote bool
+}
+
+func (t *outputTracker) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		t.wrote = true
+	}
+	return t.w.Write(p)
+}
+
+// Unwrap exposes the underlying stdout so terminal detection (progress bars, `health --watch`
+// redraws) still sees the real TTY behind the tracker.
+func (t *outputTracker) Unwrap() io.Writer {
+	return t.w
+}
+
+// failureOutputFormat reports the output format in effect when a command failed. It falls back
+// to the --output flag when the failure happened before state was initialised.
+func failureOutputFormat() string {
+	if ctx := rootCmd.Context(); ctx != nil {
+		if state, ok := ctx.Value(appStateKey{}).(*runtimeState); ok && state != nil {
+			return state.OutputFormat
+		}
+	}
+	return resolveOutputFormat(rootOpts.output, "")
+}
+
+// newErrorEnvelope builds the JSON error document for err, preferring backend-provided details.
+func newErrorEnvelope(err error) errorEnvelope {
+	detail := errorDetail{
+		Message: err.Error(),
+		TraceID: rootOpts.traceID,
+		Code:    exitCodeName(cliflags.ExitCode(err)),
+	}
+	var backendErr *ipc.BackendError
+	if errors.As(err, &backendErr) {
+		if backendErr.Code != "" {
+			detail.Code = backendErr.Code
+		}
+		if backendErr.TraceID != "" {
+			detail.TraceID = backendErr.TraceID
+		}
+	}
+	return errorEnvelope{Error: detail}
+}
+
+// exitCodeName maps a process exit code to the stable error code used in the JSON envelope.
+func exitCodeName(code int) string {
+	switch code {
+	case ExitBackendUnavailable:
+		return "backend_unavailable"
+	case ExitHealthFail:
+		return "health_fail"
+	case ExitHealthWarn:
+		return "health_warn"
+	case ExitDependencyFail:
+		return "dependency_fail"
+	case ExitDependencyWarn:
+		return "dependency_warn"
+	case ExitInterrupted:
+		return "interrupted"
+	default:
+		return "error"
+	}
+}
+
+func writeErrorEnvelope(out io.Writer, err error) error {
+	data, marshalErr := json.MarshalIndent(newErrorEnvelope(err), "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	_, writeErr := out.Write(append(data, '\n'))
+	return writeErr
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestNewErrorEnvelope(t *testing.T) {
+	backendErr := &ipc.BackendError{Operation: "sources show", Status: 404, Code: "SOURCE_NOT_FOUND", TraceID: "trace-1"}
+	envelope := newErrorEnvelope(fmt.Errorf("wrapped: %w", backendErr))
+	if envelope.Error.Code != "SOURCE_NOT_FOUND" || envelope.Error.TraceID != "trace-1" {
+		t.Fatalf("expected backend details in envelope, got %+v", envelope.Error)
+	}
+
+	unavailable := &ExitError{Code: ExitBackendUnavailable, Err: errors.New("dial failed")}
+	envelope = newErrorEnvelope(unavailable)
+	if envelope.Error.Code != "backend_unavailable" || envelope.Error.Message != "dial failed" {
+		t.Fatalf("unexpected envelope for unavailable backend: %+v", envelope.Error)
+	}
+
+	for code, want := range map[int]string{
+		ExitDependencyFail: "dependency_fail",
+		ExitDependencyWarn: "dependency_warn",
+		ExitInterrupted:    "interrupted",
+	} {
+		if got := newErrorEnvelope(&ExitError{Code: code, Err: errors.New("failed")}).Error.Code; got != want {
+			t.Fatalf("exit code %d mapped to %q, want %q", code, got, want)
+		}
+	}
+
+	if code := newErrorEnvelope(errors.New("boom")).Error.Code; code != "error" {
+		t.Fatalf("expected generic error code, got %q", code)
+	}
+}
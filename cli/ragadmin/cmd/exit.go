@@ -0,0 +1,26 @@
+package cmd
+
+import "github.com/linux-rag-t2/cli/shared/cliflags"
+
+// Process exit codes returned by ragadmin so scripts can tell failure modes apart.
+const (
+	// ExitOK signals success.
+	ExitOK = cliflags.ExitOK
+	// ExitFailure covers usage errors and any failure without a more specific code.
+	ExitFailure = cliflags.ExitFailure
+	// ExitBackendUnavailable means the backend socket could not be reached.
+	ExitBackendUnavailable = 2
+	// ExitHealthFail means the backend answered and reported an overall `fail` status.
+	ExitHealthFail = 3
+	// ExitHealthWarn means the backend reported `warn` and `health --strict` was requested.
+	ExitHealthWarn = 4
+	// ExitDependencyFail means `init --require-dependencies` saw a dependency check with status `fail`.
+	ExitDependencyFail = 5
+	// ExitDependencyWarn means `init --require-dependencies --strict` saw a dependency check with status `warn`.
+	ExitDependencyWarn = 6
+	// ExitInterrupted follows the shell convention (128+SIGINT) for a forced exit on a repeated Ctrl-C.
+	ExitInterrupted = 130
+)
+
+// ExitError attaches a process exit code to a command error; see cliflags.ExitCode.
+type ExitError = cliflags.ExitError
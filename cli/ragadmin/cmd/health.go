@@ -6,24 +6,46 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
 	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/linux-rag-t2/cli/shared/termutil"
 	"github.com/spf13/cobra"
 )
 
+// clearScreen moves the cursor home and clears the terminal before each watch redraw.
+const clearScreen = "\x1b[H\x1b[2J"
+
 // newHealthCommand returns the Cobra subcommand that executes `ragadmin health`.
 func newHealthCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:   "health",
-		Short: "Display dependency and storage health",
+	var (
+		watch     bool
+		interval  time.Duration
+		strict    bool
+		component string
+	)
+
+	cmd := &cobra.Command{
+		Use:         "health",
+		Short:       "Display dependency and storage health",
+		Annotations: map[string]string{outputFormatsAnnotation: outputPrometheus},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			req := ipc.HealthRequest{TraceID: ipc.NewTraceID()}
+			if watch {
+				if interval <= 0 {
+					return fmt.Errorf("interval must be positive, got %s", interval)
+				}
+				return runHealthWatch(cmd, interval, component)
+			}
+
+			req := ipc.HealthRequest{TraceID: requestTraceID()}
 			started := time.Now()
 
-			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+			return runWithClient(cmd, withSpinner(cmd, "Checking backend health...", func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
 				logger := loggerForState(state).With(slog.String("trace_id", req.TraceID))
 				logger.Info("ragadmin.health :: request")
 
@@ -32,6 +54,9 @@ func newHealthCommand() *cobra.Command {
 					logger.Error("ragadmin.health :: error", slog.String("error", err.Error()))
 					return err
 				}
+				if summary.Results, err = filterHealthResults(summary.Results, component); err != nil {
+					return err
+				}
 
 				logger.Info(
 					"ragadmin.health :: success",
@@ -51,14 +76,150 @@ func newHealthCommand() *cobra.Command {
 					summary.TraceID,
 					fmt.Sprintf("overall=%s", strings.ToLower(summary.OverallStatus)),
 				)
-				return nil
-			})
+				return healthStatusError(summary.OverallStatus, strict)
+			}))
 		},
 	}
+
+	cmd.Flags().BoolVar(&watch, "watch", false, "Poll health repeatedly and redraw the summary until interrupted")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Delay between polls in --watch mode")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Also exit non-zero when overall status is warn")
+	cmd.Flags().StringVar(&component, "component", "", "Only show the named component (e.g. weaviate, disk_capacity)")
+	return cmd
+}
+
+// filterHealthResults keeps only the result whose raw component identifier matches name
+// case-insensitively. An empty name returns results unchanged.
+func filterHealthResults(results []ipc.HealthResult, name string) ([]ipc.HealthResult, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return results, nil
+	}
+
+	available := make([]string, 0, len(results))
+	for _, result := range results {
+		if strings.EqualFold(strings.TrimSpace(result.Component), name) {
+			return []ipc.HealthResult{result}, nil
+		}
+		available = append(available, result.Component)
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("component %q not reported by backend (no components reported)", name)
+	}
+	return nil, fmt.Errorf("component %q not reported by backend (available: %s)", name, strings.Join(available, ", "))
+}
+
+// healthStatusError turns a degraded overall status into an ExitError once the summary has been printed.
+func healthStatusError(overall string, strict bool) error {
+	switch status := strings.ToLower(strings.TrimSpace(overall)); {
+	case status == "fail":
+		return &ExitError{Code: ExitHealthFail, Err: fmt.Errorf("backend reports overall status %s", status)}
+	case status == "warn" && strict:
+		return &ExitError{Code: ExitHealthWarn, Err: fmt.Errorf("backend reports overall status %s (--strict)", status)}
+	default:
+		return nil
+	}
+}
+
+// runHealthWatch keeps one backend connection open and polls health until the
+// command is interrupted. Each poll gets its own request timeout.
+func runHealthWatch(cmd *cobra.Command, interval time.Duration, component string) error {
+	state, err := obtainState(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := newBackendClient(state, true)
+	if err != nil {
+		return &ExitError{Code: ExitBackendUnavailable, Err: err}
+	}
+	defer client.Close()
+
+	logger := loggerForState(state)
+	poll := func(ctx context.Context) (ipc.HealthSummary, error) {
+		pollCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		summary, err := client.HealthCheck(pollCtx, ipc.HealthRequest{TraceID: requestTraceID()})
+		if err != nil {
+			return summary, err
+		}
+		summary.Results, err = filterHealthResults(summary.Results, component)
+		return summary, err
+	}
+	return watchHealth(ctx, cmd.OutOrStdout(), cmd.ErrOrStderr(), state.OutputFormat, termutil.IsTerminal(cmd.OutOrStdout()), interval, poll, logger)
+}
+
+// watchHealth polls until ctx is cancelled. Table output is redrawn in place on a
+// terminal; JSON output is streamed as one object per line. Poll failures are
+// reported and polling continues, so a dashboard survives backend restarts.
+func watchHealth(
+	ctx context.Context,
+	out, errOut io.Writer,
+	format string,
+	redraw bool,
+	interval time.Duration,
+	poll func(context.Context) (ipc.HealthSummary, error),
+	logger *slog.Logger,
+) error {
+	for {
+		summary, err := poll(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			logger.Warn("ragadmin.health :: watch poll failed", slog.String("error", err.Error()))
+			if _, werr := fmt.Fprintf(errOut, "health check failed: %v\n", err); werr != nil {
+				return werr
+			}
+		} else if err := renderHealthWatchFrame(out, format, redraw, summary); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func renderHealthWatchFrame(out io.Writer, format string, redraw bool, summary ipc.HealthSummary) error {
+	if format == "json" {
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(append(data, '\n'))
+		return err
+	}
+
+	if redraw {
+		if _, err := fmt.Fprint(out, clearScreen); err != nil {
+			return err
+		}
+	}
+	if err := renderHealthSummary(out, format, summary); err != nil {
+		return err
+	}
+	if !redraw {
+		_, err := fmt.Fprintln(out)
+		return err
+	}
+	return nil
 }
 
 // renderHealthSummary writes the health summary to stdout using the requested format.
 func renderHealthSummary(out io.Writer, format string, summary ipc.HealthSummary) error {
+	if format == outputPrometheus {
+		return renderHealthPrometheus(out, summary)
+	}
 	if format == "json" {
 		data, err := json.MarshalIndent(summary, "", "  ")
 		if err != nil {
@@ -95,14 +256,25 @@ func renderHealthSummary(out io.Writer, format string, summary ipc.HealthSummary
 		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", component, status, result.Message); err != nil {
 			return err
 		}
-		if trimmed := strings.TrimSpace(result.Remediation); trimmed != "" {
-			if _, err := fmt.Fprintf(tw, "  Remediation\t\t%s\n", trimmed); err != nil {
-				return err
-			}
-		}
 	}
 	if err := tw.Flush(); err != nil {
 		return err
 	}
+	return renderHealthRemediations(out, summary.Results)
+}
+
+// renderHealthRemediations prints a fix block below the table for every warn or fail component
+// that carries remediation advice, so the hint is not lost among the table rows.
+func renderHealthRemediations(out io.Writer, results []ipc.HealthResult) error {
+	for _, result := range results {
+		status := strings.ToLower(strings.TrimSpace(result.Status))
+		remediation := strings.TrimSpace(result.Remediation)
+		if remediation == "" || (status != "warn" && status != "fail") {
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "\n%s (%s)\n  → Fix: %s\n", formatComponentName(result.Component), strings.ToUpper(status), remediation); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+const (
+	// outputPrometheus selects the Prometheus text exposition format for health output.
+	outputPrometheus = "prometheus"
+
+	prometheusMetricPrefix = "ragcli_"
+	componentStatusMetric  = prometheusMetricPrefix + "component_status"
+)
+
+// prometheusSample is a single labelled value of a gauge.
+type prometheusSample struct {
+	component string
+	value     float64
+}
+
+// renderHealthPrometheus writes a ragcli_component_status gauge (0=pass, 1=warn, 2=fail)
+// plus one gauge per backend metric key, each labelled by component. Metric families are
+// sorted by name; samples keep the backend's component order.
+func renderHealthPrometheus(out io.Writer, summary ipc.HealthSummary) error {
+	families := map[string][]prometheusSample{}
+	for _, result := range summary.Results {
+		families[componentStatusMetric] = append(families[componentStatusMetric], prometheusSample{
+			component: result.Component,
+			value:     componentStatusValue(result.Status),
+		})
+		for key, value := range result.Metrics {
+			name := prometheusMetricName(key)
+			families[name] = append(families[name], prometheusSample{component: result.Component, value: value})
+		}
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		help := fmt.Sprintf("Backend health metric reported as %s.", strings.TrimPrefix(name, prometheusMetricPrefix))
+		if name == componentStatusMetric {
+			help = "Component health status (0=pass, 1=warn, 2=fail)."
+		}
+		if _, err := fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name); err != nil {
+			return err
+		}
+		for _, sample := range families[name] {
+			if _, err := fmt.Fprintf(
+				out,
+				"%s{component=\"%s\"} %s\n",
+				name,
+				escapePrometheusLabel(sample.component),
+				formatPrometheusValue(sample.value),
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// componentStatusValue maps health statuses to gauge values; unknown statuses become NaN.
+func componentStatusValue(status string) float64 {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "pass":
+		return 0
+	case "warn":
+		return 1
+	case "fail":
+		return 2
+	default:
+		return math.NaN()
+	}
+}
+
+// prometheusMetricName prefixes the key and replaces characters outside [a-zA-Z0-9_].
+func prometheusMetricName(key string) string {
+	var b strings.Builder
+	b.WriteString(prometheusMetricPrefix)
+	for _, r := range strings.ToLower(strings.TrimSpace(key)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+func escapePrometheusLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatPrometheusValue(value float64) string {
+	if math.IsNaN(value) {
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/cliflags"
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestWatchHealthStreamsJSONUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var polls int
+	poll := func(context.Context) (ipc.HealthSummary, error) {
+		polls++
+		if polls == 2 {
+			return ipc.HealthSummary{}, errors.New("backend restarting")
+		}
+		if polls == 3 {
+			cancel()
+		}
+		return ipc.HealthSummary{OverallStatus: "pass", TraceID: "watch"}, nil
+	}
+
+	var out, errOut strings.Builder
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if err := watchHealth(ctx, &out, &errOut, "json", false, time.Millisecond, poll, logger); err != nil {
+		t.Fatalf("watchHealth() error = %v", err)
+	}
+
+	if polls != 3 {
+		t.Fatalf("expected polling to stop after cancellation, got %d polls", polls)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], `"overall_status":"pass"`) {
+		t.Fatalf("expected one JSON object per successful poll, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "backend restarting") {
+		t.Fatalf("expected poll failure to be reported, got %q", errOut.String())
+	}
+}
+
+func TestWatchHealthRedrawsTableInPlace(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var polls int
+	poll := func(context.Context) (ipc.HealthSummary, error) {
+		polls++
+		if polls == 2 {
+			defer cancel()
+		}
+		return ipc.HealthSummary{OverallStatus: "warn"}, nil
+	}
+
+	var out strings.Builder
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if err := watchHealth(ctx, &out, io.Discard, "table", true, time.Millisecond, poll, logger); err != nil {
+		t.Fatalf("watchHealth() error = %v", err)
+	}
+
+	if got := strings.Count(out.String(), clearScreen); got != 1 {
+		t.Fatalf("expected 1 redraw before cancellation, got %d:\n%q", got, out.String())
+	}
+	if !strings.Contains(out.String(), "Overall Status: WARN") {
+		t.Fatalf("expected summary in watch output:\n%s", out.String())
+	}
+}
+
+func TestHealthStatusErrorExitCodes(t *testing.T) {
+	cases := []struct {
+		overall string
+		strict  bool
+		want    int
+	}{
+		{overall: "pass", want: ExitOK},
+		{overall: "warn", want: ExitOK},
+		{overall: "warn", strict: true, want: ExitHealthWarn},
+		{overall: "FAIL", want: ExitHealthFail},
+		{overall: "fail", strict: true, want: ExitHealthFail},
+	}
+
+	for _, tc := range cases {
+		if got := cliflags.ExitCode(healthStatusError(tc.overall, tc.strict)); got != tc.want {
+			t.Fatalf("cliflags.ExitCode(healthStatusError(%q, %v)) = %d, want %d", tc.overall, tc.strict, got, tc.want)
+		}
+	}
+}
+
+func TestFilterHealthResults(t *testing.T) {
+	results := []ipc.HealthResult{
+		{Component: "disk_capacity", Status: "warn"},
+		{Component: "weaviate", Status: "pass"},
+	}
+
+	all, err := filterHealthResults(results, "")
+	if err != nil || len(all) != 2 {
+		t.Fatalf("expected empty name to keep all results, got %v (err %v)", all, err)
+	}
+
+	got, err := filterHealthResults(results, " Weaviate ")
+	if err != nil {
+		t.Fatalf("filterHealthResults() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Component != "weaviate" {
+		t.Fatalf("expected only weaviate, got %v", got)
+	}
+
+	if _, err := filterHealthResults(results, "ollama"); err == nil || !strings.Contains(err.Error(), "available: disk_capacity, weaviate") {
+		t.Fatalf("expected missing component error listing available components, got %v", err)
+	}
+}
+
+func TestRenderHealthPrometheusMatchesGolden(t *testing.T) {
+	summary := ipc.HealthSummary{
+		OverallStatus: "warn",
+		Results: []ipc.HealthResult{
+			{
+				Component: "disk_capacity",
+				Status:    "warn",
+				Metrics:   map[string]float64{"free_percent": 9.5, "free-bytes": 1073741824},
+			},
+			{Component: "weaviate", Status: "pass"},
+			{Component: "ollama", Status: "fail", Metrics: map[string]float64{"free_percent": 0}},
+		},
+	}
+
+	var out strings.Builder
+	if err := renderHealthSummary(&out, outputPrometheus, summary); err != nil {
+		t.Fatalf("renderHealthSummary() error = %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "health_prometheus.golden"))
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+	if out.String() != string(want) {
+		t.Fatalf("prometheus output mismatch\n--- got ---\n%s\n--- want ---\n%s", out.String(), want)
+	}
+}
+
+func TestRenderHealthTableMatchesGolden(t *testing.T) {
+	// Mirrors the fixture served by the admin-health contract scenario, plus a passing component
+	// whose remediation must stay hidden.
+	summary := ipc.HealthSummary{
+		OverallStatus: "warn",
+		TraceID:       "admin-health-trace",
+		Results: []ipc.HealthResult{
+			{
+				Component:   "disk_capacity",
+				Status:      "warn",
+				Message:     "9% free space remaining",
+				Remediation: "Delete temporary files or expand the partition.",
+			},
+			{Component: "ollama", Status: "pass", Message: "Local models loaded", Remediation: "Nothing to do."},
+			{Component: "weaviate", Status: "pass", Message: "Cluster ready"},
+		},
+	}
+
+	var out strings.Builder
+	if err := renderHealthSummary(&out, "table", summary); err != nil {
+		t.Fatalf("renderHealthSummary() error = %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "health_table.golden"))
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+	if out.String() != string(want) {
+		t.Fatalf("table output mismatch\n--- got ---\n%s\n--- want ---\n%s", out.String(), want)
+	}
+}
@@ -3,33 +3,48 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
+	"github.com/linux-rag-t2/cli/shared/cliflags"
 	"github.com/linux-rag-t2/cli/shared/ipc"
 	"github.com/spf13/cobra"
 )
 
 // newInitCommand returns the Cobra subcommand that runs `ragadmin init`.
 func newInitCommand() *cobra.Command {
-	return &cobra.Command{
+	var (
+		dryRun       bool
+		kiwixDir     string
+		relativeTime bool
+		requireDeps  bool
+		strict       bool
+	)
+
+	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize ragcli directories and seed default sources",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			req := ipc.InitRequest{TraceID: ipc.NewTraceID()}
+			if strict && !requireDeps {
+				return fmt.Errorf("--strict requires --require-dependencies")
+			}
+			req := ipc.InitRequest{TraceID: requestTraceID(), DryRun: dryRun}
 			started := time.Now()
 
-			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+			return runWithClient(cmd, withSpinner(cmd, "Initializing ragcli...", func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
 				logger := loggerForState(state).With(slog.String("trace_id", req.TraceID))
-				logger.Info("ragadmin.init :: request")
+				logger.Info("ragadmin.init :: request", slog.Bool("dry_run", dryRun))
 
-				kiwixDir, err := ensureKiwixDataDir(state)
+				kiwixDir, err := ensureKiwixDataDir(state, kiwixDir, dryRun)
 				if err != nil {
 					logger.Error("ragadmin.init :: kiwix_dir_error", slog.String("error", err.Error()))
 					return err
@@ -48,31 +63,48 @@ func newInitCommand() *cobra.Command {
 					slog.Int("catalog_version", resp.CatalogVersion),
 				)
 
-				if err := renderInitSummary(cmd.OutOrStdout(), state.OutputFormat, resp, kiwixDir); err != nil {
+				if err := renderInitSummary(cmd.OutOrStdout(), state.OutputFormat, resp, kiwixDir, dryRun, timestampFormatter(relativeTime)); err != nil {
 					return err
 				}
+				var gateErr error
+				if requireDeps {
+					gateErr = dependencyCheckError(resp.DependencyChecks, strict)
+				}
+				if dryRun {
+					return gateErr
+				}
 
 				appendAuditEntry(
 					state,
 					"admin_init",
 					"*",
-					"success",
+					initAuditStatus(gateErr),
 					resp.TraceID,
 					fmt.Sprintf("catalog_version=%d", resp.CatalogVersion),
 				)
-				return nil
-			})
+				return gateErr
+			}))
 		},
 	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the directories and sources init would create without changing anything")
+	cmd.Flags().StringVar(&kiwixDir, "kiwix-dir", "", "Directory for kiwix data (overrides kiwix_data_dir in config)")
+	cmd.Flags().BoolVar(&requireDeps, "require-dependencies", false, "Exit non-zero after the summary when any dependency check fails")
+	cmd.Flags().BoolVar(&strict, "strict", false, "With --require-dependencies, also exit non-zero when a dependency check warns")
+	cmd.Flags().BoolVar(&relativeTime, "relative-time", true, "Show seeded source timestamps relative to now, e.g. \"3 days ago\"")
+	return cmd
 }
 
 // renderInitSummary writes the init response to stdout using the selected format.
-func renderInitSummary(out io.Writer, format string, resp ipc.InitResponse, kiwixDir string) error {
+// Dry runs are prefixed with "(dry run)" so planned changes are not mistaken for applied ones.
+// Seeded source timestamps in the table pass through formatTime.
+func renderInitSummary(out io.Writer, format string, resp ipc.InitResponse, kiwixDir string, dryRun bool, formatTime func(string) string) error {
 	if format == "json" {
 		payload := map[string]any{
 			"init":         resp,
 			"kiwix_dir":    kiwixDir,
 			"seeded_count": len(resp.SeededSources),
+			"dry_run":      dryRun,
 		}
 		data, err := json.MarshalIndent(payload, "", "  ")
 		if err != nil {
@@ -82,16 +114,31 @@ func renderInitSummary(out io.Writer, format string, resp ipc.InitResponse, kiwi
 		return err
 	}
 
+	if dryRun {
+		if _, err := fmt.Fprintln(out, "(dry run) No directories or sources were changed."); err != nil {
+			return err
+		}
+	}
 	if _, err := fmt.Fprintf(out, "Catalog Version: %d\n", resp.CatalogVersion); err != nil {
 		return err
 	}
 	if kiwixDir != "" {
-		if _, err := fmt.Fprintf(out, "Kiwix Data: %s\n", kiwixDir); err != nil {
+		label := kiwixDir
+		if dryRun {
+			if _, err := os.Stat(kiwixDir); errors.Is(err, fs.ErrNotExist) {
+				label = kiwixDir + " (would create)"
+			}
+		}
+		if _, err := fmt.Fprintf(out, "Kiwix Data: %s\n", label); err != nil {
 			return err
 		}
 	}
 	if len(resp.CreatedDirectories) > 0 {
-		if _, err := fmt.Fprintln(out, "Created:"); err != nil {
+		heading := "Created:"
+		if dryRun {
+			heading = "Would create:"
+		}
+		if _, err := fmt.Fprintln(out, heading); err != nil {
 			return err
 		}
 		for _, dir := range resp.CreatedDirectories {
@@ -110,16 +157,17 @@ func renderInitSummary(out io.Writer, format string, resp ipc.InitResponse, kiwi
 		}
 	} else {
 		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
-		if _, err := fmt.Fprintln(tw, "ALIAS\tTYPE\tSTATUS\tLOCATION"); err != nil {
+		if _, err := fmt.Fprintln(tw, "ALIAS\tTYPE\tSTATUS\tLOCATION\tUPDATED"); err != nil {
 			return err
 		}
 		for _, source := range resp.SeededSources {
 			line := fmt.Sprintf(
-				"%s\t%s\t%s\t%s",
+				"%s\t%s\t%s\t%s\t%s",
 				source.Alias,
 				strings.ToUpper(source.Type),
 				strings.ToUpper(source.Status),
 				source.Location,
+				dashIfEmpty(formatTime(source.LastUpdated)),
 			)
 			if _, err := fmt.Fprintln(tw, line); err != nil {
 				return err
@@ -155,10 +203,47 @@ func renderInitSummary(out io.Writer, format string, resp ipc.InitResponse, kiwi
 	return nil
 }
 
+// dependencyCheckError turns failing dependency checks into an ExitError once the init summary has
+// been printed. Warnings only count when strict is set.
+func dependencyCheckError(checks []ipc.DependencyCheck, strict bool) error {
+	var failed, warned []string
+	for _, check := range checks {
+		switch strings.ToLower(strings.TrimSpace(check.Status)) {
+		case "fail":
+			failed = append(failed, formatComponentName(check.Component))
+		case "warn":
+			warned = append(warned, formatComponentName(check.Component))
+		}
+	}
+	switch {
+	case len(failed) > 0:
+		return &ExitError{Code: ExitDependencyFail, Err: fmt.Errorf("dependency checks failed: %s", strings.Join(failed, ", "))}
+	case len(warned) > 0 && strict:
+		return &ExitError{Code: ExitDependencyWarn, Err: fmt.Errorf("dependency checks reported warnings: %s (--strict)", strings.Join(warned, ", "))}
+	default:
+		return nil
+	}
+}
+
+// initAuditStatus records the --require-dependencies outcome in the audit log: "success" when the
+// gate passed, otherwise the same code the JSON error envelope uses (dependency_fail/dependency_warn).
+func initAuditStatus(gateErr error) string {
+	if gateErr == nil {
+		return "success"
+	}
+	return exitCodeName(cliflags.ExitCode(gateErr))
+}
+
 // ensureKiwixDataDir creates the kiwix data directory using the best candidate path.
-func ensureKiwixDataDir(state *runtimeState) (string, error) {
-	candidates := kiwixDirCandidates(state)
+// In dry-run mode nothing is created; each candidate is probed instead, so the path reported is the
+// one a real run would fall back to.
+func ensureKiwixDataDir(state *runtimeState, override string, dryRun bool) (string, error) {
+	candidates := kiwixDirCandidates(state, override)
 	seen := make(map[string]struct{}, len(candidates))
+	prepare := func(dir string) error { return os.MkdirAll(dir, 0o755) }
+	if dryRun {
+		prepare = probeDirCreatable
+	}
 
 	var firstErr error
 	for _, dir := range candidates {
@@ -171,7 +256,7 @@ func ensureKiwixDataDir(state *runtimeState) (string, error) {
 		}
 		seen[dir] = struct{}{}
 
-		if err := os.MkdirAll(dir, 0o755); err != nil {
+		if err := prepare(dir); err != nil {
 			if firstErr == nil {
 				firstErr = err
 			}
@@ -185,8 +270,50 @@ func ensureKiwixDataDir(state *runtimeState) (string, error) {
 	return "", fmt.Errorf("ragadmin: unable to determine kiwix directory")
 }
 
+// accessWriteSearch is the access(2) mode for W_OK|X_OK: entries can be created inside the directory.
+const accessWriteSearch = 0x3
+
+// probeDirCreatable reports the error os.MkdirAll(dir) would hit without creating anything: the
+// directory must already exist, or its nearest existing ancestor must be a directory the current user
+// can create entries in.
+func probeDirCreatable(dir string) error {
+	path := filepath.Clean(dir)
+	for {
+		info, err := os.Stat(path)
+		switch {
+		case err == nil && !info.IsDir():
+			return &fs.PathError{Op: "mkdir", Path: path, Err: syscall.ENOTDIR}
+		case err == nil && path == filepath.Clean(dir):
+			return nil
+		case err == nil:
+			if err := syscall.Access(path, accessWriteSearch); err != nil {
+				return &fs.PathError{Op: "mkdir", Path: path, Err: err}
+			}
+			return nil
+		case !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return err
+		}
+		path = parent
+	}
+}
+
 // kiwixDirCandidates returns ordered directory candidates for kiwix data.
-func kiwixDirCandidates(state *runtimeState) []string {
+// An explicit directory from the flag or config is the sole candidate so a pinned
+// location never silently falls back to another disk.
+func kiwixDirCandidates(state *runtimeState, override string) []string {
+	if explicit := strings.TrimSpace(override); explicit != "" {
+		return []string{explicit}
+	}
+	if state != nil {
+		if explicit := strings.TrimSpace(state.Config.KiwixDataDir()); explicit != "" {
+			return []string{explicit}
+		}
+	}
+
 	var candidates []string
 
 	if custom := strings.TrimSpace(os.Getenv("RAGCLI_DATA_HOME")); custom != "" {
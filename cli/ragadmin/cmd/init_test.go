@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/linux-rag-t2/cli/ragadmin/internal/config"
+	"github.com/linux-rag-t2/cli/shared/cliflags"
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestKiwixDirCandidatesPrecedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("RAGCLI_DATA_HOME", "/srv/ragcli")
+	t.Setenv("XDG_DATA_HOME", "/data/xdg")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+
+	pinned := config.Default()
+	pinned.Ragadmin.KiwixDataDir = "/mnt/bulk/kiwix"
+
+	cases := []struct {
+		name     string
+		state    *runtimeState
+		override string
+		want     []string
+	}{
+		{
+			name:  "fallback chain",
+			state: &runtimeState{Config: config.Default(), ConfigPath: "/etc/ragcli/config.yaml"},
+			want: []string{
+				"/srv/ragcli/kiwix",
+				"/data/xdg/ragcli/kiwix",
+				"/etc/ragcli/kiwix",
+				"/run/user/1000/ragcli/kiwix",
+				filepath.Join(home, ".local", "share", "ragcli", "kiwix"),
+			},
+		},
+		{
+			name:  "config pins directory",
+			state: &runtimeState{Config: pinned, ConfigPath: "/etc/ragcli/config.yaml"},
+			want:  []string{"/mnt/bulk/kiwix"},
+		},
+		{
+			name:     "flag beats config",
+			state:    &runtimeState{Config: pinned},
+			override: " /mnt/fast/kiwix ",
+			want:     []string{"/mnt/fast/kiwix"},
+		},
+		{
+			name:     "flag without state",
+			override: "/mnt/fast/kiwix",
+			want:     []string{"/mnt/fast/kiwix"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := kiwixDirCandidates(tc.state, tc.override); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("kiwixDirCandidates() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEnsureKiwixDataDirCreatesExplicitDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pinned", "kiwix")
+
+	got, err := ensureKiwixDataDir(&runtimeState{Config: config.Default()}, dir, false)
+	if err != nil {
+		t.Fatalf("ensureKiwixDataDir() error = %v", err)
+	}
+	if got != dir {
+		t.Fatalf("ensureKiwixDataDir() = %q, want %q", got, dir)
+	}
+}
+
+func TestEnsureKiwixDataDirDryRunFallsBackLikeARealRun(t *testing.T) {
+	root := t.TempDir()
+	blocker := filepath.Join(root, "blocker")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker: %v", err)
+	}
+	t.Setenv("HOME", root)
+	t.Setenv("RAGCLI_DATA_HOME", blocker)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "xdg"))
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	state := &runtimeState{Config: config.Default()}
+	want := filepath.Join(root, "xdg", "ragcli", "kiwix")
+
+	got, err := ensureKiwixDataDir(state, "", true)
+	if err != nil {
+		t.Fatalf("ensureKiwixDataDir(dry run) error = %v", err)
+	}
+	if got != want {
+		t.Fatalf("ensureKiwixDataDir(dry run) = %q, want the fallback %q", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(root, "xdg")); !os.IsNotExist(err) {
+		t.Fatalf("expected the dry run to create nothing, stat err = %v", err)
+	}
+
+	got, err = ensureKiwixDataDir(state, "", false)
+	if err != nil || got != want {
+		t.Fatalf("ensureKiwixDataDir() = %q, %v; want %q like the dry run", got, err, want)
+	}
+
+	if _, err := ensureKiwixDataDir(state, filepath.Join(blocker, "kiwix"), true); err == nil {
+		t.Fatal("expected a dry run to reject a pinned directory that cannot be created")
+	}
+}
+
+func TestDependencyCheckErrorExitCodes(t *testing.T) {
+	checks := func(statuses ...string) []ipc.DependencyCheck {
+		out := make([]ipc.DependencyCheck, 0, len(statuses))
+		for idx, status := range statuses {
+			component := []string{"ollama", "weaviate"}[idx]
+			out = append(out, ipc.DependencyCheck{Component: component, Status: status})
+		}
+		return out
+	}
+
+	cases := []struct {
+		name   string
+		checks []ipc.DependencyCheck
+		strict bool
+		want   int
+	}{
+		{name: "none", want: ExitOK},
+		{name: "pass", checks: checks("pass", "pass"), strict: true, want: ExitOK},
+		{name: "warn", checks: checks("pass", "warn"), want: ExitOK},
+		{name: "warn strict", checks: checks("pass", "WARN"), strict: true, want: ExitDependencyWarn},
+		{name: "fail", checks: checks("fail", "warn"), want: ExitDependencyFail},
+		{name: "fail strict", checks: checks("warn", "fail"), strict: true, want: ExitDependencyFail},
+	}
+	for _, tc := range cases {
+		if got := cliflags.ExitCode(dependencyCheckError(tc.checks, tc.strict)); got != tc.want {
+			t.Fatalf("%s: cliflags.ExitCode(dependencyCheckError()) = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+
+	err := dependencyCheckError(checks("fail", "fail"), false)
+	if err == nil || err.Error() != "dependency checks failed: Ollama, Weaviate" {
+		t.Fatalf("unexpected error message: %v", err)
+	}
+}
+
+func TestInitAuditStatusRecordsDependencyGate(t *testing.T) {
+	failing := []ipc.DependencyCheck{{Component: "ollama", Status: "fail"}}
+	warning := []ipc.DependencyCheck{{Component: "weaviate", Status: "warn"}}
+
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "gate passed", err: nil, want: "success"},
+		{name: "dependency failed", err: dependencyCheckError(failing, false), want: "dependency_fail"},
+		{name: "dependency warned", err: dependencyCheckError(warning, true), want: "dependency_warn"},
+	}
+	for _, tc := range cases {
+		if got := initAuditStatus(tc.err); got != tc.want {
+			t.Fatalf("%s: initAuditStatus() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
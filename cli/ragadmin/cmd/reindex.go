@@ -6,17 +6,26 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"os"
+	"os/signal"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/linux-rag-t2/cli/shared/termutil"
 	"github.com/spf13/cobra"
 )
 
 func newReindexCommand() *cobra.Command {
 	var opts struct {
-		trigger string
-		force   bool
+		trigger    string
+		force      bool
+		source     string
+		noProgress bool
+		jobLog     bool
+		wait       bool
 	}
 
 	cmd := &cobra.Command{
@@ -31,26 +40,51 @@ func newReindexCommand() *cobra.Command {
 				return fmt.Errorf("unsupported trigger %q (expected manual|init|scheduled)", trigger)
 			}
 
-			req := ipc.ReindexRequest{
-				TraceID: ipc.NewTraceID(),
-				Trigger: trigger,
-				Force:   opts.force,
+			source := strings.TrimSpace(opts.source)
+			if cmd.Flags().Changed("source") && source == "" {
+				return fmt.Errorf("source alias must not be empty")
 			}
-			started := time.Now()
 
+			req := ipc.ReindexRequest{
+				TraceID:     requestTraceID(),
+				Trigger:     trigger,
+				Force:       opts.force,
+				SourceAlias: source,
+			}
 			cmd.SetContext(context.WithValue(cmd.Context(), timeoutKey{}, reindexTimeout))
 
-			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
-				renderer := newReindexProgressRenderer(cmd.OutOrStdout(), state.OutputFormat)
-				job, streamErr := client.StartReindexStream(ctx, req, func(job ipc.IngestionJob) error {
-					return renderer.Handle(job)
+			if !opts.wait {
+				return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+					return queueReindex(ctx, cmd.OutOrStdout(), state, client, req)
 				})
-				elapsed := time.Since(started)
+			}
 
-				if err := renderer.Complete(job, elapsed); err != nil {
-					return err
+			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+				var jobID atomic.Value
+				jobID.Store("")
+				var interrupted atomic.Bool
+				defer cancelReindexOnInterrupt(cmd.ErrOrStderr(), func() {
+					interrupted.Store(true)
+					requestReindexCancel(cmd.ErrOrStderr(), state, jobID.Load().(string))
+				}, func() {
+					drainCtx, cancel := context.WithTimeout(context.Background(), reindexDrainTimeout)
+					defer cancel()
+					_ = client.Shutdown(drainCtx)
+				})()
+
+				var jobLog *reindexJobLog
+				if opts.jobLog {
+					jobLog = newReindexJobLog(state, cmd.ErrOrStderr())
 				}
 
+				renderer := newReindexProgressRenderer(cmd.OutOrStdout(), state.OutputFormat)
+				renderer.hideProgress = opts.noProgress
+				sink := trackingProgressSink{ProgressSink: renderer, onUpdate: func(job ipc.IngestionJob) {
+					jobID.Store(job.JobID)
+					jobLog.Record(job)
+				}}
+				job, streamErr := client.StreamReindex(ctx, req, sink)
+
 				status := normalizedJobStatus(job)
 				target := job.SourceAlias
 				if target == "" {
@@ -59,6 +93,13 @@ func newReindexCommand() *cobra.Command {
 				details := fmt.Sprintf("stage=%s", strings.TrimSpace(job.Stage))
 				appendAuditEntry(state, "index_reindex", target, status, req.TraceID, details)
 
+				if path := jobLog.Path(); path != "" && (streamErr != nil || status != "succeeded") {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Job log: %s\n", path)
+				}
+
+				if interrupted.Load() && (streamErr != nil || status == "cancelled") {
+					return fmt.Errorf("reindex interrupted (job %s)", coalesceJobID(job.JobID, ""))
+				}
 				if streamErr != nil {
 					return streamErr
 				}
@@ -76,6 +117,126 @@ func newReindexCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.trigger, "trigger", "manual", "Reindex trigger (manual|init|scheduled)")
 	cmd.Flags().BoolVar(&opts.force, "force", false, "Force rebuild even if source checksums are unchanged")
+	cmd.Flags().StringVar(&opts.source, "source", "", "Only reindex the source with this alias")
+	cmd.Flags().BoolVar(&opts.noProgress, "no-progress", false, "Only print the final result instead of streaming progress updates")
+	cmd.Flags().BoolVar(&opts.jobLog, "job-log", false, "Append every streamed job update to a JSON-lines log under the data directory")
+	cmd.Flags().BoolVar(&opts.wait, "wait", true, "Follow the job until it finishes; --wait=false returns once the backend has queued it")
+	cmd.AddCommand(newReindexStatusCommand(), newReindexCancelCommand())
+	return cmd
+}
+
+// queueReindex starts a reindex without following its progress stream and reports the
+// accepted job, leaving the backend to finish it in the background.
+func queueReindex(ctx context.Context, out io.Writer, state *runtimeState, client *ipc.Client, req ipc.ReindexRequest) error {
+	job, err := client.StartReindex(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	target := job.SourceAlias
+	if target == "" {
+		target = req.SourceAlias
+	}
+	if target == "" {
+		target = "*"
+	}
+	appendAuditEntry(state, "index_reindex", target, "queued", req.TraceID, fmt.Sprintf("job=%s", job.JobID))
+
+	if err := renderReindexStatus(out, state.OutputFormat, job); err != nil {
+		return err
+	}
+	if state.OutputFormat != "json" {
+		_, err = fmt.Fprintf(out, "Follow progress with: ragadmin reindex status --job %s\n", job.JobID)
+	}
+	return err
+}
+
+// trackingProgressSink observes every snapshot before handing it to the wrapped sink.
+type trackingProgressSink struct {
+	ipc.ProgressSink
+	onUpdate func(ipc.IngestionJob)
+}
+
+func (s trackingProgressSink) Handle(job ipc.IngestionJob) error {
+	s.onUpdate(job)
+	return s.ProgressSink.Handle(job)
+}
+
+// cancelReindexOnInterrupt watches for SIGINT while a reindex streams. The first interrupt asks
+// the backend to cancel the job and then drains the stream so its terminal (cancelled) frame is
+// still rendered; a second one exits immediately. The returned function stops watching for the signal.
+func cancelReindexOnInterrupt(errOut io.Writer, cancelJob, drainStream func()) func() {
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-interrupts:
+		case <-done:
+			return
+		}
+		fmt.Fprintf(errOut, "\nCancelling reindex… waiting up to %s for the stream to finish\n", reindexDrainTimeout)
+		go func() {
+			cancelJob()
+			drainStream()
+		}()
+
+		select {
+		case <-interrupts:
+			os.Exit(ExitInterrupted)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(interrupts)
+		close(done)
+	}
+}
+
+// requestReindexCancel sends a best-effort cancel for jobID over a separate connection,
+// since the interrupted stream still holds the original client. Without a job ID the backend
+// would resolve the request to its latest job, which may belong to another run, so nothing is sent.
+func requestReindexCancel(errOut io.Writer, state *runtimeState, jobID string) {
+	if strings.TrimSpace(jobID) == "" {
+		fmt.Fprintln(errOut, "Interrupted before the backend assigned a job; check `ragadmin reindex status`")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reindexCancelTimeout)
+	defer cancel()
+
+	client, err := newBackendClient(state, false)
+	if err == nil {
+		defer client.Close()
+		_, err = client.CancelReindex(ctx, jobID, ipc.ReindexCancelRequest{TraceID: requestTraceID()})
+	}
+	if err != nil {
+		fmt.Fprintf(errOut, "Could not cancel reindex job %s: %v\n", coalesceJobID("", jobID), err)
+	}
+}
+
+func newReindexStatusCommand() *cobra.Command {
+	var jobID string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show progress of an in-flight or finished reindex job",
+		Long:  "status reports the current stage of a reindex job. Without --job it shows the most recent job, including ones started by another process.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+				job, err := client.GetReindexStatus(ctx, jobID, ipc.ReindexStatusRequest{TraceID: requestTraceID()})
+				if err != nil {
+					return err
+				}
+				return renderReindexStatus(cmd.OutOrStdout(), state.OutputFormat, job)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&jobID, "job", "", "Reindex job ID (defaults to the most recent job)")
 	return cmd
 }
 
@@ -95,6 +256,9 @@ func renderReindexResult(out io.Writer, format string, job ipc.IngestionJob, ela
 
 	status := normalizedJobStatus(job)
 	statusLine := fmt.Sprintf("Reindex %s (job %s)", status, job.JobID)
+	if alias := strings.TrimSpace(job.SourceAlias); alias != "" {
+		statusLine = fmt.Sprintf("Reindex %s for %s (job %s)", status, alias, job.JobID)
+	}
 	stage := strings.TrimSpace(job.Stage)
 	if stage == "" {
 		stage = status
@@ -119,6 +283,91 @@ func renderReindexResult(out io.Writer, format string, job ipc.IngestionJob, ela
 	return err
 }
 
+func newReindexCancelCommand() *cobra.Command {
+	var jobID string
+
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Abort a running reindex job",
+		Long:  "cancel stops a reindex job. Without --job it cancels the most recent job.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+				req := ipc.ReindexCancelRequest{TraceID: requestTraceID()}
+				job, err := client.CancelReindex(ctx, jobID, req)
+				target := coalesceJobID(job.JobID, jobID)
+				if err != nil {
+					appendAuditEntry(state, "index_reindex_cancel", target, "failure", req.TraceID, err.Error())
+					return err
+				}
+
+				status := normalizedJobStatus(job)
+				appendAuditEntry(state, "index_reindex_cancel", target, status, req.TraceID, fmt.Sprintf("stage=%s", strings.TrimSpace(job.Stage)))
+				if status != "cancelled" {
+					return fmt.Errorf("reindex job %s was not cancelled (status %s)", target, status)
+				}
+
+				if state.OutputFormat == "json" {
+					return renderReindexStatus(cmd.OutOrStdout(), state.OutputFormat, job)
+				}
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Reindex cancelled (job %s)\n", target)
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&jobID, "job", "", "Reindex job ID (defaults to the most recent job)")
+	return cmd
+}
+
+// coalesceJobID prefers the backend-reported job ID, then the requested one, then "latest".
+func coalesceJobID(reported, requested string) string {
+	if id := strings.TrimSpace(reported); id != "" {
+		return id
+	}
+	if id := strings.TrimSpace(requested); id != "" {
+		return id
+	}
+	return "latest"
+}
+
+func renderReindexStatus(out io.Writer, format string, job ipc.IngestionJob) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(map[string]any{"job": job}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(append(data, '\n'))
+		return err
+	}
+
+	lines := []string{
+		fmt.Sprintf("Reindex %s (job %s)", normalizedJobStatus(job), job.JobID),
+		fmt.Sprintf("Stage: %s", formatProgressStage(job)),
+	}
+	if job.DocumentsProcessed > 0 {
+		lines = append(lines, fmt.Sprintf("Documents: %d", job.DocumentsProcessed))
+	}
+	if job.RequestedAt != "" {
+		lines = append(lines, fmt.Sprintf("Requested: %s", job.RequestedAt))
+	}
+	if job.StartedAt != "" {
+		lines = append(lines, fmt.Sprintf("Started: %s", job.StartedAt))
+	}
+	if job.CompletedAt != "" {
+		lines = append(lines, fmt.Sprintf("Completed: %s", job.CompletedAt))
+	}
+	if job.ErrorMessage != "" {
+		lines = append(lines, fmt.Sprintf("Error: %s", job.ErrorMessage))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func formatPercent(value float64) string {
 	return fmt.Sprintf("%d%%", int(math.Round(value)))
 }
@@ -139,26 +388,76 @@ func isValidTrigger(value string) bool {
 	}
 }
 
+// etaWindow bounds how many recent progress samples feed the rolling ETA estimate.
+const etaWindow = 5
+
+// Progress bar geometry and glyphs for TTY output.
+const (
+	minProgressBarWidth = 10
+	maxProgressBarWidth = 50
+	progressBarFilled   = "█"
+	progressBarEmpty    = "░"
+	ansiGreen           = "\x1b[32m"
+	ansiReset           = "\x1b[0m"
+)
+
+// progressSample records the percent complete observed at a point in time.
+type progressSample struct {
+	at      time.Time
+	percent float64
+}
+
+// docsSample records the processed document count observed at a point in time.
+type docsSample struct {
+	at   time.Time
+	docs int
+}
+
+// reindexProgressRenderer is the default ipc.ProgressSink, writing either a live progress
+// line for terminals or one JSON event per snapshot.
 type reindexProgressRenderer struct {
 	out           io.Writer
 	format        string
 	lastLineWidth int
 	wroteProgress bool
+	now           func() time.Time
+	samples       []progressSample
+	docsSamples   []docsSample
+	// width is the terminal column count; zero disables the progress bar.
+	width int
+	color bool
+	// hideProgress drops intermediate updates so only the Complete output is written.
+	hideProgress bool
 }
 
+var _ ipc.ProgressSink = (*reindexProgressRenderer)(nil)
+
 func newReindexProgressRenderer(out io.Writer, format string) *reindexProgressRenderer {
-	return &reindexProgressRenderer{
+	renderer := &reindexProgressRenderer{
 		out:    out,
 		format: format,
+		now:    time.Now,
 	}
+	if format != "json" && termutil.IsTerminal(out) {
+		renderer.width = termutil.Width(out)
+		renderer.color = os.Getenv("NO_COLOR") == ""
+	}
+	return renderer
 }
 
 func (r *reindexProgressRenderer) Handle(job ipc.IngestionJob) error {
+	if r.hideProgress {
+		return nil
+	}
+	r.observeDocs(job)
 	if r.format == "json" {
 		payload := map[string]any{
 			"event": "progress",
 			"job":   job,
 		}
+		if rate, ok := r.docsPerSecond(); ok {
+			payload["docs_per_sec"] = math.Round(rate*10) / 10
+		}
 		data, err := json.Marshal(payload)
 		if err != nil {
 			return err
@@ -167,12 +466,17 @@ func (r *reindexProgressRenderer) Handle(job ipc.IngestionJob) error {
 		return err
 	}
 
-	line := r.buildProgressLine(job)
+	r.observe(job)
+	line, width := r.buildProgressBarLine(job)
+	if line == "" {
+		line = r.buildProgressLine(job)
+		width = utf8.RuneCountInString(line)
+	}
 	padding := ""
-	if r.lastLineWidth > len(line) {
-		padding = strings.Repeat(" ", r.lastLineWidth-len(line))
+	if r.lastLineWidth > width {
+		padding = strings.Repeat(" ", r.lastLineWidth-width)
 	}
-	r.lastLineWidth = len(line)
+	r.lastLineWidth = width
 	r.wroteProgress = true
 	_, err := fmt.Fprintf(r.out, "\r%s%s", line, padding)
 	return err
@@ -180,10 +484,14 @@ func (r *reindexProgressRenderer) Handle(job ipc.IngestionJob) error {
 
 func (r *reindexProgressRenderer) Complete(job ipc.IngestionJob, elapsed time.Duration) error {
 	if r.format == "json" {
+		// The normalized fields spare scripts from re-deriving status from error_message.
+		status := normalizedJobStatus(job)
 		payload := map[string]any{
-			"event":       "summary",
-			"job":         job,
-			"duration_ms": elapsed.Milliseconds(),
+			"event":             "summary",
+			"job":               job,
+			"duration_ms":       elapsed.Milliseconds(),
+			"normalized_status": status,
+			"succeeded":         status == "succeeded",
 		}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -208,9 +516,139 @@ func (r *reindexProgressRenderer) buildProgressLine(job ipc.IngestionJob) string
 	if job.DocumentsProcessed > 0 {
 		line = fmt.Sprintf("%s docs=%d", line, job.DocumentsProcessed)
 	}
+	if !isFinishedJobStatus(status) {
+		line += r.formatThroughput(job)
+		line = fmt.Sprintf("%s %s", line, r.formatETA())
+	}
 	return line
 }
 
+// buildProgressBarLine draws a block progress bar sized to the terminal and returns the line with
+// its visible width. It returns an empty line when the bar cannot be drawn, so callers fall back
+// to the text line.
+func (r *reindexProgressRenderer) buildProgressBarLine(job ipc.IngestionJob) (string, int) {
+	if r.width <= 0 || job.PercentComplete == nil {
+		return "", 0
+	}
+
+	status := normalizedJobStatus(job)
+	stage := strings.TrimSpace(job.Stage)
+	if stage == "" {
+		stage = status
+	}
+	prefix := fmt.Sprintf("Reindex %s — %s ", status, stage)
+	suffix := " " + formatPercent(*job.PercentComplete)
+	if job.DocumentsProcessed > 0 {
+		suffix = fmt.Sprintf("%s docs=%d", suffix, job.DocumentsProcessed)
+	}
+	if !isFinishedJobStatus(status) {
+		suffix += r.formatThroughput(job)
+		suffix = fmt.Sprintf("%s %s", suffix, r.formatETA())
+	}
+
+	// Leave one spare column so the cursor never wraps onto the next line.
+	barWidth := r.width - utf8.RuneCountInString(prefix) - utf8.RuneCountInString(suffix) - len("[]") - 1
+	if barWidth < minProgressBarWidth {
+		return "", 0
+	}
+	if barWidth > maxProgressBarWidth {
+		barWidth = maxProgressBarWidth
+	}
+
+	fraction := math.Min(math.Max(*job.PercentComplete/100, 0), 1)
+	filled := int(math.Round(fraction * float64(barWidth)))
+	bar := strings.Repeat(progressBarFilled, filled)
+	if r.color && bar != "" {
+		bar = ansiGreen + bar + ansiReset
+	}
+	bar += strings.Repeat(progressBarEmpty, barWidth-filled)
+
+	line := prefix + "[" + bar + "]" + suffix
+	return line, utf8.RuneCountInString(prefix) + barWidth + len("[]") + utf8.RuneCountInString(suffix)
+}
+
+// observe records the job's percent complete for the ETA estimate. Missing or
+// regressing percentages reset the window so stale rates are not extrapolated.
+func (r *reindexProgressRenderer) observe(job ipc.IngestionJob) {
+	if job.PercentComplete == nil {
+		r.samples = nil
+		return
+	}
+	sample := progressSample{at: r.now(), percent: *job.PercentComplete}
+	if n := len(r.samples); n > 0 && sample.percent < r.samples[n-1].percent {
+		r.samples = nil
+	}
+	r.samples = append(r.samples, sample)
+	if len(r.samples) > etaWindow {
+		r.samples = r.samples[len(r.samples)-etaWindow:]
+	}
+}
+
+// observeDocs records the processed document count for the throughput estimate. A count that
+// goes backwards resets the window so a restarted job does not report a negative rate.
+func (r *reindexProgressRenderer) observeDocs(job ipc.IngestionJob) {
+	sample := docsSample{at: r.now(), docs: job.DocumentsProcessed}
+	if n := len(r.docsSamples); n > 0 && sample.docs < r.docsSamples[n-1].docs {
+		r.docsSamples = nil
+	}
+	r.docsSamples = append(r.docsSamples, sample)
+	if len(r.docsSamples) > etaWindow {
+		r.docsSamples = r.docsSamples[len(r.docsSamples)-etaWindow:]
+	}
+}
+
+// docsPerSecond reports the document rate across the sample window. It needs two samples taken
+// at different times, so the first frame never has a rate.
+func (r *reindexProgressRenderer) docsPerSecond() (float64, bool) {
+	if len(r.docsSamples) < 2 {
+		return 0, false
+	}
+	first, last := r.docsSamples[0], r.docsSamples[len(r.docsSamples)-1]
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return float64(last.docs-first.docs) / elapsed, true
+}
+
+// formatThroughput returns " 12.5 docs/s" for jobs that report documents, or "" when no rate is known.
+func (r *reindexProgressRenderer) formatThroughput(job ipc.IngestionJob) string {
+	if job.DocumentsProcessed <= 0 {
+		return ""
+	}
+	rate, ok := r.docsPerSecond()
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" %.1f docs/s", rate)
+}
+
+// formatETA extrapolates the remaining time from the progress rate across the sample window.
+func (r *reindexProgressRenderer) formatETA() string {
+	const unknown = "ETA unknown"
+	if len(r.samples) < 2 {
+		return unknown
+	}
+	first, last := r.samples[0], r.samples[len(r.samples)-1]
+	progressed := last.percent - first.percent
+	elapsed := last.at.Sub(first.at)
+	if progressed <= 0 || elapsed <= 0 {
+		return unknown
+	}
+	remaining := math.Max(100-last.percent, 0)
+	eta := time.Duration(float64(elapsed) * remaining / progressed)
+	return fmt.Sprintf("ETA ~%s", eta.Round(time.Second))
+}
+
+func isFinishedJobStatus(status string) bool {
+	switch status {
+	case "succeeded", "failed", "cancelled":
+		return true
+	default:
+		return false
+	}
+}
+
 func formatProgressStage(job ipc.IngestionJob) string {
 	stage := strings.TrimSpace(job.Stage)
 	if stage == "" {
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/audit"
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+// reindexJobLogDirName is the directory, next to the audit log, that holds one job log per reindex.
+const reindexJobLogDirName = "reindex-jobs"
+
+// reindexJobLog appends every streamed job snapshot to a per-job JSON-lines file so the full
+// sequence of stage transitions survives a failed reindex. It is kept apart from the audit trail.
+// A nil *reindexJobLog records nothing.
+type reindexJobLog struct {
+	dir    string
+	opts   []audit.Option
+	warn   io.Writer
+	logger *audit.Logger
+}
+
+// newReindexJobLog returns a job log rooted in the data directory that holds the audit log, rotated
+// by the ragadmin.job_log_* settings. Without an audit log there is no data directory, so a warning
+// is written to warn and nil is returned. Write failures are reported once on warn and then ignored.
+func newReindexJobLog(state *runtimeState, warn io.Writer) *reindexJobLog {
+	if state == nil || state.AuditLogger == nil {
+		fmt.Fprintln(warn, "Job log disabled: no audit log directory is available")
+		return nil
+	}
+	return &reindexJobLog{
+		dir:  filepath.Join(filepath.Dir(state.AuditLogger.Path()), reindexJobLogDirName),
+		opts: state.Config.JobLogOptions(),
+		warn: warn,
+	}
+}
+
+// Record appends the snapshot. The log file is named after the first job ID seen.
+func (l *reindexJobLog) Record(job ipc.IngestionJob) {
+	if l == nil || l.dir == "" {
+		return
+	}
+	if l.logger == nil {
+		logger, err := audit.NewLogger(filepath.Join(l.dir, jobLogFileName(job.JobID)), l.opts...)
+		if err != nil {
+			l.disable(err)
+			return
+		}
+		l.logger = logger
+	}
+
+	entry := map[string]any{
+		"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		"job":       job,
+	}
+	if err := l.logger.Append(entry); err != nil {
+		l.disable(err)
+	}
+}
+
+// Path returns the job log file, or "" when nothing has been recorded.
+func (l *reindexJobLog) Path() string {
+	if l == nil || l.logger == nil {
+		return ""
+	}
+	return l.logger.Path()
+}
+
+func (l *reindexJobLog) disable(err error) {
+	fmt.Fprintf(l.warn, "Job log disabled: %v\n", err)
+	l.dir = ""
+	l.logger = nil
+}
+
+// jobLogFileName turns a backend job ID into a safe file name.
+func jobLogFileName(jobID string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimSpace(jobID))
+	if strings.Trim(name, ".") == "" {
+		name = "unknown"
+	}
+	return name + ".jsonl"
+}
@@ -0,0 +1,403 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/linux-rag-t2/cli/ragadmin/internal/config"
+	"github.com/linux-rag-t2/cli/shared/audit"
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func percent(value float64) *float64 {
+	return &value
+}
+
+func TestReindexProgressRendererETA(t *testing.T) {
+	start := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		percents []*float64
+		want     string
+	}{
+		{name: "single sample", percents: []*float64{percent(10)}, want: "ETA unknown"},
+		{name: "steady progress", percents: []*float64{percent(10), percent(20), percent(30)}, want: "ETA ~7m0s"},
+		{name: "missing percent", percents: []*float64{percent(10), percent(20), nil}, want: "ETA unknown"},
+		{name: "stuck", percents: []*float64{percent(40), percent(40), percent(40)}, want: "ETA unknown"},
+		{name: "regression resets window", percents: []*float64{percent(50), percent(60), percent(20)}, want: "ETA unknown"},
+		{name: "recovers after regression", percents: []*float64{percent(50), percent(20), percent(60)}, want: "ETA ~1m0s"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer := newReindexProgressRenderer(io.Discard, "table")
+			tick := 0
+			renderer.now = func() time.Time {
+				return start.Add(time.Duration(tick) * time.Minute)
+			}
+
+			var line string
+			for _, pct := range tc.percents {
+				job := ipc.IngestionJob{Status: "running", Stage: "embedding", PercentComplete: pct}
+				renderer.observe(job)
+				line = renderer.buildProgressLine(job)
+				tick++
+			}
+			if !strings.HasSuffix(line, tc.want) {
+				t.Fatalf("progress line %q does not end with %q", line, tc.want)
+			}
+		})
+	}
+}
+
+func TestReindexProgressRendererOmitsETAWhenFinished(t *testing.T) {
+	renderer := newReindexProgressRenderer(io.Discard, "table")
+	job := ipc.IngestionJob{Status: "succeeded", Stage: "completed", PercentComplete: percent(100)}
+	renderer.observe(job)
+	if line := renderer.buildProgressLine(job); strings.Contains(line, "ETA") {
+		t.Fatalf("expected no ETA for finished job, got %q", line)
+	}
+}
+
+func TestReindexProgressRendererDrawsBarForKnownWidth(t *testing.T) {
+	var out strings.Builder
+	renderer := newReindexProgressRenderer(&out, "table")
+	renderer.width = 80
+
+	job := ipc.IngestionJob{Status: "running", Stage: "chunking", PercentComplete: percent(50), DocumentsProcessed: 128}
+	if err := renderer.Handle(job); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	line := strings.TrimPrefix(out.String(), "\r")
+	if !strings.Contains(line, "[█") || !strings.Contains(line, "░]") {
+		t.Fatalf("expected block progress bar, got %q", line)
+	}
+	if !strings.Contains(line, "50% docs=128") {
+		t.Fatalf("expected percent and docs suffix, got %q", line)
+	}
+	if strings.Contains(line, "\x1b[") {
+		t.Fatalf("expected no ANSI styling without color, got %q", line)
+	}
+	if width := utf8.RuneCountInString(line); width >= renderer.width {
+		t.Fatalf("progress line is %d columns, want fewer than %d", width, renderer.width)
+	}
+	if filled, empty := strings.Count(line, "█"), strings.Count(line, "░"); filled < empty || filled-empty > 1 {
+		t.Fatalf("expected half-filled bar, got %d filled and %d empty", filled, empty)
+	}
+}
+
+func TestReindexProgressRendererFallsBackToText(t *testing.T) {
+	cases := []struct {
+		name  string
+		width int
+		job   ipc.IngestionJob
+	}{
+		{name: "unknown width", width: 0, job: ipc.IngestionJob{Status: "running", Stage: "chunking", PercentComplete: percent(45)}},
+		{name: "missing percent", width: 80, job: ipc.IngestionJob{Status: "running", Stage: "chunking"}},
+		{name: "narrow terminal", width: 30, job: ipc.IngestionJob{Status: "running", Stage: "chunking", PercentComplete: percent(45)}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out strings.Builder
+			renderer := newReindexProgressRenderer(&out, "table")
+			renderer.width = tc.width
+			if err := renderer.Handle(tc.job); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if strings.Contains(out.String(), "█") || !strings.Contains(out.String(), "Stage: chunking") {
+				t.Fatalf("expected plain text progress line, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestCancelReindexOnInterruptCancelsJobThenDrainsStream(t *testing.T) {
+	var stderr syncBuffer
+	jobCancelled := make(chan struct{})
+	streamDrained := make(chan struct{})
+	stop := cancelReindexOnInterrupt(&stderr, func() { close(jobCancelled) }, func() {
+		select {
+		case <-jobCancelled:
+		default:
+			t.Error("stream drained before the cancel request was sent")
+		}
+		close(streamDrained)
+	})
+	defer stop()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatalf("send SIGINT: %v", err)
+	}
+	select {
+	case <-streamDrained:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream drain was not triggered by SIGINT")
+	}
+	if !strings.Contains(stderr.String(), "Cancelling reindex…") {
+		t.Fatalf("expected cancellation notice, got %q", stderr.String())
+	}
+}
+
+func TestRequestReindexCancelSkipsBackendWithoutJobID(t *testing.T) {
+	var stderr bytes.Buffer
+	state := &runtimeState{SocketPath: filepath.Join(t.TempDir(), "missing.sock"), DialTimeout: 50 * time.Millisecond}
+
+	requestReindexCancel(&stderr, state, "")
+
+	output := stderr.String()
+	if !strings.Contains(output, "Interrupted before the backend assigned a job") {
+		t.Fatalf("expected warning about missing job ID, got %q", output)
+	}
+	if strings.Contains(output, "Could not cancel") {
+		t.Fatalf("expected no cancel request without a job ID, got %q", output)
+	}
+}
+
+// syncBuffer guards a bytes.Buffer written from the signal goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestReindexProgressRendererJSONSummaryNormalizesStatus(t *testing.T) {
+	cases := []struct {
+		name      string
+		job       ipc.IngestionJob
+		status    string
+		succeeded bool
+	}{
+		{name: "succeeded", job: ipc.IngestionJob{JobID: "job-1", Status: "SUCCEEDED"}, status: "succeeded", succeeded: true},
+		{name: "error message", job: ipc.IngestionJob{JobID: "job-2", Status: "succeeded", ErrorMessage: "chunking failed"}, status: "failed"},
+		{name: "cancelled", job: ipc.IngestionJob{JobID: "job-3", Status: "cancelled"}, status: "cancelled"},
+	}
+	for _, tc := range cases {
+		var out bytes.Buffer
+		renderer := newReindexProgressRenderer(&out, "json")
+		if err := renderer.Complete(tc.job, 2*time.Second); err != nil {
+			t.Fatalf("%s: Complete() error = %v", tc.name, err)
+		}
+
+		var payload struct {
+			Event            string `json:"event"`
+			NormalizedStatus string `json:"normalized_status"`
+			Succeeded        bool   `json:"succeeded"`
+			DurationMS       int64  `json:"duration_ms"`
+		}
+		if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+			t.Fatalf("%s: decode summary: %v", tc.name, err)
+		}
+		if payload.Event != "summary" || payload.NormalizedStatus != tc.status || payload.Succeeded != tc.succeeded || payload.DurationMS != 2000 {
+			t.Fatalf("%s: unexpected summary %+v", tc.name, payload)
+		}
+	}
+}
+
+func TestReindexProgressRendererHideProgressPrintsOnlyResult(t *testing.T) {
+	updates := []ipc.IngestionJob{
+		{JobID: "job-9", Status: "running", Stage: "discovering", PercentComplete: percent(10)},
+		{JobID: "job-9", Status: "running", Stage: "chunking", PercentComplete: percent(60)},
+	}
+	final := ipc.IngestionJob{JobID: "job-9", Status: "succeeded", Stage: "completed", PercentComplete: percent(100)}
+
+	var jsonOut bytes.Buffer
+	renderer := newReindexProgressRenderer(&jsonOut, "json")
+	renderer.hideProgress = true
+	for _, job := range updates {
+		if err := renderer.Handle(job); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+	if err := renderer.Complete(final, time.Second); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(jsonOut.String()), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], `"event":"summary"`) {
+		t.Fatalf("expected only the summary event, got:\n%s", jsonOut.String())
+	}
+
+	var tableOut, want bytes.Buffer
+	renderer = newReindexProgressRenderer(&tableOut, "table")
+	renderer.hideProgress = true
+	for _, job := range updates {
+		if err := renderer.Handle(job); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+	if err := renderer.Complete(final, time.Second); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if err := renderReindexResult(&want, "table", final, time.Second); err != nil {
+		t.Fatalf("renderReindexResult() error = %v", err)
+	}
+	if tableOut.String() != want.String() {
+		t.Fatalf("expected only the final result, got %q want %q", tableOut.String(), want.String())
+	}
+}
+
+func TestReindexProgressRendererThroughput(t *testing.T) {
+	start := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	clock := func(renderer *reindexProgressRenderer) *int {
+		tick := 0
+		renderer.now = func() time.Time { return start.Add(time.Duration(tick) * 10 * time.Second) }
+		return &tick
+	}
+
+	var out bytes.Buffer
+	renderer := newReindexProgressRenderer(&out, "json")
+	tick := clock(renderer)
+	for _, docs := range []int{0, 100, 250} {
+		if err := renderer.Handle(ipc.IngestionJob{Status: "running", Stage: "chunking", DocumentsProcessed: docs}); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+		*tick++
+	}
+
+	var rates []any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var event map[string]any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("decode progress event: %v", err)
+		}
+		rates = append(rates, event["docs_per_sec"])
+	}
+	if rates[0] != nil {
+		t.Fatalf("expected no rate on the first frame, got %v", rates[0])
+	}
+	if rates[1] != 10.0 || rates[2] != 12.5 {
+		t.Fatalf("unexpected rolling rates %v", rates)
+	}
+
+	renderer = newReindexProgressRenderer(io.Discard, "table")
+	tick = clock(renderer)
+	var line string
+	for _, docs := range []int{500, 900, 100} {
+		job := ipc.IngestionJob{Status: "running", Stage: "embedding", DocumentsProcessed: docs}
+		renderer.observeDocs(job)
+		line = renderer.buildProgressLine(job)
+		if docs == 900 && !strings.Contains(line, "docs=900 40.0 docs/s") {
+			t.Fatalf("expected rate in progress line, got %q", line)
+		}
+		*tick++
+	}
+	if strings.Contains(line, "docs/s") {
+		t.Fatalf("expected a regressing count to reset the rate, got %q", line)
+	}
+}
+
+func TestReindexJobLogRecordsOneLinePerFrame(t *testing.T) {
+	dataDir := t.TempDir()
+	auditLogger, err := audit.NewLogger(filepath.Join(dataDir, "audit.log"))
+	if err != nil {
+		t.Fatalf("audit.NewLogger() error = %v", err)
+	}
+	var warn bytes.Buffer
+	jobLog := newReindexJobLog(&runtimeState{AuditLogger: auditLogger}, &warn)
+
+	frames := []ipc.IngestionJob{
+		{JobID: "job/42", Status: "running", Stage: "discovering"},
+		{JobID: "job/42", Status: "running", Stage: "chunking"},
+		{JobID: "job/42", Status: "failed", Stage: "embedding", ErrorMessage: "ollama unavailable"},
+	}
+	for _, job := range frames {
+		jobLog.Record(job)
+	}
+
+	if want := filepath.Join(dataDir, reindexJobLogDirName, "job_42.jsonl"); jobLog.Path() != want {
+		t.Fatalf("job log path = %q, want %q", jobLog.Path(), want)
+	}
+	data, err := os.ReadFile(jobLog.Path())
+	if err != nil {
+		t.Fatalf("read job log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != len(frames) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(frames), len(lines), data)
+	}
+	for idx, line := range lines {
+		var entry struct {
+			Job ipc.IngestionJob `json:"job"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("decode line %d: %v", idx, err)
+		}
+		if entry.Job.Stage != frames[idx].Stage {
+			t.Fatalf("line %d stage = %q, want %q", idx, entry.Job.Stage, frames[idx].Stage)
+		}
+	}
+	if warn.Len() != 0 {
+		t.Fatalf("unexpected warnings: %s", warn.String())
+	}
+
+	var disabled *reindexJobLog
+	disabled.Record(frames[0])
+	if disabled.Path() != "" {
+		t.Fatal("expected a nil job log to record nothing")
+	}
+}
+
+func TestReindexJobLogWarnsWithoutAuditLog(t *testing.T) {
+	var warn bytes.Buffer
+	if jobLog := newReindexJobLog(&runtimeState{}, &warn); jobLog != nil {
+		t.Fatalf("expected no job log without an audit log, got %+v", jobLog)
+	}
+	if !strings.Contains(warn.String(), "Job log disabled") {
+		t.Fatalf("expected a warning on stderr, got %q", warn.String())
+	}
+}
+
+func TestReindexJobLogUsesItsOwnRotationSettings(t *testing.T) {
+	dataDir := t.TempDir()
+	auditLogger, err := audit.NewLogger(filepath.Join(dataDir, "audit.log"))
+	if err != nil {
+		t.Fatalf("audit.NewLogger() error = %v", err)
+	}
+	cfg := config.Default()
+	backups := 0
+	cfg.Ragadmin.JobLogMaxSizeBytes = 200
+	cfg.Ragadmin.JobLogMaxBackups = &backups
+	var warn bytes.Buffer
+	jobLog := newReindexJobLog(&runtimeState{Config: cfg, AuditLogger: auditLogger}, &warn)
+
+	for i := 0; i < 10; i++ {
+		jobLog.Record(ipc.IngestionJob{JobID: "job-7", Status: "running", Stage: fmt.Sprintf("stage-%d", i)})
+	}
+
+	info, err := os.Stat(jobLog.Path())
+	if err != nil {
+		t.Fatalf("stat job log: %v", err)
+	}
+	if info.Size() > 200 {
+		t.Fatalf("job log size = %d, want it rotated at 200 bytes", info.Size())
+	}
+	if _, err := os.Stat(jobLog.Path() + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("expected job_log_max_backups: 0 to keep no backups, stat err = %v", err)
+	}
+	if warn.Len() != 0 {
+		t.Fatalf("unexpected warnings: %s", warn.String())
+	}
+}
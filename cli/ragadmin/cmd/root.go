@@ -4,14 +4,16 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/linux-rag-t2/cli/ragadmin/internal/audit"
 	"github.com/linux-rag-t2/cli/ragadmin/internal/config"
+	"github.com/linux-rag-t2/cli/shared/audit"
+	"github.com/linux-rag-t2/cli/shared/cliflags"
 	"github.com/linux-rag-t2/cli/shared/ipc"
 	"github.com/spf13/cobra"
 )
@@ -20,34 +22,63 @@ type appStateKey struct{}
 type timeoutKey struct{}
 
 type runtimeState struct {
-	Config       config.Config
-	ConfigPath   string
-	SocketPath   string
-	OutputFormat string
-	Logger       *slog.Logger
-	AuditLogger  *audit.Logger
+	Config        config.Config
+	ConfigPath    string
+	SocketPath    string
+	OutputFormat  string
+	Logger        *slog.Logger
+	DialTimeout   time.Duration
+	RetrySchedule []time.Duration
+	AuditLogger   *audit.Logger
 }
 
 type rootOptions struct {
-	configPath string
-	socketPath string
-	output     string
+	configPath  string
+	socketPath  string
+	output      string
+	traceID     string
+	logLevel    string
+	verbosity   int
+	dialTimeout time.Duration
+	retryDelays string
 }
 
 const (
-	clientID       = "ragadmin-cli"
-	requestTimeout = 15 * time.Second
-	reindexTimeout = 30 * time.Minute
+	defaultClientID    = "ragadmin-cli"
+	defaultDialTimeout = 2 * time.Second
+	requestTimeout     = 15 * time.Second
+	reindexTimeout     = 30 * time.Minute
+	// reindexCancelTimeout bounds the best-effort cancel request sent when a reindex is interrupted.
+	reindexCancelTimeout = 10 * time.Second
+	// reindexDrainTimeout bounds how long an interrupted reindex waits for its stream to finish.
+	reindexDrainTimeout = 10 * time.Second
 )
 
 var (
 	rootCmd  = newRootCommand()
 	rootOpts = &rootOptions{}
+	// offlineGuard keeps outbound HTTP on loopback while a command runs unless --offline=false is given.
+	offlineGuard = &cliflags.OfflineGuard{}
 )
 
-// Execute runs the ragadmin command tree.
+// Execute runs the ragadmin command tree. When `--output json` is in effect and a command fails
+// before writing its own payload, a JSON error envelope is printed to stdout as well.
 func Execute() error {
-	return rootCmd.Execute()
+	// PersistentPostRun is skipped when a command fails, so restore the guard here as well.
+	defer offlineGuard.Disable()
+	return execute(rootCmd, os.Stdout)
+}
+
+// execute runs root with stdout wrapped in an outputTracker so the JSON error envelope is only
+// written when the failing command produced no output of its own.
+func execute(root *cobra.Command, stdout io.Writer) error {
+	out := &outputTracker{w: stdout}
+	root.SetOut(out)
+	err := root.Execute()
+	if err != nil && !out.wrote && failureOutputFormat() == "json" {
+		_ = writeErrorEnvelope(out, err)
+	}
+	return err
 }
 
 func newRootCommand() *cobra.Command {
@@ -56,8 +87,12 @@ func newRootCommand() *cobra.Command {
 		Short: "Manage knowledge sources for the local RAG backend",
 		Long:  "ragadmin administers knowledge sources, reindex operations, and health checks for the local RAG backend over Unix sockets.",
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			offlineGuard.Enable()
 			return initializeState(cmd)
 		},
+		PersistentPostRun: func(*cobra.Command, []string) {
+			offlineGuard.Disable()
+		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
 		},
@@ -69,17 +104,24 @@ func newRootCommand() *cobra.Command {
 	if err != nil {
 		defaultConfigPath = ""
 	}
-	defaultSocket := defaultSocketPath("")
 
 	cmd.PersistentFlags().StringVar(&rootOpts.configPath, "config", defaultConfigPath, "Path to the ragcli configuration file")
-	cmd.PersistentFlags().StringVar(&rootOpts.socketPath, "socket", defaultSocket, "Unix socket path for the rag backend")
-	cmd.PersistentFlags().StringVar(&rootOpts.output, "output", "", "Output format for tabular commands (table|json)")
+	cmd.PersistentFlags().StringVar(&rootOpts.socketPath, "socket", "", "Unix socket path for the rag backend (defaults to shared.socket_path, $RAGCLI_SOCKET, then $XDG_RUNTIME_DIR/ragcli/backend.sock)")
+	offlineGuard.AddFlag(cmd.PersistentFlags())
+	cmd.PersistentFlags().DurationVar(&rootOpts.dialTimeout, "dial-timeout", defaultDialTimeout, "Timeout for connecting to the backend socket (e.g. 500ms, 5s)")
+	cmd.PersistentFlags().StringVar(&rootOpts.retryDelays, "retry-delays", "", "Comma-separated delays between backend read retries (e.g. 250ms,500ms,1s)")
+	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "", "Log level for diagnostics on stderr (debug|info|warn|error); overrides $RAGADMIN_LOG_LEVEL")
+	cmd.PersistentFlags().CountVarP(&rootOpts.verbosity, "verbose", "v", "Increase log verbosity (-v for info, -vv for debug)")
+	cmd.PersistentFlags().StringVar(&rootOpts.traceID, "trace-id", "", "Reuse an external trace ID for backend requests and audit entries instead of generating one")
+	cmd.PersistentFlags().StringVar(&rootOpts.output, "output", "", "Output format for tabular commands (table|json; health also accepts prometheus, sources list also accepts csv)")
 
 	cmd.SetContext(context.Background())
 	cmd.AddCommand(newInitCommand())
 	cmd.AddCommand(newHealthCommand())
 	cmd.AddCommand(newSourcesCommand())
 	cmd.AddCommand(newReindexCommand())
+	cmd.AddCommand(newAuditCommand())
+	cmd.AddCommand(newConfigCommand())
 	return cmd
 }
 
@@ -93,28 +135,51 @@ func initializeState(cmd *cobra.Command) error {
 		return nil
 	}
 
+	if err := validateTraceIDFlag(rootOpts.traceID); err != nil {
+		return err
+	}
+	if err := cliflags.ValidateDialTimeout(rootOpts.dialTimeout); err != nil {
+		return err
+	}
+	retrySchedule, err := ipc.ParseRetrySchedule(rootOpts.retryDelays)
+	if err != nil {
+		return fmt.Errorf("invalid --retry-delays: %w", err)
+	}
+	level, err := cliflags.ResolveLogLevel(rootOpts.logLevel, rootOpts.verbosity, os.Getenv("RAGADMIN_LOG_LEVEL"))
+	if err != nil {
+		return err
+	}
+	logger := newLogger(level)
+
 	cfgPath, err := resolveConfigPath(rootOpts.configPath)
 	if err != nil {
 		return err
 	}
+	logger.Debug("ragadmin loading config", slog.String("path", cfgPath))
 	cfg, err := config.Load(cfgPath)
 	if err != nil {
+		logger.Debug("ragadmin config load failed", slog.String("path", cfgPath), slog.String("error", err.Error()))
 		return err
 	}
 
 	output := resolveOutputFormat(rootOpts.output, cfg.Output())
-	auditLogger, err := audit.NewLogger("")
+	if err := validateOutputFormat(cmd, output); err != nil {
+		return err
+	}
+	auditLogger, err := audit.NewLoggerWithRedaction("", cfg.AuditRedaction(), cfg.AuditOptions()...)
 	if err != nil {
 		return err
 	}
 
 	state := &runtimeState{
-		Config:       cfg,
-		ConfigPath:   cfgPath,
-		SocketPath:   defaultSocketPath(rootOpts.socketPath),
-		OutputFormat: output,
-		Logger:       newLogger(),
-		AuditLogger:  auditLogger,
+		Config:        cfg,
+		ConfigPath:    cfgPath,
+		SocketPath:    cliflags.ResolveSocketPath(rootOpts.socketPath, cfg.SocketPath()),
+		OutputFormat:  output,
+		Logger:        logger,
+		DialTimeout:   rootOpts.dialTimeout,
+		RetrySchedule: retrySchedule,
+		AuditLogger:   auditLogger,
 	}
 
 	root.SetContext(context.WithValue(ctx, appStateKey{}, state))
@@ -140,17 +205,23 @@ func resolveConfigPath(flagValue string) (string, error) {
 	return config.DefaultPath()
 }
 
-func defaultSocketPath(flagValue string) string {
-	if trimmed := strings.TrimSpace(flagValue); trimmed != "" {
-		return trimmed
+// validateTraceIDFlag rejects a malformed --trace-id before any request is built.
+func validateTraceIDFlag(value string) error {
+	if value == "" {
+		return nil
 	}
-	if env := strings.TrimSpace(os.Getenv("RAGCLI_SOCKET")); env != "" {
-		return env
+	if err := ipc.ValidateTraceID(value); err != nil {
+		return fmt.Errorf("invalid --trace-id: %w", err)
 	}
-	if runtimeDir := strings.TrimSpace(os.Getenv("XDG_RUNTIME_DIR")); runtimeDir != "" {
-		return filepath.Join(runtimeDir, "ragcli", "backend.sock")
+	return nil
+}
+
+// requestTraceID returns the --trace-id value when given, otherwise a fresh trace identifier.
+func requestTraceID() string {
+	if rootOpts.traceID != "" {
+		return rootOpts.traceID
 	}
-	return filepath.Join(os.TempDir(), "ragcli", "backend.sock")
+	return ipc.NewTraceID()
 }
 
 func resolveOutputFormat(flagValue, configValue string) string {
@@ -161,25 +232,34 @@ func resolveOutputFormat(flagValue, configValue string) string {
 	switch candidate {
 	case "json":
 		return "json"
+	case outputPrometheus:
+		return outputPrometheus
+	case outputCSV:
+		return outputCSV
 	default:
 		return "table"
 	}
 }
 
-func newLogger() *slog.Logger {
-	level := slog.LevelWarn
-	if raw := strings.TrimSpace(os.Getenv("RAGADMIN_LOG_LEVEL")); raw != "" {
-		switch strings.ToLower(raw) {
-		case "debug":
-			level = slog.LevelDebug
-		case "info":
-			level = slog.LevelInfo
-		case "warn", "warning":
-			level = slog.LevelWarn
-		case "error":
-			level = slog.LevelError
+// outputFormatsAnnotation lists, comma-separated, the --output formats beyond table and json that a
+// command can render.
+const outputFormatsAnnotation = "ragadmin/output-formats"
+
+// validateOutputFormat rejects a format the running command cannot render instead of letting it fall
+// back to a table.
+func validateOutputFormat(cmd *cobra.Command, format string) error {
+	if format == "table" || format == "json" {
+		return nil
+	}
+	for _, supported := range strings.Split(cmd.Annotations[outputFormatsAnnotation], ",") {
+		if supported == format {
+			return nil
 		}
 	}
+	return fmt.Errorf("--output %s is not supported by %s (expected table or json)", format, cmd.CommandPath())
+}
+
+func newLogger(level slog.Level) *slog.Logger {
 	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
 	return slog.New(handler)
 }
@@ -201,15 +281,35 @@ func runWithClient(cmd *cobra.Command, fn func(context.Context, *runtimeState, *
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	client, err := ipc.NewClient(ipc.Config{
-		SocketPath: state.SocketPath,
-		ClientID:   clientID,
-		Logger:     state.Logger,
-	})
+	client, err := newBackendClient(state, false)
 	if err != nil {
-		return err
+		return &ExitError{Code: ExitBackendUnavailable, Err: err}
 	}
 	defer client.Close()
 
 	return fn(ctx, state, client)
 }
+
+// newBackendClient dials the backend socket configured in state. Long-running
+// commands enable autoReconnect so a backend restart does not end the session.
+// The handshake is validated eagerly so an incompatible backend is rejected before
+// any mutating request is sent.
+func newBackendClient(state *runtimeState, autoReconnect bool) (*ipc.Client, error) {
+	return ipc.NewClient(ipc.Config{
+		SocketPath:     state.SocketPath,
+		ClientID:       clientIDForState(state),
+		Logger:         state.Logger,
+		DialTimeout:    state.DialTimeout,
+		RetrySchedule:  state.RetrySchedule,
+		AutoReconnect:  autoReconnect,
+		EagerHandshake: true,
+	})
+}
+
+// clientIDForState returns the configured handshake client identifier, falling back to the built-in one.
+func clientIDForState(state *runtimeState) string {
+	if id := state.Config.ClientID(); id != "" {
+		return id
+	}
+	return defaultClientID
+}
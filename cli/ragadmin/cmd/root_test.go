@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/linux-rag-t2/cli/shared/termutil"
+	"github.com/spf13/cobra"
+)
+
+func TestOutputFormatRejectedOnCommandsThatCannotRenderIt(t *testing.T) {
+	t.Setenv("RAGCLI_CONFIG", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Cleanup(func() { rootOpts.output = "" })
+
+	cases := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "prometheus on sources show", args: []string{"--output", "prometheus", "sources", "show", "man-pages"}, wantErr: "--output prometheus is not supported by ragadmin sources show"},
+		{name: "csv on health", args: []string{"--output", "csv", "health"}, wantErr: "--output csv is not supported by ragadmin health"},
+		{name: "csv on reindex status", args: []string{"--output", "csv", "reindex", "status", "--job", "job-1"}, wantErr: "--output csv is not supported by ragadmin reindex status"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := newRootCommand()
+			root.SetArgs(tc.args)
+			err := root.Execute()
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Execute() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecuteWriterKeepsTerminalDetection(t *testing.T) {
+	t.Setenv("RAGCLI_CONFIG", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	// /dev/null is a character device, so it stands in for a TTY behind the output tracker.
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("open %s: %v", os.DevNull, err)
+	}
+	defer devNull.Close()
+
+	var sawTerminal bool
+	root := newRootCommand()
+	root.AddCommand(&cobra.Command{
+		Use: "probe",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			sawTerminal = termutil.IsTerminal(cmd.OutOrStdout())
+			return nil
+		},
+	})
+	root.SetArgs([]string{"probe"})
+	if err := execute(root, devNull); err != nil {
+		t.Fatalf("execute() error = %v", err)
+	}
+	if !sawTerminal {
+		t.Fatal("expected the tracked stdout writer to still report a terminal")
+	}
+}
@@ -1,15 +1,24 @@
 package cmd
 
 import (
+	"bufio"
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/linux-rag-t2/cli/shared/termutil"
 	"github.com/spf13/cobra"
 )
 
@@ -27,27 +36,93 @@ func newSourcesCommand() *cobra.Command {
 			return cmd.Help()
 		},
 	}
+	var si bool
+	cmd.PersistentFlags().BoolVar(&si, "si", false, "Show sizes in SI units (KB, MB, ...) instead of binary units (KiB, MiB, ...)")
 
 	cmd.AddCommand(
-		newSourcesListCommand(),
+		newSourcesListCommand(&si),
+		newSourcesShowCommand(&si),
 		newSourcesAddCommand(),
 		newSourcesUpdateCommand(),
 		newSourcesRemoveCommand(),
+		newSourcesExportCommand(),
+		newSourcesImportCommand(),
+		newSourcesDiffCommand(&si),
 	)
 	return cmd
 }
 
-func newSourcesListCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:   "list",
-		Short: "List catalogued knowledge sources",
+// newSourcesListCommand constructs `sources list`; si points at the `sources --si` flag value.
+func newSourcesListCommand(si *bool) *cobra.Command {
+	var (
+		filter       sourceFilter
+		sortBy       string
+		reverse      bool
+		fields       string
+		relativeTime bool
+	)
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Short:       "List catalogued knowledge sources",
+		Annotations: map[string]string{outputFormatsAnnotation: outputCSV},
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			filter = filter.normalized()
+			if filter.sourceType != "" && !isValidSourceType(filter.sourceType) {
+				return fmt.Errorf("unsupported source type %q (expected man|kiwix|info)", filter.sourceType)
+			}
+			if filter.status != "" && !isValidSourceStatus(filter.status) {
+				return fmt.Errorf("unsupported status %q (expected pending_validation|active|quarantined|error)", filter.status)
+			}
+			sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+			if sortBy != "" && !isValidSourceSortKey(sortBy) {
+				return fmt.Errorf("unsupported sort key %q (expected alias|size|status|updated)", sortBy)
+			}
+			columns, err := parseSourceFields(fields)
+			if err != nil {
+				return err
+			}
+
 			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
-				resp, err := client.ListSources(ctx, ipc.SourceListRequest{TraceID: ipc.NewTraceID()})
+				resp, err := client.ListSources(ctx, ipc.SourceListRequest{TraceID: requestTraceID()})
 				if err != nil {
 					return err
 				}
-				return renderSourceList(cmd.OutOrStdout(), state.OutputFormat, resp)
+				resp.Sources = filterSources(resp.Sources, filter)
+				sortSources(resp.Sources, sortBy, reverse)
+				return renderSourceList(cmd.OutOrStdout(), state.OutputFormat, resp, columns, timestampFormatter(relativeTime), sizeFormatter(*si))
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&filter.sourceType, "type", "", "Only show sources of this type (man|kiwix|info)")
+	cmd.Flags().StringVar(&filter.status, "status", "", "Only show sources with this status (pending_validation|active|quarantined|error)")
+	cmd.Flags().StringVar(&filter.language, "language", "", "Only show sources in this language")
+	cmd.Flags().StringVar(&sortBy, "sort-by", "", "Sort sources by alias|size|status|updated")
+	cmd.Flags().BoolVar(&reverse, "reverse", false, "Reverse the sort order")
+	cmd.Flags().BoolVar(&relativeTime, "relative-time", true, "Show table timestamps relative to now, e.g. \"3 days ago\"")
+	cmd.Flags().StringVar(&fields, "fields", "", "Comma-separated table columns to show, in order ("+strings.Join(sourceFieldNames, "|")+")")
+	return cmd
+}
+
+// newSourcesShowCommand constructs `sources show`; si points at the `sources --si` flag value.
+func newSourcesShowCommand(si *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <alias>",
+		Short: "Show every catalog field for a single source",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			alias := strings.TrimSpace(args[0])
+			if alias == "" {
+				return fmt.Errorf("alias must be provided")
+			}
+
+			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+				record, err := client.GetSource(ctx, alias, ipc.SourceGetRequest{TraceID: requestTraceID()})
+				if err != nil {
+					return err
+				}
+				return renderSourceDetail(cmd.OutOrStdout(), state.OutputFormat, record, sizeFormatter(*si))
 			})
 		},
 	}
@@ -61,6 +136,7 @@ func newSourcesAddCommand() *cobra.Command {
 		language   string
 		notes      string
 		checksum   string
+		compute    bool
 	}
 
 	cmd := &cobra.Command{
@@ -75,11 +151,29 @@ func newSourcesAddCommand() *cobra.Command {
 			if opts.path == "" {
 				return fmt.Errorf("path is required")
 			}
+			if err := ipc.ValidateSourceLocation(opts.path); err != nil {
+				return err
+			}
 			if opts.language = strings.TrimSpace(opts.language); opts.language == "" {
 				opts.language = "en"
 			}
+			opts.checksum = strings.TrimSpace(opts.checksum)
+			if opts.compute {
+				if opts.checksum != "" {
+					return fmt.Errorf("--checksum and --compute-checksum are mutually exclusive")
+				}
+				checksum, ok, err := computeSourceChecksum(opts.path)
+				if err != nil {
+					return err
+				}
+				if ok {
+					opts.checksum = checksum
+				} else {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Skipping checksum: %s is not a local file\n", opts.path)
+				}
+			}
 
-			traceID := ipc.NewTraceID()
+			traceID := requestTraceID()
 			req := ipc.SourceCreateRequest{
 				TraceID:  traceID,
 				Alias:    strings.TrimSpace(opts.alias),
@@ -87,7 +181,7 @@ func newSourcesAddCommand() *cobra.Command {
 				Location: opts.path,
 				Language: opts.language,
 				Notes:    strings.TrimSpace(opts.notes),
-				Checksum: strings.TrimSpace(opts.checksum),
+				Checksum: opts.checksum,
 			}
 
 			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
@@ -110,12 +204,38 @@ func newSourcesAddCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.language, "language", "en", "Content language (default: en)")
 	cmd.Flags().StringVar(&opts.notes, "notes", "", "Optional notes describing the source")
 	cmd.Flags().StringVar(&opts.checksum, "checksum", "", "Optional checksum override")
+	cmd.Flags().BoolVar(&opts.compute, "compute-checksum", false, "Compute a SHA-256 checksum from a local file path")
 	_ = cmd.MarkFlagRequired("type")
 	_ = cmd.MarkFlagRequired("path")
 
 	return cmd
 }
 
+// checksumBufferSize matches the backend's read size so multi-gigabyte ZIM files stream in bounded memory.
+const checksumBufferSize = 1 << 20
+
+// computeSourceChecksum hashes a local file in the backend's "sha256:<hex>" format. Locations that
+// are not regular local files (directories, man:/info: references, missing paths) report ok=false.
+func computeSourceChecksum(location string) (checksum string, ok bool, err error) {
+	path := strings.TrimPrefix(location, "file://")
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", false, fmt.Errorf("compute checksum: %w", err)
+	}
+	defer file.Close()
+
+	digest := sha256.New()
+	if _, err := io.Copy(digest, bufio.NewReaderSize(file, checksumBufferSize)); err != nil {
+		return "", false, fmt.Errorf("compute checksum: %w", err)
+	}
+	return "sha256:" + hex.EncodeToString(digest.Sum(nil)), true, nil
+}
+
 func newSourcesUpdateCommand() *cobra.Command {
 	var opts struct {
 		path     string
@@ -135,10 +255,13 @@ func newSourcesUpdateCommand() *cobra.Command {
 			}
 
 			req := ipc.SourceUpdateRequest{
-				TraceID: ipc.NewTraceID(),
+				TraceID: requestTraceID(),
 			}
 
 			if trimmed := strings.TrimSpace(opts.path); trimmed != "" {
+				if err := ipc.ValidateSourceLocation(trimmed); err != nil {
+					return err
+				}
 				req.Location = trimmed
 			}
 			if trimmed := strings.TrimSpace(opts.language); trimmed != "" {
@@ -181,7 +304,10 @@ func newSourcesUpdateCommand() *cobra.Command {
 }
 
 func newSourcesRemoveCommand() *cobra.Command {
-	var reason string
+	var (
+		reason    string
+		assumeYes bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "remove <alias>",
@@ -197,8 +323,21 @@ func newSourcesRemoveCommand() *cobra.Command {
 				return fmt.Errorf("reason must be provided")
 			}
 
+			if !assumeYes {
+				in := cmd.InOrStdin()
+				// The exchange goes to stderr so stdout carries only the command's table or JSON payload.
+				confirmed, err := confirmSourceRemoval(in, cmd.ErrOrStderr(), alias, termutil.IsTerminal(in))
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					_, err := fmt.Fprintf(cmd.ErrOrStderr(), "Removal cancelled; %s was not quarantined\n", alias)
+					return err
+				}
+			}
+
 			req := ipc.SourceRemoveRequest{
-				TraceID: ipc.NewTraceID(),
+				TraceID: requestTraceID(),
 				Reason:  reason,
 			}
 			traceID := req.TraceID
@@ -219,13 +358,118 @@ func newSourcesRemoveCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&reason, "reason", "", "Reason for removal/quarantine")
+	cmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation prompt (required when stdin is not a terminal)")
 	_ = cmd.MarkFlagRequired("reason")
 	return cmd
 }
 
-func renderSourceList(out io.Writer, format string, resp ipc.SourceListResponse) error {
+// confirmSourceRemoval asks the operator to confirm a quarantine, defaulting to no.
+// Non-interactive input is refused outright so scripts fail fast instead of hanging on the prompt.
+func confirmSourceRemoval(in io.Reader, out io.Writer, alias string, interactive bool) (bool, error) {
+	if !interactive {
+		return false, fmt.Errorf("refusing to quarantine %s without confirmation: stdin is not a terminal (pass --yes to skip the prompt)", alias)
+	}
+	if _, err := fmt.Fprintf(out, "Are you sure you want to quarantine %s? (y/N) ", alias); err != nil {
+		return false, err
+	}
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// sourceColumn is one selectable column of the `sources list` table.
+type sourceColumn struct {
+	name   string
+	header string
+	// value renders the table cell; formatSize is the size formatter selected by `sources --si`.
+	value func(src ipc.SourceRecord, formatSize func(int64) string) string
+	// raw, when set, replaces value in CSV output, where unformatted values import better.
+	raw func(ipc.SourceRecord) string
+}
+
+// sourceFieldNames lists the --fields names in the order they are documented.
+var sourceFieldNames = []string{"alias", "type", "status", "language", "size", "location", "checksum", "notes", "last_updated"}
+
+var sourceColumns = map[string]sourceColumn{
+	"alias":    {header: "ALIAS", value: func(src ipc.SourceRecord, _ func(int64) string) string { return src.Alias }},
+	"type":     {header: "TYPE", value: func(src ipc.SourceRecord, _ func(int64) string) string { return strings.ToLower(src.Type) }},
+	"status":   {header: "STATUS", value: func(src ipc.SourceRecord, _ func(int64) string) string { return strings.ToLower(src.Status) }},
+	"language": {header: "LANGUAGE", value: func(src ipc.SourceRecord, _ func(int64) string) string { return src.Language }},
+	"size": {
+		header: "SIZE",
+		value:  func(src ipc.SourceRecord, formatSize func(int64) string) string { return formatSize(src.SizeBytes) },
+		raw:    func(src ipc.SourceRecord) string { return strconv.FormatInt(src.SizeBytes, 10) },
+	},
+	"location": {header: "LOCATION", value: func(src ipc.SourceRecord, _ func(int64) string) string { return src.Location }},
+	"checksum": {
+		header: "CHECKSUM",
+		value:  func(src ipc.SourceRecord, _ func(int64) string) string { return dashIfEmpty(src.Checksum) },
+		raw:    func(src ipc.SourceRecord) string { return src.Checksum },
+	},
+	"notes": {
+		header: "NOTES",
+		value:  func(src ipc.SourceRecord, _ func(int64) string) string { return dashIfEmpty(src.Notes) },
+		raw:    func(src ipc.SourceRecord) string { return src.Notes },
+	},
+	"last_updated": {
+		header: "LAST UPDATED",
+		value:  func(src ipc.SourceRecord, _ func(int64) string) string { return dashIfEmpty(src.LastUpdated) },
+		raw:    func(src ipc.SourceRecord) string { return src.LastUpdated },
+	},
+}
+
+// defaultSourceFields is the column set shown when --fields is not given.
+var defaultSourceFields = []string{"alias", "type", "status", "language", "size", "location"}
+
+// parseSourceFields resolves a comma-separated --fields value into table columns, keeping the
+// requested order. An empty value selects the default columns.
+func parseSourceFields(spec string) ([]sourceColumn, error) {
+	names := defaultSourceFields
+	if strings.TrimSpace(spec) != "" {
+		names = strings.Split(spec, ",")
+	}
+
+	columns := make([]sourceColumn, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		column, ok := sourceColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q (expected %s)", name, strings.Join(sourceFieldNames, "|"))
+		}
+		column.name = name
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// renderSourceList prints the catalog as a table of the given columns, passing timestamps through
+// formatTime and sizes through formatSize. JSON output always carries every field unchanged; CSV
+// output carries only the rows.
+func renderSourceList(out io.Writer, format string, resp ipc.SourceListResponse, columns []sourceColumn, formatTime func(string) string, formatSize func(int64) string) error {
+	if format == outputCSV {
+		return renderSourceListCSV(out, resp.Sources, columns)
+	}
+	summary := summarizeSources(resp.Sources)
 	if format == "json" {
-		data, err := json.MarshalIndent(resp, "", "  ")
+		payload := struct {
+			ipc.SourceListResponse
+			Summary catalogSummary `json:"summary"`
+		}{resp, summary}
+		data, err := json.MarshalIndent(payload, "", "  ")
 		if err != nil {
 			return err
 		}
@@ -233,31 +477,124 @@ func renderSourceList(out io.Writer, format string, resp ipc.SourceListResponse)
 		return err
 	}
 
+	cells := make([]string, len(columns))
+	for idx, column := range columns {
+		cells[idx] = column.header
+	}
 	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
-	if _, err := fmt.Fprintln(tw, "ALIAS\tTYPE\tSTATUS\tLANGUAGE\tSIZE\tLOCATION"); err != nil {
+	if _, err := fmt.Fprintln(tw, strings.Join(cells, "\t")); err != nil {
 		return err
 	}
 	for _, src := range resp.Sources {
-		if _, err := fmt.Fprintf(
-			tw,
-			"%s\t%s\t%s\t%s\t%s\t%s\n",
-			src.Alias,
-			strings.ToLower(src.Type),
-			strings.ToLower(src.Status),
-			src.Language,
-			formatBytes(src.SizeBytes),
-			src.Location,
-		); err != nil {
+		src.LastUpdated = formatTime(src.LastUpdated)
+		for idx, column := range columns {
+			cells[idx] = column.value(src, formatSize)
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(cells, "\t")); err != nil {
 			return err
 		}
 	}
 	if err := tw.Flush(); err != nil {
 		return err
 	}
-	_, err := fmt.Fprintf(out, "\nCatalog updated: %s\n", resp.UpdatedAt)
+	if err := renderCatalogSummary(out, summary, formatSize); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "Catalog updated: %s\n", formatTime(resp.UpdatedAt))
+	return err
+}
+
+// catalogSummary aggregates a source listing for the `sources list` footer and JSON summary.
+type catalogSummary struct {
+	Total     int            `json:"total"`
+	SizeBytes int64          `json:"size_bytes"`
+	ByStatus  map[string]int `json:"by_status"`
+	ByType    map[string]int `json:"by_type"`
+}
+
+// summarizeSources counts sources by lowercased status and type and totals their size.
+func summarizeSources(sources []ipc.SourceRecord) catalogSummary {
+	summary := catalogSummary{
+		Total:    len(sources),
+		ByStatus: map[string]int{},
+		ByType:   map[string]int{},
+	}
+	for _, src := range sources {
+		summary.SizeBytes += src.SizeBytes
+		summary.ByStatus[strings.ToLower(src.Status)]++
+		summary.ByType[strings.ToLower(src.Type)]++
+	}
+	return summary
+}
+
+func renderCatalogSummary(out io.Writer, summary catalogSummary, formatSize func(int64) string) error {
+	noun := "sources"
+	if summary.Total == 1 {
+		noun = "source"
+	}
+	if _, err := fmt.Fprintf(out, "\n%d %s, %s total\n", summary.Total, noun, formatSize(summary.SizeBytes)); err != nil {
+		return err
+	}
+	if summary.Total == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(out, "By status: %s\n", formatCounts(summary.ByStatus)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "By type: %s\n", formatCounts(summary.ByType))
 	return err
 }
 
+// formatCounts renders a count map as "key n, key n" sorted by key.
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s %d", dashIfEmpty(key), counts[key]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func renderSourceDetail(out io.Writer, format string, src ipc.SourceRecord, formatSize func(int64) string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(src, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(append(data, '\n'))
+		return err
+	}
+
+	rows := [][2]string{
+		{"Alias", src.Alias},
+		{"Type", strings.ToLower(src.Type)},
+		{"Status", strings.ToLower(src.Status)},
+		{"Language", src.Language},
+		{"Size", fmt.Sprintf("%s (%d bytes)", formatSize(src.SizeBytes), src.SizeBytes)},
+		{"Location", src.Location},
+		{"Last Updated", src.LastUpdated},
+		{"Checksum", src.Checksum},
+		{"Notes", src.Notes},
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		value := row[1]
+		if value == "" {
+			value = "-"
+		}
+		if _, err := fmt.Fprintf(tw, "%s:\t%s\n", row[0], value); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
 func renderSourceMutation(out io.Writer, format string, kind string, resp ipc.SourceMutationResponse) error {
 	if format == "json" {
 		data, err := json.MarshalIndent(resp, "", "  ")
@@ -313,21 +650,131 @@ func renderSourceMutation(out io.Writer, format string, kind string, resp ipc.So
 	return nil
 }
 
-func formatBytes(size int64) string {
-	const unit = 1024
-	if size <= 0 {
-		return "0B"
+// sourceFilter narrows a catalog listing; empty fields match every source.
+type sourceFilter struct {
+	sourceType string
+	status     string
+	language   string
+}
+
+func (f sourceFilter) normalized() sourceFilter {
+	return sourceFilter{
+		sourceType: strings.ToLower(strings.TrimSpace(f.sourceType)),
+		status:     strings.ToLower(strings.TrimSpace(f.status)),
+		language:   strings.ToLower(strings.TrimSpace(f.language)),
 	}
-	if size < unit {
-		return fmt.Sprintf("%dB", size)
+}
+
+func (f sourceFilter) matches(src ipc.SourceRecord) bool {
+	if f.sourceType != "" && !strings.EqualFold(src.Type, f.sourceType) {
+		return false
+	}
+	if f.status != "" && !strings.EqualFold(src.Status, f.status) {
+		return false
+	}
+	if f.language != "" && !strings.EqualFold(src.Language, f.language) {
+		return false
+	}
+	return true
+}
+
+// filterSources returns the sources matching every populated filter field, preserving order.
+func filterSources(sources []ipc.SourceRecord, filter sourceFilter) []ipc.SourceRecord {
+	filter = filter.normalized()
+	filtered := make([]ipc.SourceRecord, 0, len(sources))
+	for _, src := range sources {
+		if filter.matches(src) {
+			filtered = append(filtered, src)
+		}
+	}
+	return filtered
+}
+
+// sortSources orders sources in place by key; ties keep their catalog order even when reversed.
+// An empty key leaves the backend ordering untouched.
+func sortSources(sources []ipc.SourceRecord, key string, reverse bool) {
+	var compare func(a, b ipc.SourceRecord) int
+	switch key {
+	case "alias":
+		compare = func(a, b ipc.SourceRecord) int {
+			return strings.Compare(strings.ToLower(a.Alias), strings.ToLower(b.Alias))
+		}
+	case "size":
+		compare = func(a, b ipc.SourceRecord) int {
+			return cmp.Compare(a.SizeBytes, b.SizeBytes)
+		}
+	case "status":
+		compare = func(a, b ipc.SourceRecord) int {
+			return strings.Compare(strings.ToLower(a.Status), strings.ToLower(b.Status))
+		}
+	case "updated":
+		compare = func(a, b ipc.SourceRecord) int {
+			return compareTimestamps(a.LastUpdated, b.LastUpdated)
+		}
+	default:
+		return
 	}
-	div, exp := int64(unit), 0
-	for n := size / unit; n >= unit; n /= unit {
+
+	sort.SliceStable(sources, func(i, j int) bool {
+		if reverse {
+			return compare(sources[j], sources[i]) < 0
+		}
+		return compare(sources[i], sources[j]) < 0
+	})
+}
+
+// compareTimestamps orders RFC 3339 timestamps chronologically, falling back to lexical order
+// when either value does not parse.
+func compareTimestamps(a, b string) int {
+	ta, errA := time.Parse(time.RFC3339, a)
+	tb, errB := time.Parse(time.RFC3339, b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return ta.Compare(tb)
+}
+
+func isValidSourceSortKey(value string) bool {
+	switch value {
+	case "alias", "size", "status", "updated":
+		return true
+	default:
+		return false
+	}
+}
+
+// sizeFormatter returns the formatter table renderers apply to byte counts: SI units (KB, MB, ...)
+// when si is set by `sources --si`, otherwise binary units (KiB, MiB, ...).
+func sizeFormatter(si bool) func(int64) string {
+	return func(size int64) string { return formatSize(size, si) }
+}
+
+// formatSize scales size to the largest unit that keeps the value at or above one. Negative sizes
+// keep their sign, and values beyond the largest prefix stay in exabytes.
+func formatSize(size int64, si bool) string {
+	const prefixes = "KMGTPE"
+	unit, suffix := uint64(1024), "iB"
+	if si {
+		unit, suffix = 1000, "B"
+	}
+
+	sign := ""
+	magnitude := uint64(size)
+	if size < 0 {
+		sign = "-"
+		magnitude = -magnitude
+	}
+	if magnitude < unit {
+		return fmt.Sprintf("%s%dB", sign, magnitude)
+	}
+
+	div, exp := unit, 0
+	for n := magnitude / unit; n >= unit && exp < len(prefixes)-1; n /= unit {
 		div *= unit
 		exp++
 	}
-	value := float64(size) / float64(div)
-	return fmt.Sprintf("%.1f%ciB", value, "KMGTPE"[exp])
+	value := float64(magnitude) / float64(div)
+	return fmt.Sprintf("%s%.1f%c%s", sign, value, prefixes[exp], suffix)
 }
 
 func isValidSourceType(value string) bool {
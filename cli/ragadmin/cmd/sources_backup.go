@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/spf13/cobra"
+)
+
+// catalogImportResult summarises which catalog entries an import created or skipped.
+type catalogImportResult struct {
+	DryRun  bool     `json:"dry_run"`
+	Created []string `json:"created"`
+	Skipped []string `json:"skipped"`
+}
+
+func newSourcesExportCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the catalog snapshot to a JSON file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			file = strings.TrimSpace(file)
+			if file == "" {
+				return fmt.Errorf("file is required")
+			}
+
+			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+				resp, err := client.ListSources(ctx, ipc.SourceListRequest{TraceID: requestTraceID()})
+				if err != nil {
+					return err
+				}
+				if err := writeCatalogFile(file, resp); err != nil {
+					return err
+				}
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Exported %d sources to %s\n", len(resp.Sources), file)
+				return err
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Destination path for the catalog JSON")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func newSourcesImportCommand() *cobra.Command {
+	var opts struct {
+		file   string
+		dryRun bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Register sources from a catalog JSON file, skipping existing aliases",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.file = strings.TrimSpace(opts.file)
+			if opts.file == "" {
+				return fmt.Errorf("file is required")
+			}
+			catalog, err := readCatalogFile(opts.file)
+			if err != nil {
+				return err
+			}
+
+			return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+				existing, err := client.ListSources(ctx, ipc.SourceListRequest{TraceID: requestTraceID()})
+				if err != nil {
+					return err
+				}
+				known := make(map[string]bool, len(existing.Sources))
+				for _, src := range existing.Sources {
+					known[src.Alias] = true
+				}
+
+				out := cmd.OutOrStdout()
+				result := catalogImportResult{DryRun: opts.dryRun, Created: []string{}, Skipped: []string{}}
+				for _, src := range catalog.Sources {
+					if known[src.Alias] {
+						result.Skipped = append(result.Skipped, src.Alias)
+						if state.OutputFormat != "json" {
+							if _, err := fmt.Fprintf(out, "Skipping %s (already catalogued)\n", src.Alias); err != nil {
+								return err
+							}
+						}
+						continue
+					}
+
+					if opts.dryRun {
+						result.Created = append(result.Created, src.Alias)
+						if state.OutputFormat != "json" {
+							if _, err := fmt.Fprintf(out, "Would create %s (%s at %s)\n", src.Alias, strings.ToLower(src.Type), src.Location); err != nil {
+								return err
+							}
+						}
+						continue
+					}
+
+					traceID := requestTraceID()
+					resp, err := client.CreateSource(ctx, ipc.SourceCreateRequest{
+						TraceID:  traceID,
+						Alias:    src.Alias,
+						Type:     src.Type,
+						Location: src.Location,
+						Language: src.Language,
+						Notes:    src.Notes,
+						Checksum: src.Checksum,
+					})
+					if err != nil {
+						appendAuditEntry(state, "source_import", src.Alias, "failure", traceID, err.Error())
+						return fmt.Errorf("import %s: %w", src.Alias, err)
+					}
+					known[resp.Source.Alias] = true
+					result.Created = append(result.Created, resp.Source.Alias)
+					appendAuditEntry(state, "source_import", resp.Source.Alias, "success", traceID, fmt.Sprintf("location=%s", resp.Source.Location))
+					if state.OutputFormat != "json" {
+						if _, err := fmt.Fprintf(out, "Created %s (status %s)\n", resp.Source.Alias, resp.Source.Status); err != nil {
+							return err
+						}
+					}
+				}
+
+				return renderCatalogImportResult(out, state.OutputFormat, result)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.file, "file", "", "Catalog JSON file produced by `sources export`")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the planned changes without creating any sources")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func writeCatalogFile(path string, resp ipc.SourceListResponse) error {
+	data, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write catalog file: %w", err)
+	}
+	return nil
+}
+
+func readCatalogFile(path string) (ipc.SourceListResponse, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ipc.SourceListResponse{}, fmt.Errorf("read catalog file: %w", err)
+	}
+	var catalog ipc.SourceListResponse
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return ipc.SourceListResponse{}, fmt.Errorf("decode catalog file %s: %w", path, err)
+	}
+	for idx, src := range catalog.Sources {
+		if strings.TrimSpace(src.Alias) == "" {
+			return ipc.SourceListResponse{}, fmt.Errorf("catalog entry %d is missing an alias", idx)
+		}
+		if !isValidSourceType(src.Type) {
+			return ipc.SourceListResponse{}, fmt.Errorf("catalog entry %s has unsupported type %q", src.Alias, src.Type)
+		}
+		if strings.TrimSpace(src.Location) == "" {
+			return ipc.SourceListResponse{}, fmt.Errorf("catalog entry %s is missing a location", src.Alias)
+		}
+	}
+	return catalog, nil
+}
+
+func renderCatalogImportResult(out io.Writer, format string, result catalogImportResult) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(append(data, '\n'))
+		return err
+	}
+
+	verb := "Imported"
+	if result.DryRun {
+		verb = "Dry run: would import"
+	}
+	_, err := fmt.Fprintf(out, "%s %d sources, skipped %d already catalogued\n", verb, len(result.Created), len(result.Skipped))
+	return err
+}
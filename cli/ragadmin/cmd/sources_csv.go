@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+// outputCSV selects RFC 4180 CSV output; only `sources list` renders it so far.
+const outputCSV = "csv"
+
+// renderSourceListCSV writes a header row of field names followed by one row per source.
+// Values are unformatted (byte sizes, raw timestamps, empty instead of "-") so they import
+// cleanly into spreadsheets; encoding/csv quotes any value containing commas, quotes or newlines.
+func renderSourceListCSV(out io.Writer, sources []ipc.SourceRecord, columns []sourceColumn) error {
+	writer := csv.NewWriter(out)
+	writer.UseCRLF = true
+
+	record := make([]string, len(columns))
+	for idx, column := range columns {
+		record[idx] = column.name
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	for _, src := range sources {
+		for idx, column := range columns {
+			if column.raw != nil {
+				record[idx] = column.raw(src)
+				continue
+			}
+			record[idx] = column.value(src, sizeFormatter(false))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/spf13/cobra"
+)
+
+// liveCatalog is the --to value that compares against the backend's current catalog.
+const liveCatalog = "-"
+
+// catalogDiff lists the sources added, removed, and changed between two catalog snapshots.
+type catalogDiff struct {
+	Added   []ipc.SourceRecord `json:"added"`
+	Removed []ipc.SourceRecord `json:"removed"`
+	Changed []sourceChange     `json:"changed"`
+}
+
+// sourceChange records the fields that differ for a source present in both snapshots.
+type sourceChange struct {
+	Alias  string       `json:"alias"`
+	Fields []fieldDelta `json:"fields"`
+}
+
+type fieldDelta struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// newSourcesDiffCommand constructs `sources diff`; si points at the `sources --si` flag value.
+func newSourcesDiffCommand(si *bool) *cobra.Command {
+	var opts struct {
+		from string
+		to   string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare two catalog snapshots produced by `sources export`",
+		Long: "diff compares two catalog JSON files and prints the sources that were added, removed, or changed.\n" +
+			"Pass `--to -` to compare the --from snapshot against the backend's live catalog.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.from = strings.TrimSpace(opts.from)
+			opts.to = strings.TrimSpace(opts.to)
+			if opts.from == "" || opts.to == "" {
+				return fmt.Errorf("both --from and --to are required")
+			}
+			from, err := readCatalogFile(opts.from)
+			if err != nil {
+				return err
+			}
+
+			if opts.to == liveCatalog {
+				return runWithClient(cmd, func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+					to, err := client.ListSources(ctx, ipc.SourceListRequest{TraceID: requestTraceID()})
+					if err != nil {
+						return err
+					}
+					return renderCatalogDiff(cmd.OutOrStdout(), state.OutputFormat, diffCatalogs(from, to), sizeFormatter(*si))
+				})
+			}
+
+			state, err := obtainState(cmd)
+			if err != nil {
+				return err
+			}
+			to, err := readCatalogFile(opts.to)
+			if err != nil {
+				return err
+			}
+			return renderCatalogDiff(cmd.OutOrStdout(), state.OutputFormat, diffCatalogs(from, to), sizeFormatter(*si))
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "Older catalog JSON file")
+	cmd.Flags().StringVar(&opts.to, "to", "", "Newer catalog JSON file, or - for the live catalog")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// diffCatalogs compares two snapshots by alias. Every result list is sorted by alias.
+func diffCatalogs(from, to ipc.SourceListResponse) catalogDiff {
+	before := make(map[string]ipc.SourceRecord, len(from.Sources))
+	for _, src := range from.Sources {
+		before[src.Alias] = src
+	}
+	after := make(map[string]ipc.SourceRecord, len(to.Sources))
+	for _, src := range to.Sources {
+		after[src.Alias] = src
+	}
+
+	diff := catalogDiff{Added: []ipc.SourceRecord{}, Removed: []ipc.SourceRecord{}, Changed: []sourceChange{}}
+	for alias, newer := range after {
+		older, existed := before[alias]
+		if !existed {
+			diff.Added = append(diff.Added, newer)
+			continue
+		}
+		if fields := diffSourceFields(older, newer); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, sourceChange{Alias: alias, Fields: fields})
+		}
+	}
+	for alias, older := range before {
+		if _, kept := after[alias]; !kept {
+			diff.Removed = append(diff.Removed, older)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Alias < diff.Added[j].Alias })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Alias < diff.Removed[j].Alias })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Alias < diff.Changed[j].Alias })
+	return diff
+}
+
+// diffSourceFields lists the catalog fields whose values differ, in a stable order.
+func diffSourceFields(older, newer ipc.SourceRecord) []fieldDelta {
+	pairs := []fieldDelta{
+		{Field: "type", From: older.Type, To: newer.Type},
+		{Field: "status", From: older.Status, To: newer.Status},
+		{Field: "location", From: older.Location, To: newer.Location},
+		{Field: "language", From: older.Language, To: newer.Language},
+		{Field: "size_bytes", From: strconv.FormatInt(older.SizeBytes, 10), To: strconv.FormatInt(newer.SizeBytes, 10)},
+		{Field: "checksum", From: older.Checksum, To: newer.Checksum},
+		{Field: "last_updated", From: older.LastUpdated, To: newer.LastUpdated},
+		{Field: "notes", From: older.Notes, To: newer.Notes},
+	}
+	var deltas []fieldDelta
+	for _, pair := range pairs {
+		if pair.From != pair.To {
+			deltas = append(deltas, pair)
+		}
+	}
+	return deltas
+}
+
+func renderCatalogDiff(out io.Writer, format string, diff catalogDiff, formatSize func(int64) string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(append(data, '\n'))
+		return err
+	}
+
+	if len(diff.Added)+len(diff.Removed)+len(diff.Changed) == 0 {
+		_, err := fmt.Fprintln(out, "No catalog differences")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "CHANGE\tALIAS\tFIELD\tFROM\tTO"); err != nil {
+		return err
+	}
+	for _, src := range diff.Added {
+		if _, err := fmt.Fprintf(tw, "added\t%s\t-\t-\t%s\n", src.Alias, strings.ToLower(src.Status)); err != nil {
+			return err
+		}
+	}
+	for _, src := range diff.Removed {
+		if _, err := fmt.Fprintf(tw, "removed\t%s\t-\t%s\t-\n", src.Alias, strings.ToLower(src.Status)); err != nil {
+			return err
+		}
+	}
+	for _, change := range diff.Changed {
+		for _, delta := range change.Fields {
+			if _, err := fmt.Fprintf(tw, "changed\t%s\t%s\t%s\t%s\n", change.Alias, delta.Field, displayDeltaValue(delta.Field, delta.From, formatSize), displayDeltaValue(delta.Field, delta.To, formatSize)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "\n%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	return err
+}
+
+// displayDeltaValue formats a field value for the table: sizes go through formatSize and blanks are
+// shown as "-".
+func displayDeltaValue(field, value string, formatSize func(int64) string) string {
+	if value == "" {
+		return "-"
+	}
+	if field == "size_bytes" {
+		if size, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return formatSize(size)
+		}
+	}
+	return value
+}
@@ -0,0 +1,398 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func sampleSources() []ipc.SourceRecord {
+	return []ipc.SourceRecord{
+		{Alias: "man-pages", Type: "man", Status: "active", Language: "en", SizeBytes: 300, LastUpdated: "2024-11-03T08:00:00Z"},
+		{Alias: "linuxwiki", Type: "kiwix", Status: "quarantined", Language: "en", SizeBytes: 900, LastUpdated: "2024-11-04T09:00:00Z"},
+		{Alias: "coreutils", Type: "info", Status: "active", Language: "de", SizeBytes: 300, LastUpdated: "2024-11-01T07:00:00Z"},
+		{Alias: "archwiki", Type: "kiwix", Status: "active", Language: "EN", SizeBytes: 100, LastUpdated: "2024-11-02T10:00:00Z"},
+	}
+}
+
+func aliases(sources []ipc.SourceRecord) []string {
+	out := make([]string, 0, len(sources))
+	for _, src := range sources {
+		out = append(out, src.Alias)
+	}
+	return out
+}
+
+func TestFilterSources(t *testing.T) {
+	cases := []struct {
+		name   string
+		filter sourceFilter
+		want   []string
+	}{
+		{name: "no filter keeps everything", filter: sourceFilter{}, want: []string{"man-pages", "linuxwiki", "coreutils", "archwiki"}},
+		{name: "type", filter: sourceFilter{sourceType: "kiwix"}, want: []string{"linuxwiki", "archwiki"}},
+		{name: "status", filter: sourceFilter{status: "active"}, want: []string{"man-pages", "coreutils", "archwiki"}},
+		{name: "language is case insensitive", filter: sourceFilter{language: " en "}, want: []string{"man-pages", "linuxwiki", "archwiki"}},
+		{name: "combined", filter: sourceFilter{sourceType: "kiwix", status: "active", language: "en"}, want: []string{"archwiki"}},
+		{name: "no match", filter: sourceFilter{sourceType: "info", language: "en"}, want: []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := aliases(filterSources(sampleSources(), tc.filter))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("filterSources() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSortSources(t *testing.T) {
+	cases := []struct {
+		key     string
+		reverse bool
+		want    []string
+	}{
+		{key: "", want: []string{"man-pages", "linuxwiki", "coreutils", "archwiki"}},
+		{key: "alias", want: []string{"archwiki", "coreutils", "linuxwiki", "man-pages"}},
+		{key: "alias", reverse: true, want: []string{"man-pages", "linuxwiki", "coreutils", "archwiki"}},
+		{key: "size", want: []string{"archwiki", "man-pages", "coreutils", "linuxwiki"}},
+		{key: "size", reverse: true, want: []string{"linuxwiki", "man-pages", "coreutils", "archwiki"}},
+		{key: "status", want: []string{"man-pages", "coreutils", "archwiki", "linuxwiki"}},
+		{key: "updated", want: []string{"coreutils", "archwiki", "man-pages", "linuxwiki"}},
+		{key: "updated", reverse: true, want: []string{"linuxwiki", "man-pages", "archwiki", "coreutils"}},
+	}
+
+	for _, tc := range cases {
+		name := tc.key
+		if tc.reverse {
+			name += "-reverse"
+		}
+		t.Run(name, func(t *testing.T) {
+			sources := sampleSources()
+			sortSources(sources, tc.key, tc.reverse)
+			if got := aliases(sources); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("sortSources(%q, %v) = %v, want %v", tc.key, tc.reverse, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConfirmSourceRemoval(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "yes", input: "y\n", want: true},
+		{name: "full word", input: " YES \n", want: true},
+		{name: "no", input: "n\n", want: false},
+		{name: "empty defaults to no", input: "\n", want: false},
+		{name: "eof defaults to no", input: "", want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out strings.Builder
+			got, err := confirmSourceRemoval(strings.NewReader(tc.input), &out, "linuxwiki", true)
+			if err != nil {
+				t.Fatalf("confirmSourceRemoval() error = %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("confirmSourceRemoval(%q) = %v, want %v", tc.input, got, tc.want)
+			}
+			if !strings.Contains(out.String(), "Are you sure you want to quarantine linuxwiki? (y/N)") {
+				t.Fatalf("expected confirmation prompt, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestConfirmSourceRemovalRefusesNonInteractiveInput(t *testing.T) {
+	var out strings.Builder
+	confirmed, err := confirmSourceRemoval(strings.NewReader("y\n"), &out, "linuxwiki", false)
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected refusal mentioning --yes, got confirmed=%v err=%v", confirmed, err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected no prompt for non-interactive input, got %q", out.String())
+	}
+}
+
+func TestDiffCatalogs(t *testing.T) {
+	from := ipc.SourceListResponse{Sources: sampleSources()}
+	to := ipc.SourceListResponse{Sources: sampleSources()[1:]}
+	to.Sources[0].Status = "active"
+	to.Sources[0].SizeBytes = 1200
+	to.Sources = append(to.Sources, ipc.SourceRecord{Alias: "busybox", Type: "man", Status: "pending_validation"})
+
+	diff := diffCatalogs(from, to)
+
+	if got := aliases(diff.Added); !reflect.DeepEqual(got, []string{"busybox"}) {
+		t.Fatalf("added = %v, want [busybox]", got)
+	}
+	if got := aliases(diff.Removed); !reflect.DeepEqual(got, []string{"man-pages"}) {
+		t.Fatalf("removed = %v, want [man-pages]", got)
+	}
+	want := []sourceChange{{
+		Alias: "linuxwiki",
+		Fields: []fieldDelta{
+			{Field: "status", From: "quarantined", To: "active"},
+			{Field: "size_bytes", From: "900", To: "1200"},
+		},
+	}}
+	if !reflect.DeepEqual(diff.Changed, want) {
+		t.Fatalf("changed = %+v, want %+v", diff.Changed, want)
+	}
+}
+
+func TestRenderCatalogDiffTable(t *testing.T) {
+	var out strings.Builder
+	if err := renderCatalogDiff(&out, "table", catalogDiff{}, sizeFormatter(false)); err != nil {
+		t.Fatalf("renderCatalogDiff() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "No catalog differences") {
+		t.Fatalf("expected an empty diff message, got:\n%s", out.String())
+	}
+}
+
+func TestSourcesAddRejectsRemoteLocationBeforeDialing(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--socket", "/nonexistent/backend.sock", "sources", "add", "--type", "kiwix", "--path", "https://download.kiwix.org/zim/linuxwiki_en.zim"})
+
+	err := root.Execute()
+	if !errors.Is(err, ipc.ErrRemoteSourceLocation) {
+		t.Fatalf("expected remote location to be rejected, got %v", err)
+	}
+}
+
+func TestSourcesUpdateRejectsRemoteLocationBeforeDialing(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--socket", "/nonexistent/backend.sock", "sources", "update", "linuxwiki", "--path", "https://download.kiwix.org/zim/linuxwiki_en.zim"})
+
+	err := root.Execute()
+	if !errors.Is(err, ipc.ErrRemoteSourceLocation) {
+		t.Fatalf("expected remote location to be rejected, got %v", err)
+	}
+}
+
+func TestComputeSourceChecksum(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manpages.tar")
+	if err := os.WriteFile(path, []byte("abc"), 0o600); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+
+	const want = "sha256:ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	for _, location := range []string{path, "file://" + path} {
+		got, ok, err := computeSourceChecksum(location)
+		if err != nil || !ok {
+			t.Fatalf("computeSourceChecksum(%q) = %q, %v, %v", location, got, ok, err)
+		}
+		if got != want {
+			t.Fatalf("computeSourceChecksum(%q) = %q, want %q", location, got, want)
+		}
+	}
+
+	for _, location := range []string{dir, filepath.Join(dir, "missing.zim"), "man:ls(1)"} {
+		if got, ok, err := computeSourceChecksum(location); ok || err != nil {
+			t.Fatalf("expected %q to be skipped, got %q, %v, %v", location, got, ok, err)
+		}
+	}
+}
+
+func TestParseSourceFields(t *testing.T) {
+	headers := func(columns []sourceColumn) []string {
+		out := make([]string, 0, len(columns))
+		for _, column := range columns {
+			out = append(out, column.header)
+		}
+		return out
+	}
+
+	columns, err := parseSourceFields("")
+	if err != nil {
+		t.Fatalf("parseSourceFields(\"\") error = %v", err)
+	}
+	if want := []string{"ALIAS", "TYPE", "STATUS", "LANGUAGE", "SIZE", "LOCATION"}; !reflect.DeepEqual(headers(columns), want) {
+		t.Fatalf("default columns = %v, want %v", headers(columns), want)
+	}
+
+	columns, err = parseSourceFields(" Alias, status ,checksum,last_updated")
+	if err != nil {
+		t.Fatalf("parseSourceFields() error = %v", err)
+	}
+	if want := []string{"ALIAS", "STATUS", "CHECKSUM", "LAST UPDATED"}; !reflect.DeepEqual(headers(columns), want) {
+		t.Fatalf("selected columns = %v, want %v", headers(columns), want)
+	}
+
+	_, err = parseSourceFields("alias,owner")
+	if err == nil || !strings.Contains(err.Error(), `unknown field "owner"`) || !strings.Contains(err.Error(), "checksum|notes|last_updated") {
+		t.Fatalf("expected unknown field error listing valid options, got %v", err)
+	}
+}
+
+func TestRenderSourceListSelectedColumns(t *testing.T) {
+	columns, err := parseSourceFields("alias,checksum,notes")
+	if err != nil {
+		t.Fatalf("parseSourceFields() error = %v", err)
+	}
+	resp := ipc.SourceListResponse{
+		Sources: []ipc.SourceRecord{
+			{Alias: "man-pages", Checksum: "sha256:abc", Notes: "system manuals", Location: "/usr/share/man"},
+			{Alias: "linuxwiki"},
+		},
+		UpdatedAt: "2024-11-04T09:00:00Z",
+	}
+
+	var out strings.Builder
+	if err := renderSourceList(&out, "table", resp, columns, timestampFormatter(false), sizeFormatter(false)); err != nil {
+		t.Fatalf("renderSourceList() error = %v", err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if got := strings.Fields(lines[0]); !reflect.DeepEqual(got, []string{"ALIAS", "CHECKSUM", "NOTES"}) {
+		t.Fatalf("unexpected header %q", lines[0])
+	}
+	if got := strings.Fields(lines[2]); !reflect.DeepEqual(got, []string{"linuxwiki", "-", "-"}) {
+		t.Fatalf("expected blanks rendered as dashes, got %q", lines[2])
+	}
+	if strings.Contains(out.String(), "/usr/share/man") {
+		t.Fatalf("expected unselected location column to be omitted:\n%s", out.String())
+	}
+}
+
+func TestRenderSourceListCSVMatchesGolden(t *testing.T) {
+	columns, err := parseSourceFields(strings.Join(sourceFieldNames, ","))
+	if err != nil {
+		t.Fatalf("parseSourceFields() error = %v", err)
+	}
+	resp := ipc.SourceListResponse{
+		Sources: []ipc.SourceRecord{
+			{
+				Alias:       "man-pages",
+				Type:        "MAN",
+				Status:      "active",
+				Language:    "en",
+				SizeBytes:   1536,
+				Location:    "/usr/share/man",
+				Checksum:    "sha256:abc",
+				Notes:       "system manuals, sections 1-8",
+				LastUpdated: "2024-11-03T08:00:00Z",
+			},
+			{
+				Alias:    "linuxwiki",
+				Type:     "kiwix",
+				Status:   "quarantined",
+				Language: "en",
+				Location: "/srv/zim/linux, \"mirror\".zim",
+			},
+		},
+		UpdatedAt: "2024-11-04T09:00:00Z",
+	}
+
+	var out strings.Builder
+	if err := renderSourceList(&out, resolveOutputFormat("CSV", ""), resp, columns, timestampFormatter(true), sizeFormatter(false)); err != nil {
+		t.Fatalf("renderSourceList() error = %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "sources_list.csv.golden"))
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+	if out.String() != string(want) {
+		t.Fatalf("csv output mismatch\n--- got ---\n%s\n--- want ---\n%s", out.String(), want)
+	}
+}
+
+func TestSummarizeSources(t *testing.T) {
+	summary := summarizeSources(sampleSources())
+
+	if summary.Total != 4 || summary.SizeBytes != 1600 {
+		t.Fatalf("unexpected totals: %+v", summary)
+	}
+	if want := map[string]int{"active": 3, "quarantined": 1}; !reflect.DeepEqual(summary.ByStatus, want) {
+		t.Fatalf("by status = %v, want %v", summary.ByStatus, want)
+	}
+	if want := map[string]int{"man": 1, "kiwix": 2, "info": 1}; !reflect.DeepEqual(summary.ByType, want) {
+		t.Fatalf("by type = %v, want %v", summary.ByType, want)
+	}
+	if got := formatCounts(summary.ByStatus); got != "active 3, quarantined 1" {
+		t.Fatalf("formatCounts() = %q", got)
+	}
+}
+
+func TestRenderSourceListSummary(t *testing.T) {
+	resp := ipc.SourceListResponse{Sources: sampleSources(), UpdatedAt: "2024-11-04T09:00:00Z"}
+	columns, _ := parseSourceFields("")
+
+	var table strings.Builder
+	if err := renderSourceList(&table, "table", resp, columns, timestampFormatter(false), sizeFormatter(false)); err != nil {
+		t.Fatalf("renderSourceList(table) error = %v", err)
+	}
+	for _, line := range []string{"4 sources, 1.6KiB total", "By status: active 3, quarantined 1", "By type: info 1, kiwix 2, man 1"} {
+		if !strings.Contains(table.String(), line) {
+			t.Fatalf("expected %q in table footer:\n%s", line, table.String())
+		}
+	}
+
+	var si strings.Builder
+	if err := renderSourceList(&si, "table", resp, columns, timestampFormatter(false), sizeFormatter(true)); err != nil {
+		t.Fatalf("renderSourceList(table, si) error = %v", err)
+	}
+	if !strings.Contains(si.String(), "4 sources, 1.6KB total") {
+		t.Fatalf("expected SI units in the table footer:\n%s", si.String())
+	}
+
+	var data strings.Builder
+	if err := renderSourceList(&data, "json", resp, columns, timestampFormatter(false), sizeFormatter(false)); err != nil {
+		t.Fatalf("renderSourceList(json) error = %v", err)
+	}
+	var payload struct {
+		Sources []ipc.SourceRecord `json:"sources"`
+		Summary catalogSummary     `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(data.String()), &payload); err != nil {
+		t.Fatalf("decode json output: %v", err)
+	}
+	if len(payload.Sources) != 4 || payload.Summary.Total != 4 || payload.Summary.ByType["kiwix"] != 2 {
+		t.Fatalf("unexpected json payload: %+v", payload)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	cases := []struct {
+		size   int64
+		binary string
+		si     string
+	}{
+		{size: 0, binary: "0B", si: "0B"},
+		{size: 999, binary: "999B", si: "999B"},
+		{size: 1023, binary: "1023B", si: "1.0KB"},
+		{size: 1024, binary: "1.0KiB", si: "1.0KB"},
+		{size: 1 << 40, binary: "1.0TiB", si: "1.1TB"},
+		{size: 1_000_000_000_000, binary: "931.3GiB", si: "1.0TB"},
+		{size: math.MaxInt64, binary: "8.0EiB", si: "9.2EB"},
+		{size: -2048, binary: "-2.0KiB", si: "-2.0KB"},
+		{size: math.MinInt64, binary: "-8.0EiB", si: "-9.2EB"},
+	}
+	for _, tc := range cases {
+		if got := formatSize(tc.size, false); got != tc.binary {
+			t.Fatalf("formatSize(%d, binary) = %q, want %q", tc.size, got, tc.binary)
+		}
+		if got := formatSize(tc.size, true); got != tc.si {
+			t.Fatalf("formatSize(%d, si) = %q, want %q", tc.size, got, tc.si)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/linux-rag-t2/cli/shared/termutil"
+	"github.com/spf13/cobra"
+)
+
+// spinnerInterval is the delay between spinner frames.
+const spinnerInterval = 100 * time.Millisecond
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinner redraws a single status line on a terminal until stopped.
+type spinner struct {
+	out   io.Writer
+	label string
+	quit  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+// startSpinner draws the first frame immediately and animates the line in the background.
+func startSpinner(out io.Writer, label string) *spinner {
+	s := &spinner{
+		out:   out,
+		label: label,
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *spinner) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		fmt.Fprintf(s.out, "\r%s %s", spinnerFrames[frame%len(spinnerFrames)], s.label)
+		select {
+		case <-s.quit:
+			fmt.Fprintf(s.out, "\r%s\r", strings.Repeat(" ", len(s.label)+2))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop clears the spinner line and waits for the animation to finish. It is safe to call repeatedly.
+func (s *spinner) Stop() {
+	s.once.Do(func() { close(s.quit) })
+	<-s.done
+}
+
+// spinnerGuardWriter stops the spinner before the first byte reaches stdout so
+// command output never shares a terminal line with the animation.
+type spinnerGuardWriter struct {
+	out     io.Writer
+	spinner *spinner
+}
+
+func (w *spinnerGuardWriter) Write(p []byte) (int, error) {
+	w.spinner.Stop()
+	return w.out.Write(p)
+}
+
+// withSpinner wraps a runWithClient callback with a stderr spinner shown while it runs.
+// The spinner only appears when stderr is a terminal, the output format is not json, and the
+// log level is warn or above, since info and debug logs share stderr with the animation.
+func withSpinner(cmd *cobra.Command, label string, fn func(context.Context, *runtimeState, *ipc.Client) error) func(context.Context, *runtimeState, *ipc.Client) error {
+	return func(ctx context.Context, state *runtimeState, client *ipc.Client) error {
+		stderr := cmd.ErrOrStderr()
+		if state.OutputFormat == "json" || !termutil.IsTerminal(stderr) || loggerForState(state).Enabled(ctx, slog.LevelInfo) {
+			return fn(ctx, state, client)
+		}
+
+		s := startSpinner(stderr, label)
+		defer s.Stop()
+		cmd.SetOut(&spinnerGuardWriter{out: cmd.OutOrStdout(), spinner: s})
+		defer cmd.SetOut(nil)
+		return fn(ctx, state, client)
+	}
+}
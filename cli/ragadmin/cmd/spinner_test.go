@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/spf13/cobra"
+)
+
+func TestSpinnerClearsLineOnStop(t *testing.T) {
+	var stderr bytes.Buffer
+	s := startSpinner(&stderr, "Working")
+	s.Stop()
+	s.Stop()
+
+	got := stderr.String()
+	if !strings.HasPrefix(got, "\r| Working") {
+		t.Fatalf("expected first frame to be drawn, got %q", got)
+	}
+	if !strings.HasSuffix(got, "\r"+strings.Repeat(" ", len("Working")+2)+"\r") {
+		t.Fatalf("expected spinner line to be cleared, got %q", got)
+	}
+}
+
+func TestSpinnerGuardStopsSpinnerBeforeOutput(t *testing.T) {
+	var stderr, stdout bytes.Buffer
+	s := startSpinner(&stderr, "Working")
+	guard := &spinnerGuardWriter{out: &stdout, spinner: s}
+
+	if _, err := guard.Write([]byte("done\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cleared := stderr.Len()
+	if _, err := guard.Write([]byte("more\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if stdout.String() != "done\nmore\n" {
+		t.Fatalf("unexpected stdout %q", stdout.String())
+	}
+	if stderr.Len() != cleared || !strings.HasSuffix(stderr.String(), "\r") {
+		t.Fatalf("expected spinner to be cleared before the first write, got %q", stderr.String())
+	}
+}
+
+func TestWithSpinnerSkipsNonTerminalAndJSON(t *testing.T) {
+	for _, format := range []string{"table", "json"} {
+		var stderr, stdout bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetErr(&stderr)
+		cmd.SetOut(&stdout)
+
+		fn := withSpinner(cmd, "Working", func(_ context.Context, _ *runtimeState, _ *ipc.Client) error {
+			cmd.Print("ok")
+			return nil
+		})
+		if err := fn(context.Background(), &runtimeState{OutputFormat: format}, nil); err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if stderr.Len() != 0 {
+			t.Fatalf("%s: expected no spinner output, got %q", format, stderr.String())
+		}
+		if stdout.String() != "ok" {
+			t.Fatalf("%s: unexpected stdout %q", format, stdout.String())
+		}
+	}
+}
+
+func TestWithSpinnerSkipsVerboseLogging(t *testing.T) {
+	// /dev/null is a character device, so it passes the terminal check like a real TTY.
+	stderr, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("open %s: %v", os.DevNull, err)
+	}
+	defer stderr.Close()
+
+	for _, tc := range []struct {
+		level       slog.Level
+		wantSpinner bool
+	}{
+		{level: slog.LevelDebug, wantSpinner: false},
+		{level: slog.LevelInfo, wantSpinner: false},
+		{level: slog.LevelWarn, wantSpinner: true},
+	} {
+		var stdout bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetErr(stderr)
+		cmd.SetOut(&stdout)
+
+		var spinning bool
+		fn := withSpinner(cmd, "Working", func(_ context.Context, _ *runtimeState, _ *ipc.Client) error {
+			_, spinning = cmd.OutOrStdout().(*spinnerGuardWriter)
+			return nil
+		})
+		state := &runtimeState{OutputFormat: "table", Logger: slog.New(slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: tc.level}))}
+		if err := fn(context.Background(), state, nil); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.level, err)
+		}
+		if spinning != tc.wantSpinner {
+			t.Fatalf("%s: spinner shown = %v, want %v", tc.level, spinning, tc.wantSpinner)
+		}
+	}
+}
@@ -4,10 +4,13 @@ package config
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/linux-rag-t2/cli/shared/audit"
+	"github.com/linux-rag-t2/cli/shared/configfile"
 	"gopkg.in/yaml.v3"
 )
 
@@ -15,12 +18,19 @@ const defaultOutput = "table"
 
 // Config represents the ragadmin configuration schema.
 type Config struct {
-	Ragadmin RagadminConfig `yaml:"ragadmin"`
+	Ragadmin RagadminConfig    `yaml:"ragadmin"`
+	Shared   configfile.Shared `yaml:"shared"`
 }
 
 // RagadminConfig captures CLI-specific default settings.
 type RagadminConfig struct {
 	OutputDefault string `yaml:"output_default"`
+	KiwixDataDir  string `yaml:"kiwix_data_dir"`
+	ClientID      string `yaml:"client_id"`
+	// JobLogMaxSizeBytes rotates each reindex job log once it would exceed this size (0 uses the default).
+	JobLogMaxSizeBytes int64 `yaml:"job_log_max_size_bytes"`
+	// JobLogMaxBackups is the number of rotated files kept per job log; nil uses the default.
+	JobLogMaxBackups *int `yaml:"job_log_max_backups"`
 }
 
 // Default returns the baseline configuration used when no file exists.
@@ -50,10 +60,15 @@ func Load(path string) (Config, error) {
 		return cfg, nil
 	}
 
-	var raw Config
-	if err := yaml.Unmarshal(data, &raw); err != nil {
+	var schema fileSchema
+	unknown, err := configfile.DecodeStrict(data, &schema)
+	if err != nil {
 		return cfg, fmt.Errorf("config: decode: %w", err)
 	}
+	if len(unknown) > 0 {
+		slog.Warn("config: ignoring unknown keys", slog.String("path", path), slog.Any("keys", unknown))
+	}
+	raw := Config{Ragadmin: schema.Ragadmin, Shared: schema.Shared}
 
 	cfg.apply(raw)
 	cfg.normalize()
@@ -80,10 +95,101 @@ func (c Config) Output() string {
 	return c.Ragadmin.OutputDefault
 }
 
+// KiwixDataDir returns the pinned kiwix data directory, or an empty string to use the default candidates.
+func (c Config) KiwixDataDir() string {
+	return c.Ragadmin.KiwixDataDir
+}
+
+// SocketPath returns the backend socket pinned in the shared section, or an empty string when unset.
+func (c Config) SocketPath() string {
+	return c.Shared.SocketPath
+}
+
+// ClientID returns the handshake client identifier override, or an empty string for the built-in default.
+func (c Config) ClientID() string {
+	return c.Ragadmin.ClientID
+}
+
+// AuditOptions returns the shared audit log rotation limits.
+func (c Config) AuditOptions() []audit.Option {
+	return c.Shared.AuditOptions()
+}
+
+// AuditRedaction returns the shared masking rules for audit log entries.
+func (c Config) AuditRedaction() audit.RedactionRules {
+	return c.Shared.AuditRedaction()
+}
+
+// JobLogOptions returns the rotation limits for `reindex --job-log` files, which are independent of
+// the audit log's.
+func (c Config) JobLogOptions() []audit.Option {
+	backups := -1
+	if c.Ragadmin.JobLogMaxBackups != nil {
+		backups = *c.Ragadmin.JobLogMaxBackups
+	}
+	return []audit.Option{
+		audit.WithMaxSizeBytes(c.Ragadmin.JobLogMaxSizeBytes),
+		audit.WithMaxBackups(backups),
+	}
+}
+
+// fileSchema mirrors the whole shared ragcli file so strict decoding tolerates the sections owned by
+// ragman and the backend.
+type fileSchema struct {
+	Ragadmin RagadminConfig    `yaml:"ragadmin"`
+	Shared   configfile.Shared `yaml:"shared"`
+	Ragman   yaml.Node         `yaml:"ragman"`
+	Backend  yaml.Node         `yaml:"backend"`
+}
+
+// Validate strictly decodes the configuration file and returns every problem found: unknown keys,
+// values of the wrong type, negative limits, and unsupported output formats.
+// An error is returned only when the file cannot be read.
+func Validate(path string) ([]string, error) {
+	var raw fileSchema
+	problems, err := configfile.Validate(path, &raw)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, raw.Ragadmin.validate()...)
+	return append(problems, raw.Shared.Validate()...), nil
+}
+
+// validate reports values that Load would silently ignore or replace.
+func (r RagadminConfig) validate() []string {
+	var problems []string
+	switch output := strings.ToLower(strings.TrimSpace(r.OutputDefault)); output {
+	case "", "table", "json":
+	default:
+		problems = append(problems, fmt.Sprintf("ragadmin.output_default %q is not one of table, json", r.OutputDefault))
+	}
+	if r.JobLogMaxSizeBytes < 0 {
+		problems = append(problems, fmt.Sprintf("ragadmin.job_log_max_size_bytes must not be negative, got %d", r.JobLogMaxSizeBytes))
+	}
+	if r.JobLogMaxBackups != nil && *r.JobLogMaxBackups < 0 {
+		problems = append(problems, fmt.Sprintf("ragadmin.job_log_max_backups must not be negative, got %d", *r.JobLogMaxBackups))
+	}
+	return problems
+}
+
 func (c *Config) apply(raw Config) {
 	if strings.TrimSpace(raw.Ragadmin.OutputDefault) != "" {
 		c.Ragadmin.OutputDefault = raw.Ragadmin.OutputDefault
 	}
+	if dir := strings.TrimSpace(raw.Ragadmin.KiwixDataDir); dir != "" {
+		c.Ragadmin.KiwixDataDir = dir
+	}
+	if id := strings.TrimSpace(raw.Ragadmin.ClientID); id != "" {
+		c.Ragadmin.ClientID = id
+	}
+	if raw.Ragadmin.JobLogMaxSizeBytes > 0 {
+		c.Ragadmin.JobLogMaxSizeBytes = raw.Ragadmin.JobLogMaxSizeBytes
+	}
+	if raw.Ragadmin.JobLogMaxBackups != nil && *raw.Ragadmin.JobLogMaxBackups >= 0 {
+		backups := *raw.Ragadmin.JobLogMaxBackups
+		c.Ragadmin.JobLogMaxBackups = &backups
+	}
+	c.Shared.Merge(raw.Shared)
 }
 
 func (c *Config) normalize() {
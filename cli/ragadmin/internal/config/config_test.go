@@ -0,0 +1,98 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateReportsProblems(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "valid shared file",
+			content: "ragman:\n  presenter_default: plain\nragadmin:\n  output_default: table\nshared:\n  audit_max_backups: 0\n",
+		},
+		{
+			name:    "unknown key",
+			content: "ragadmin:\n  output: json\n",
+			want:    []string{`line 2: unknown key "output"`},
+		},
+		{
+			name:    "invalid values",
+			content: "ragadmin:\n  output_default: xml\n  job_log_max_size_bytes: -1\n  job_log_max_backups: -1\nshared:\n  audit_max_size_bytes: -1\n  audit_max_backups: -2\n",
+			want: []string{
+				`output_default "xml"`,
+				"ragadmin.job_log_max_size_bytes must not be negative",
+				"ragadmin.job_log_max_backups must not be negative",
+				"shared.audit_max_size_bytes must not be negative",
+				"shared.audit_max_backups must not be negative",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			problems, err := Validate(path)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if len(problems) != len(tc.want) {
+				t.Fatalf("Validate() = %q, want %d problem(s)", problems, len(tc.want))
+			}
+			for idx, want := range tc.want {
+				if !strings.Contains(problems[idx], want) {
+					t.Fatalf("problem %d = %q, want it to mention %q", idx, problems[idx], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadWarnsOnUnknownKeys(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "ragman:\n  presenter_default: plain\nragadmin:\n  output_defualt: json\n  kiwix_data_dir: /srv/kiwix\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.KiwixDataDir(); got != "/srv/kiwix" {
+		t.Fatalf("KiwixDataDir() = %q, want /srv/kiwix", got)
+	}
+	if got := cfg.Output(); got != defaultOutput {
+		t.Fatalf("Output() = %q, want default %q", got, defaultOutput)
+	}
+	if !strings.Contains(logs.String(), "output_defualt") {
+		t.Fatalf("expected warning naming the unknown key, got %q", logs.String())
+	}
+}
+
+func TestValidateAcceptsInstallTemplate(t *testing.T) {
+	problems, err := Validate(filepath.Join("..", "..", "..", "..", "docs", "install", "config", "ragcli-config.yaml"))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("expected the shipped config template to validate, got %q", problems)
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	renderio "github.com/linux-rag-t2/cli/ragman/internal/io"
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+// batchOptions carries the query flags that apply to every question in a batch.
+type batchOptions struct {
+	path             string
+	ndjson           bool
+	conversationID   string
+	maxContextTokens int
+	timeout          time.Duration
+	sourceTypes      []string
+	language         string
+	indexVersion     string
+	withHistory      int
+	noHistory        bool
+	debugIPC         bool
+}
+
+// batchResult is one entry of the batch output. Exactly one of Answer and Error is set.
+type batchResult struct {
+	Question string          `json:"question"`
+	TraceID  string          `json:"trace_id"`
+	Answer   json.RawMessage `json:"answer,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// readBatchQuestions loads one question per line from path, skipping blank lines.
+func readBatchQuestions(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ragman: read batch file: %w", err)
+	}
+	defer file.Close()
+
+	var questions []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if question := strings.TrimSpace(scanner.Text()); question != "" {
+			questions = append(questions, question)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ragman: read batch file: %w", err)
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("ragman: batch file %s contains no questions", path)
+	}
+	return questions, nil
+}
+
+// batchTraceID returns the trace ID for the index-th question. An external --trace-id is
+// suffixed with the 1-based position so every entry stays distinguishable.
+func batchTraceID(index int) string {
+	if rootOpts.traceID != "" {
+		return fmt.Sprintf("%s-%d", rootOpts.traceID, index+1)
+	}
+	return newTraceID()
+}
+
+// runBatch asks every question from the batch file over a shared client connection and writes
+// the results as a JSON array, or as NDJSON when requested. Failures are recorded per entry and
+// never abort the remaining questions; a transport failure such as a timeout leaves the
+// connection unusable, so the next question redials.
+func runBatch(ctx context.Context, out io.Writer, state *runtimeState, opts batchOptions) error {
+	questions, err := readBatchQuestions(opts.path)
+	if err != nil {
+		return err
+	}
+
+	logger := state.Logger.With(slog.String("command", "query"), slog.String("batch", opts.path))
+	logger.Info("ragman batch started", slog.Int("questions", len(questions)))
+
+	clientLogger := ipcClientLogger(ctx, state, opts.debugIPC)
+	client, err := dialBackend(state, clientLogger)
+	if err != nil {
+		logger.Error("ragman batch connection failed", slog.String("error", err.Error()))
+		return fmt.Errorf("ragman: connect backend: %w", err)
+	}
+	defer func() {
+		if client != nil {
+			client.Close()
+		}
+	}()
+
+	results := make([]batchResult, 0, len(questions))
+	for idx, question := range questions {
+		traceID := batchTraceID(idx)
+		result := batchResult{Question: question, TraceID: traceID}
+		if client == nil {
+			if client, err = dialBackend(state, clientLogger); err != nil {
+				logger.Warn("ragman batch reconnect failed", slog.String("error", err.Error()))
+				client = nil
+				result.Error = fmt.Sprintf("ragman: connect backend: %v", err)
+			}
+		}
+		if client != nil {
+			var queryErr error
+			result, queryErr = askBatchQuestion(ctx, client, state, logger, opts, question, traceID)
+			var backendErr *ipc.BackendError
+			if queryErr != nil && !errors.As(queryErr, &backendErr) {
+				client.Close()
+				client = nil
+			}
+		}
+
+		if opts.ndjson {
+			if err := writeNDJSON(out, result); err != nil {
+				return err
+			}
+			continue
+		}
+		results = append(results, result)
+	}
+
+	logger.Info("ragman batch completed", slog.Int("questions", len(questions)))
+	if opts.ndjson {
+		return nil
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ragman: encode batch results: %w", err)
+	}
+	_, err = out.Write(append(data, '\n'))
+	return err
+}
+
+// askBatchQuestion issues a single batch query and captures either its JSON answer or its error.
+// The query error, if any, is also returned so the caller can tell transport failures apart.
+func askBatchQuestion(ctx context.Context, client *ipc.Client, state *runtimeState, logger *slog.Logger, opts batchOptions, question, traceID string) (batchResult, error) {
+	result := batchResult{Question: question, TraceID: traceID}
+	logger = logger.With(slog.String("trace_id", traceID))
+
+	request := ipc.QueryRequest{
+		Question:         question,
+		ConversationID:   strings.TrimSpace(opts.conversationID),
+		MaxContextTokens: opts.maxContextTokens,
+		TraceID:          traceID,
+		SourceTypes:      opts.sourceTypes,
+		Language:         opts.language,
+		IndexVersion:     opts.indexVersion,
+	}
+	if opts.withHistory > 0 {
+		request.History = historyTurns(state, logger, opts.withHistory)
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+	response, err := client.Query(queryCtx, request)
+	if err != nil {
+		logger.Warn("ragman batch query failed", slog.String("error", err.Error()))
+		result.Error = err.Error()
+		return result, err
+	}
+	result.TraceID = coalesce(response.TraceID, traceID)
+
+	output, err := renderio.Render(response, renderio.Options{
+		ConfidenceThreshold: state.Config.ConfidenceThreshold(),
+		TraceID:             result.TraceID,
+		Presenter:           renderio.FormatJSON,
+		Logger:              logger,
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(output)); err != nil {
+		result.Error = fmt.Sprintf("ragman: encode answer: %v", err)
+		return result, nil
+	}
+	result.Answer = compact.Bytes()
+
+	if !opts.noHistory && state.Config.HistoryEnabled() {
+		recordHistory(state, logger, question, result.TraceID, response)
+	}
+	recordQueryAudit(state, logger, question, result.TraceID, response)
+	return result, nil
+}
+
+func writeNDJSON(out io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("ragman: encode batch result: %w", err)
+	}
+	_, err = out.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/linux-rag-t2/cli/ragman/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// newConfigCommand groups configuration helpers. It replaces the root pre-run hook so a config file
+// that fails to load can still be inspected.
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "config",
+		Short:             "Inspect the ragcli configuration file",
+		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newConfigValidateCommand())
+	cmd.AddCommand(newConfigShowCommand())
+	return cmd
+}
+
+// newConfigValidateCommand constructs `config validate`, which strictly checks the ragman settings.
+func newConfigValidateCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Report unknown keys and invalid values in the configuration file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path := strings.TrimSpace(file)
+			if path == "" {
+				resolved, err := resolveConfigPath(rootOpts.configPath)
+				if err != nil {
+					return err
+				}
+				path = resolved
+			}
+
+			problems, err := config.Validate(path)
+			if err != nil {
+				return err
+			}
+			return reportConfigValidation(cmd.OutOrStdout(), path, problems)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Configuration file to validate (defaults to --config)")
+	return cmd
+}
+
+// reportConfigValidation prints a pass/fail line followed by each problem, failing when any were found.
+func reportConfigValidation(out io.Writer, path string, problems []string) error {
+	if len(problems) == 0 {
+		_, err := fmt.Fprintf(out, "PASS %s\n", path)
+		return err
+	}
+
+	if _, err := fmt.Fprintf(out, "FAIL %s\n", path); err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		if _, err := fmt.Fprintf(out, "  - %s\n", problem); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("ragman: configuration has %d problem(s)", len(problems))
+}
+
+// effectiveConfig is the merged configuration reported by `config show`.
+type effectiveConfig struct {
+	ConfigPath           string  `json:"config_path"`
+	SocketPath           string  `json:"socket_path"`
+	ClientID             string  `json:"client_id"`
+	Presenter            string  `json:"presenter"`
+	ConfidenceThreshold  float64 `json:"confidence_threshold"`
+	ConfidenceWarnBand   float64 `json:"confidence_warn_band"`
+	QueryTimeoutSeconds  int     `json:"query_timeout_seconds"`
+	HistoryEnabled       bool    `json:"history_enabled"`
+	AuditQueries         bool    `json:"audit_queries"`
+	MarkdownTemplatePath string  `json:"markdown_template_path"`
+	PlainTemplatePath    string  `json:"plain_template_path"`
+}
+
+// newConfigShowCommand constructs `config show`, which prints the settings in effect after
+// merging the config file, environment overrides, and global flags.
+func newConfigShowCommand() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration after file, environment, and flag merging",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := initializeState(cmd); err != nil {
+				return err
+			}
+			state, err := obtainState(cmd)
+			if err != nil {
+				return err
+			}
+
+			effective := effectiveConfigFor(state)
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(effective)
+			}
+			return renderEffectiveConfig(cmd.OutOrStdout(), effective)
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Emit the effective configuration as JSON")
+	return cmd
+}
+
+func effectiveConfigFor(state *runtimeState) effectiveConfig {
+	cfg := state.Config
+	return effectiveConfig{
+		ConfigPath:           state.ConfigPath,
+		SocketPath:           state.SocketPath,
+		ClientID:             coalesce(cfg.ClientID(), defaultClientID),
+		Presenter:            cfg.Presenter(),
+		ConfidenceThreshold:  cfg.ConfidenceThreshold(),
+		ConfidenceWarnBand:   cfg.ConfidenceWarnBand(),
+		QueryTimeoutSeconds:  cfg.QueryTimeoutSeconds(),
+		HistoryEnabled:       cfg.HistoryEnabled(),
+		AuditQueries:         cfg.AuditQueries(),
+		MarkdownTemplatePath: cfg.MarkdownTemplatePath(),
+		PlainTemplatePath:    cfg.PlainTemplatePath(),
+	}
+}
+
+func renderEffectiveConfig(out io.Writer, effective effectiveConfig) error {
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	rows := [][2]string{
+		{"Config file", effective.ConfigPath},
+		{"Socket", effective.SocketPath},
+		{"Client ID", effective.ClientID},
+		{"Presenter", effective.Presenter},
+		{"Confidence threshold", strconv.FormatFloat(effective.ConfidenceThreshold, 'f', -1, 64)},
+		{"Confidence warn band", strconv.FormatFloat(effective.ConfidenceWarnBand, 'f', -1, 64)},
+		{"Query timeout", fmt.Sprintf("%ds", effective.QueryTimeoutSeconds)},
+		{"History", strconv.FormatBool(effective.HistoryEnabled)},
+		{"Audit queries", strconv.FormatBool(effective.AuditQueries)},
+		{"Markdown template", coalesce(effective.MarkdownTemplatePath, "(built-in)")},
+		{"Plain template", coalesce(effective.PlainTemplatePath, "(built-in)")},
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1]); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import "github.com/linux-rag-t2/cli/shared/cliflags"
+
+// Process exit codes returned by ragman so scripts can tell failure modes apart.
+const (
+	// ExitOK signals success.
+	ExitOK = cliflags.ExitOK
+	// ExitFailure covers usage errors and any failure without a more specific code.
+	ExitFailure = cliflags.ExitFailure
+	// ExitNoAnswer means the answer was rendered but the backend reported no answer or the
+	// confidence fell below the threshold, and `query --fail-on-no-answer` was requested.
+	ExitNoAnswer = 2
+)
+
+// ExitError attaches a process exit code to a command error; see cliflags.ExitCode.
+type ExitError = cliflags.ExitError
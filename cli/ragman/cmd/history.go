@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linux-rag-t2/cli/ragman/internal/history"
+	"github.com/spf13/cobra"
+)
+
+// defaultHistoryLimit is the number of entries shown when --limit is not provided.
+const defaultHistoryLimit = 20
+
+// newHistoryCommand constructs the `history` subcommand that lists previously answered questions.
+func newHistoryCommand() *cobra.Command {
+	var (
+		limit  = defaultHistoryLimit
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List recently answered questions with their trace IDs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if limit <= 0 {
+				return errors.New("ragman: --limit must be positive")
+			}
+
+			state, err := obtainState(cmd)
+			if err != nil {
+				return err
+			}
+			if state.History == nil {
+				return errors.New("ragman: query history is unavailable")
+			}
+
+			entries, err := state.History.Recent(limit)
+			if err != nil {
+				return fmt.Errorf("ragman: read history: %w", err)
+			}
+			if asJSON {
+				return renderHistoryJSON(cmd.OutOrStdout(), entries)
+			}
+			return renderHistoryTable(cmd.OutOrStdout(), entries)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", defaultHistoryLimit, "Maximum number of recent entries to show")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Emit history entries as JSON")
+
+	return cmd
+}
+
+func renderHistoryJSON(out io.Writer, entries []history.Entry) error {
+	if entries == nil {
+		entries = []history.Entry{}
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+func renderHistoryTable(out io.Writer, entries []history.Entry) error {
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(out, "No questions recorded yet.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "TIMESTAMP\tTRACE ID\tCONFIDENCE\tQUESTION"); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if _, err := fmt.Fprintf(
+			tw,
+			"%s\t%s\t%.0f%%\t%s\n",
+			entry.Timestamp.Local().Format(time.DateTime),
+			entry.TraceID,
+			entry.Confidence*100,
+			entry.Question,
+		); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
@@ -3,42 +3,115 @@ package cmd
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/linux-rag-t2/cli/ragman/internal/history"
 	renderio "github.com/linux-rag-t2/cli/ragman/internal/io"
 	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/linux-rag-t2/cli/shared/termutil"
 	"github.com/spf13/cobra"
 )
 
+// defaultExcerptWidth is the number of characters of a citation excerpt shown before it is truncated.
+const defaultExcerptWidth = 240
+
 // newQueryCommand constructs the `query` subcommand responsible for invoking the backend.
 func newQueryCommand() *cobra.Command {
 	var (
-		usePlain         bool
-		useJSON          bool
+		presenters       presenterFlags
 		conversationID   string
 		maxContextTokens int
 		queryTimeoutSecs = 30
+		questionFile     string
+		stream           bool
+		usePager         bool
+		debugIPC         bool
+		batchFile        string
+		maxReferences    int
+		excerptWidth     = defaultExcerptWidth
+		citationOrder    = string(renderio.CitationOrderAlias)
+		referencesOnly   bool
+		confidenceFormat = string(renderio.ConfidenceFormatPercent)
+		wrapWidth        int
+		indexVersion     string
+		sourceTypes      []string
+		noHistory        bool
+		language         string
+		withHistory      int
+		failOnNoAnswer   bool
+		outputFile       string
+		outputFileFormat string
+		colorMode        = colorAuto
 	)
 
 	cmd := &cobra.Command{
-		Use:   "query [question]",
+		Use:   "query [question|-]",
 		Short: "Query the local RAG backend for Linux guidance",
-		Long:  "query sends the provided question to the local RAG backend and prints the structured answer with citations.",
+		Long: "query sends the provided question to the local RAG backend and prints the structured answer with citations.\n" +
+			"Pass `-` as the question to read it from stdin, or use --question-file to read it from a file.",
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
+			if strings.TrimSpace(batchFile) != "" {
+				if len(args) > 0 || strings.TrimSpace(questionFile) != "" {
+					return errors.New("ragman: --batch cannot be combined with a positional question or --question-file")
+				}
+				return nil
+			}
+			if len(args) == 0 && strings.TrimSpace(questionFile) == "" {
 				return errors.New("ragman: question must be provided")
 			}
+			if len(args) > 0 && strings.TrimSpace(questionFile) != "" {
+				return errors.New("ragman: --question-file cannot be combined with a positional question")
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if usePlain && useJSON {
-				return errors.New("ragman: --plain and --json cannot be used together")
+			if presenters.count() > 1 {
+				return errors.New("ragman: --plain, --json, --yaml and --html cannot be used together")
+			}
+
+			for _, sourceType := range sourceTypes {
+				if !isValidSourceType(strings.TrimSpace(sourceType)) {
+					return fmt.Errorf("ragman: unsupported --source-type %q (expected man|kiwix|info)", sourceType)
+				}
+			}
+			if err := validateContextTokens(maxContextTokens); err != nil {
+				return err
+			}
+			if strings.TrimSpace(outputFileFormat) != "" && !isValidPresenterName(outputFileFormat) {
+				return fmt.Errorf("ragman: unsupported --output-format-file %q (expected markdown|plain|json|yaml|html)", outputFileFormat)
+			}
+			if wrapWidth < 0 {
+				return errors.New("ragman: --width must not be negative")
+			}
+			if excerptWidth < 0 {
+				return errors.New("ragman: --excerpt-width must not be negative")
+			}
+			if maxReferences < 0 {
+				return errors.New("ragman: --max-references must not be negative")
+			}
+			order, err := parseCitationOrder(citationOrder)
+			if err != nil {
+				return err
+			}
+			confidenceDisplay, err := parseConfidenceFormat(confidenceFormat)
+			if err != nil {
+				return err
+			}
+			if withHistory < 0 {
+				return errors.New("ragman: --with-history must not be negative")
+			}
+			if referencesOnly && stream {
+				return errors.New("ragman: --references-only cannot be combined with --stream")
 			}
 
 			state, err := obtainState(cmd)
@@ -46,9 +119,35 @@ func newQueryCommand() *cobra.Command {
 				return err
 			}
 
-			format := resolveFormat(usePlain, useJSON, state.Config.Presenter())
-			question := strings.TrimSpace(strings.Join(args, " "))
-			traceID := newTraceID()
+			if path := strings.TrimSpace(batchFile); path != "" {
+				if presenters.plain || presenters.yaml || presenters.html || stream || usePager || strings.TrimSpace(outputFile) != "" || failOnNoAnswer || referencesOnly {
+					return errors.New("ragman: --batch only supports --json among the presenter and output flags")
+				}
+				return runBatch(cmd.Context(), cmd.OutOrStdout(), state, batchOptions{
+					path:             path,
+					ndjson:           presenters.json,
+					conversationID:   conversationID,
+					maxContextTokens: maxContextTokens,
+					timeout:          resolveQueryTimeout(cmd, queryTimeoutSecs, state),
+					sourceTypes:      sourceTypes,
+					language:         language,
+					indexVersion:     indexVersion,
+					withHistory:      withHistory,
+					noHistory:        noHistory,
+					debugIPC:         debugIPC,
+				})
+			}
+
+			format := resolveFormat(presenters, state.Config.Presenter())
+			color, err := resolveColor(colorMode, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			question, err := resolveQuestion(cmd.InOrStdin(), args, questionFile)
+			if err != nil {
+				return err
+			}
+			traceID := requestTraceID()
 			logger := state.Logger.With(
 				slog.String("command", "query"),
 				slog.String("trace_id", traceID),
@@ -60,14 +159,10 @@ func newQueryCommand() *cobra.Command {
 				slog.Int("context_tokens", maxContextTokens),
 			)
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(queryTimeoutSecs)*time.Second)
+			ctx, cancel := context.WithTimeout(cmd.Context(), resolveQueryTimeout(cmd, queryTimeoutSecs, state))
 			defer cancel()
 
-			client, err := ipc.NewClient(ipc.Config{
-				SocketPath: state.SocketPath,
-				ClientID:   "ragman-cli",
-				Logger:     silentLogger(),
-			})
+			client, err := dialBackend(state, ipcClientLogger(cmd.Context(), state, debugIPC))
 			if err != nil {
 				logger.Error("ragman query connection failed", slog.String("error", err.Error()))
 				return fmt.Errorf("ragman: connect backend: %w", err)
@@ -79,63 +174,461 @@ func newQueryCommand() *cobra.Command {
 				ConversationID:   strings.TrimSpace(conversationID),
 				MaxContextTokens: maxContextTokens,
 				TraceID:          traceID,
+				SourceTypes:      sourceTypes,
+				Language:         language,
+				IndexVersion:     indexVersion,
+			}
+			if withHistory > 0 {
+				request.History = historyTurns(state, logger, withHistory)
 			}
 
-			response, err := client.Query(ctx, request)
+			var (
+				response ipc.QueryResponse
+				streamed bool
+			)
+			if stream {
+				response, streamed, err = streamQuery(ctx, client, request, cmd.OutOrStdout(), format)
+			} else {
+				response, err = client.Query(ctx, request)
+			}
 			if err != nil {
 				logger.Error("ragman query failed", slog.String("error", err.Error()))
+				printBackendRemediation(cmd.ErrOrStderr(), err)
 				return fmt.Errorf("ragman: query backend: %w", err)
 			}
 
-			output, err := renderio.Render(response, renderio.Options{
-				ConfidenceThreshold: state.Config.ConfidenceThreshold(),
-				TraceID:             coalesce(response.TraceID, traceID),
-				Presenter:           format,
-			})
+			renderOpts := renderio.Options{
+				ConfidenceThreshold:   state.Config.ConfidenceThreshold(),
+				WarnBandWidth:         state.Config.ConfidenceWarnBand(),
+				TraceID:               coalesce(response.TraceID, traceID),
+				Presenter:             format,
+				SummaryStreamed:       streamed,
+				Color:                 color,
+				MarkdownTemplatePath:  state.Config.MarkdownTemplatePath(),
+				PlainTemplatePath:     state.Config.PlainTemplatePath(),
+				Logger:                logger,
+				MaxReferences:         maxReferences,
+				MaxExcerptRunes:       excerptWidth,
+				RequestedIndexVersion: indexVersion,
+				CitationOrder:         order,
+				ReferencesOnly:        referencesOnly,
+				ConfidenceFormat:      confidenceDisplay,
+				Width:                 resolveWrapWidth(wrapWidth, cmd.Flags().Changed("width"), cmd.OutOrStdout()),
+			}
+			output, err := renderio.Render(response, renderOpts)
 			if err != nil {
 				logger.Error("ragman render failed", slog.String("error", err.Error()))
 				return err
 			}
 
-			fmt.Fprintln(cmd.OutOrStdout(), output)
+			if streamed {
+				fmt.Fprint(cmd.OutOrStdout(), "\n\n")
+			}
+			if !usePager || streamed || !pageOutput(cmd.OutOrStdout(), cmd.ErrOrStderr(), output, format, logger) {
+				fmt.Fprintln(cmd.OutOrStdout(), output)
+			}
+			var fileErr error
+			if path := strings.TrimSpace(outputFile); path != "" {
+				fileOpts := renderOpts
+				fileOpts.Presenter = resolveFormat(presenterFlags{}, coalesce(outputFileFormat, string(format)))
+				fileOpts.SummaryStreamed = false
+				fileOpts.Color = false
+				fileOpts.Width = resolveWrapWidth(wrapWidth, cmd.Flags().Changed("width"), nil)
+				if fileErr = writeOutputFile(path, response, fileOpts); fileErr != nil {
+					logger.Error("ragman output file failed", slog.String("path", path), slog.String("error", fileErr.Error()))
+				}
+			}
+			if !noHistory && state.Config.HistoryEnabled() {
+				recordHistory(state, logger, question, coalesce(response.TraceID, traceID), response)
+			}
+			recordQueryAudit(state, logger, question, coalesce(response.TraceID, traceID), response)
 			logger.Info(
 				"ragman query completed",
 				slog.Float64("confidence", response.Confidence),
 				slog.Bool("no_answer", response.NoAnswer),
 				slog.Int("latency_ms", response.LatencyMS),
 			)
+			if fileErr != nil {
+				return fileErr
+			}
+			if failOnNoAnswer && (response.NoAnswer || response.Confidence < state.Config.ConfidenceThreshold()) {
+				return &ExitError{Code: ExitNoAnswer, Err: errors.New("ragman: no answer met the confidence threshold")}
+			}
 			return nil
 		},
 	}
 
-	cmd.Flags().BoolVar(&usePlain, "plain", false, "Render plain text output (no headings)")
-	cmd.Flags().BoolVar(&useJSON, "json", false, "Emit JSON payload instead of human-readable text")
+	cmd.Flags().BoolVar(&presenters.plain, "plain", false, "Render plain text output (no headings)")
+	cmd.Flags().BoolVar(&presenters.json, "json", false, "Emit JSON payload instead of human-readable text")
+	cmd.Flags().BoolVar(&presenters.yaml, "yaml", false, "Emit YAML payload instead of human-readable text")
+	cmd.Flags().BoolVar(&presenters.html, "html", false, "Render a self-contained HTML fragment")
 	cmd.Flags().StringVar(&conversationID, "conversation", "", "Conversation identifier to maintain context")
 	cmd.Flags().IntVar(&maxContextTokens, "context-tokens", 0, "Override maximum context tokens sent to the backend")
-	cmd.Flags().IntVar(&queryTimeoutSecs, "timeout-seconds", 30, "Timeout in seconds for backend queries")
+	cmd.Flags().IntVar(&queryTimeoutSecs, "timeout-seconds", 30, "Timeout in seconds for backend queries (defaults to query_timeout_seconds in config)")
+	cmd.Flags().StringVar(&colorMode, "color", colorAuto, "Colorize markdown/plain output (auto|always|never)")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Print the answer summary incrementally as the backend streams it")
+	cmd.Flags().StringVar(&batchFile, "batch", "", "Ask every question in this file (one per line) and emit a JSON array of results (NDJSON with --json)")
+	cmd.Flags().StringVar(&questionFile, "question-file", "", "Read the question from the given file instead of arguments")
+	cmd.Flags().StringArrayVar(&sourceTypes, "source-type", nil, "Restrict answers to a source type (man|kiwix|info); repeatable")
+	cmd.Flags().StringVar(&indexVersion, "index-version", "", "Pin the query to a specific catalog index version; warns when the backend answers from another")
+	cmd.Flags().StringVar(&language, "lang", "", "Restrict answers to sources in the given language (e.g. en)")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Also write the rendered answer to this file, creating parent directories")
+	cmd.Flags().StringVar(&outputFileFormat, "output-format-file", "", "Presenter for --output-file (markdown|plain|json|yaml|html; defaults to the terminal presenter)")
+	cmd.Flags().IntVar(&maxReferences, "max-references", 0, "Show at most N references in markdown/plain/html output (0 = unlimited; JSON stays complete)")
+	cmd.Flags().IntVar(&excerptWidth, "excerpt-width", defaultExcerptWidth, "Truncate citation excerpts in markdown/plain/html output to N characters (0 = unlimited; JSON stays complete)")
+	cmd.Flags().StringVar(&citationOrder, "citation-order", citationOrder, "Order references by alias or by relevance score (alias|score; score needs every citation scored)")
+	cmd.Flags().BoolVar(&referencesOnly, "references-only", false, "Print only the references (and trace ID), or only references/citations with --json/--yaml")
+	cmd.Flags().StringVar(&confidenceFormat, "confidence-format", confidenceFormat, "Print confidence as whole percent, one-decimal percent, or a fraction (percent|percent1|fraction)")
+	cmd.Flags().IntVar(&wrapWidth, "width", 0, "Wrap plain output at N columns (0 disables; defaults to the terminal width, or 80 when not a terminal)")
+	cmd.Flags().BoolVar(&failOnNoAnswer, "fail-on-no-answer", false, "Exit with status 2 after rendering when the backend has no answer above the confidence threshold")
+	cmd.Flags().IntVar(&withHistory, "with-history", 0, "Send the last N answered questions from the local history with the query")
+	cmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not record this question in the local query history")
+	cmd.Flags().BoolVar(&debugIPC, "debug-ipc", false, "Log IPC handshake, retry, and request diagnostics to stderr")
+	cmd.Flags().BoolVar(&usePager, "pager", false, "Page long markdown/plain answers through $RAGMAN_PAGER, $PAGER or less -R")
 
 	return cmd
 }
 
+// resolveQueryTimeout prefers an explicit --timeout-seconds flag and otherwise uses the configured default.
+func resolveQueryTimeout(cmd *cobra.Command, flagValue int, state *runtimeState) time.Duration {
+	seconds := state.Config.QueryTimeoutSeconds()
+	if cmd.Flags().Changed("timeout-seconds") {
+		seconds = flagValue
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// streamQuery issues a streaming query, printing the summary as it grows for human-readable presenters.
+// It reports whether summary text was already written so the final render can skip it.
+func streamQuery(ctx context.Context, client *ipc.Client, request ipc.QueryRequest, out io.Writer, format renderio.Format) (ipc.QueryResponse, bool, error) {
+	if format != renderio.FormatMarkdown && format != renderio.FormatPlain {
+		response, err := client.QueryStream(ctx, request, nil)
+		return response, false, err
+	}
+
+	incremental := renderio.NewIncrementalRenderer(out)
+	response, err := client.QueryStream(ctx, request, func(chunk ipc.QueryResponse) error {
+		if chunk.NoAnswer {
+			return nil
+		}
+		return incremental.Update(chunk)
+	})
+	return response, incremental.Streamed(), err
+}
+
+// recordHistory appends the answered question to the local query log.
+// Failures are logged and never surface to the user.
+func recordHistory(state *runtimeState, logger *slog.Logger, question, traceID string, response ipc.QueryResponse) {
+	if state.History == nil {
+		return
+	}
+	err := state.History.Append(history.Entry{
+		Timestamp:  time.Now().UTC(),
+		Question:   question,
+		Answer:     strings.TrimSpace(response.Summary),
+		TraceID:    traceID,
+		Confidence: response.Confidence,
+	})
+	if err != nil {
+		logger.Warn("ragman history append failed", slog.String("error", err.Error()))
+	}
+}
+
+// historyTurns loads the newest limit history entries as replayable turns, oldest first.
+// History is best-effort: when it is unavailable or unreadable the query is sent without turns.
+func historyTurns(state *runtimeState, logger *slog.Logger, limit int) []ipc.QueryTurn {
+	if state.History == nil {
+		logger.Warn("ragman history unavailable; sending query without history")
+		return nil
+	}
+	entries, err := state.History.Recent(limit)
+	if err != nil {
+		logger.Warn("ragman history read failed", slog.String("error", err.Error()))
+		return nil
+	}
+	turns := make([]ipc.QueryTurn, 0, len(entries))
+	for _, entry := range entries {
+		turns = append(turns, ipc.QueryTurn{Question: entry.Question, Answer: entry.Answer})
+	}
+	return turns
+}
+
+// questionHashLength is the number of hex characters of the question digest kept as the audit target.
+const questionHashLength = 16
+
+// recordQueryAudit appends a `query` entry to the shared audit log when query auditing is enabled.
+// The question itself is never written; only a truncated SHA-256 digest identifies it.
+// Failures are logged and never surface to the user.
+func recordQueryAudit(state *runtimeState, logger *slog.Logger, question, traceID string, response ipc.QueryResponse) {
+	if state.Audit == nil {
+		return
+	}
+	status := "success"
+	if response.NoAnswer {
+		status = "no_answer"
+	}
+	digest := sha256.Sum256([]byte(question))
+	entry := map[string]any{
+		"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		"actor":     "ragman",
+		"action":    "query",
+		"target":    "sha256:" + hex.EncodeToString(digest[:])[:questionHashLength],
+		"status":    status,
+		"details":   fmt.Sprintf("confidence=%.2f", response.Confidence),
+	}
+	if traceID != "" {
+		entry["trace_id"] = traceID
+	}
+	if err := state.Audit.Append(entry); err != nil {
+		logger.Warn("ragman audit append failed", slog.String("error", err.Error()))
+	}
+}
+
+// defaultPager is used when neither RAGMAN_PAGER nor PAGER is set.
+const defaultPager = "less -R"
+
+// pageOutput pipes markdown/plain output through the configured pager when stdout is a terminal
+// and the answer would not fit on screen. It reports whether the output was written by the pager;
+// when false the caller prints the output directly.
+func pageOutput(out, errOut io.Writer, output string, format renderio.Format, logger *slog.Logger) bool {
+	if format != renderio.FormatMarkdown && format != renderio.FormatPlain {
+		return false
+	}
+	if !termutil.IsTerminal(out) {
+		return false
+	}
+	height := termutil.Height(out)
+	if height <= 0 || strings.Count(output, "\n")+1 < height {
+		return false
+	}
+
+	fields := strings.Fields(resolvePager())
+	if len(fields) == 0 {
+		return false
+	}
+	binary, err := exec.LookPath(fields[0])
+	if err != nil {
+		logger.Warn("ragman pager unavailable; printing directly", slog.String("pager", fields[0]), slog.String("error", err.Error()))
+		return false
+	}
+
+	pager := exec.Command(binary, fields[1:]...)
+	pager.Stdin = strings.NewReader(output + "\n")
+	pager.Stdout = out
+	pager.Stderr = errOut
+	if err := pager.Start(); err != nil {
+		logger.Warn("ragman pager failed to start; printing directly", slog.String("pager", binary), slog.String("error", err.Error()))
+		return false
+	}
+	if err := pager.Wait(); err != nil {
+		logger.Warn("ragman pager exited with error", slog.String("pager", binary), slog.String("error", err.Error()))
+	}
+	return true
+}
+
+// resolvePager picks the pager command from RAGMAN_PAGER, then PAGER, then less -R.
+func resolvePager() string {
+	for _, key := range []string{"RAGMAN_PAGER", "PAGER"} {
+		if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+			return value
+		}
+	}
+	return defaultPager
+}
+
+// resolveQuestion assembles the question from positional arguments, stdin (`-`), or a question file.
+func resolveQuestion(stdin io.Reader, args []string, questionFile string) (string, error) {
+	var question string
+	switch {
+	case strings.TrimSpace(questionFile) != "":
+		data, err := os.ReadFile(strings.TrimSpace(questionFile))
+		if err != nil {
+			return "", fmt.Errorf("ragman: read question file: %w", err)
+		}
+		question = joinQuestionLines(string(data))
+	case len(args) == 1 && args[0] == "-":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("ragman: read question from stdin: %w", err)
+		}
+		question = joinQuestionLines(string(data))
+	default:
+		question = strings.TrimSpace(strings.Join(args, " "))
+	}
+
+	if question == "" {
+		return "", errors.New("ragman: question must be provided")
+	}
+	return question, nil
+}
+
+// joinQuestionLines flattens multi-line input the same way positional arguments are joined.
+func joinQuestionLines(content string) string {
+	lines := strings.Split(content, "\n")
+	parts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// isValidSourceType reports whether value names a catalog source type understood by the backend.
+func isValidSourceType(value string) bool {
+	switch strings.ToLower(value) {
+	case "man", "kiwix", "info":
+		return true
+	default:
+		return false
+	}
+}
+
+// writeOutputFile renders the response with opts and writes it to path. It runs after the terminal
+// output so a failed write never loses the answer already printed.
+func writeOutputFile(path string, response ipc.QueryResponse, opts renderio.Options) error {
+	output, err := renderio.Render(response, opts)
+	if err != nil {
+		return fmt.Errorf("ragman: render output file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("ragman: create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(output+"\n"), 0o644); err != nil {
+		return fmt.Errorf("ragman: write output file: %w", err)
+	}
+	return nil
+}
+
+// isValidPresenterName reports whether value names a supported presenter.
+func isValidPresenterName(value string) bool {
+	switch renderio.Format(strings.ToLower(strings.TrimSpace(value))) {
+	case renderio.FormatMarkdown, renderio.FormatPlain, renderio.FormatJSON, renderio.FormatYAML, renderio.FormatHTML:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseCitationOrder validates the --citation-order flag.
+func parseCitationOrder(value string) (renderio.CitationOrder, error) {
+	switch order := renderio.CitationOrder(strings.ToLower(strings.TrimSpace(value))); order {
+	case renderio.CitationOrderAlias, renderio.CitationOrderScore:
+		return order, nil
+	default:
+		return "", fmt.Errorf("ragman: unsupported --citation-order value %q (expected alias|score)", value)
+	}
+}
+
+// parseConfidenceFormat validates the --confidence-format flag.
+func parseConfidenceFormat(value string) (renderio.ConfidenceFormat, error) {
+	switch format := renderio.ConfidenceFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case renderio.ConfidenceFormatPercent, renderio.ConfidenceFormatPercent1, renderio.ConfidenceFormatFraction:
+		return format, nil
+	default:
+		return "", fmt.Errorf("ragman: unsupported --confidence-format value %q (expected percent|percent1|fraction)", value)
+	}
+}
+
+// validateContextTokens rejects --context-tokens values the backend would refuse, before any connection is made.
+func validateContextTokens(value int) error {
+	if value < 0 || value > ipc.MaxContextTokensLimit {
+		return fmt.Errorf("ragman: --context-tokens must be between 1 and %d (or 0 for the default), got %d", ipc.MaxContextTokensLimit, value)
+	}
+	return nil
+}
+
+// printBackendRemediation surfaces backend-provided remediation guidance for failed requests.
+func printBackendRemediation(out io.Writer, err error) {
+	var backendErr *ipc.BackendError
+	if errors.As(err, &backendErr) && backendErr.Remediation != "" {
+		fmt.Fprintf(out, "Remediation: %s\n", backendErr.Remediation)
+	}
+}
+
+// newBackendClient dials the backend socket configured for the current invocation.
+func newBackendClient(state *runtimeState) (*ipc.Client, error) {
+	return dialBackend(state, silentLogger())
+}
+
+// dialBackend dials the backend socket with the given logger for IPC client diagnostics.
+func dialBackend(state *runtimeState, logger *slog.Logger) (*ipc.Client, error) {
+	return ipc.NewClient(ipc.Config{
+		SocketPath:    state.SocketPath,
+		ClientID:      coalesce(state.Config.ClientID(), defaultClientID),
+		Logger:        logger,
+		DialTimeout:   state.DialTimeout,
+		RetrySchedule: state.RetrySchedule,
+	})
+}
+
+// ipcClientLogger returns the logger handed to the IPC client. Without --debug-ipc the client stays
+// silent; with it, the state logger is reused when it already emits debug records, otherwise a
+// debug-level copy writing to the same stderr destination is used.
+func ipcClientLogger(ctx context.Context, state *runtimeState, debugIPC bool) *slog.Logger {
+	if !debugIPC {
+		return silentLogger()
+	}
+	if state.Logger != nil && state.Logger.Enabled(ctx, slog.LevelDebug) {
+		return state.Logger
+	}
+	return newLogger(slog.LevelDebug)
+}
+
+// presenterFlags captures the mutually exclusive presenter override flags.
+type presenterFlags struct {
+	plain bool
+	json  bool
+	yaml  bool
+	html  bool
+}
+
+// count returns how many presenter overrides were requested.
+func (f presenterFlags) count() int {
+	var count int
+	for _, set := range []bool{f.plain, f.json, f.yaml, f.html} {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
 // resolveFormat determines the output presenter from flag and configuration inputs.
-func resolveFormat(plain, json bool, configured string) renderio.Format {
+func resolveFormat(flags presenterFlags, configured string) renderio.Format {
 	switch {
-	case json:
+	case flags.json:
 		return renderio.FormatJSON
-	case plain:
+	case flags.yaml:
+		return renderio.FormatYAML
+	case flags.plain:
 		return renderio.FormatPlain
+	case flags.html:
+		return renderio.FormatHTML
 	default:
 		switch strings.ToLower(configured) {
 		case string(renderio.FormatPlain):
 			return renderio.FormatPlain
 		case string(renderio.FormatJSON):
 			return renderio.FormatJSON
+		case string(renderio.FormatYAML):
+			return renderio.FormatYAML
+		case string(renderio.FormatHTML):
+			return renderio.FormatHTML
 		default:
 			return renderio.FormatMarkdown
 		}
 	}
 }
 
+// requestTraceID returns the --trace-id value when given, otherwise a fresh trace identifier.
+func requestTraceID() string {
+	if rootOpts.traceID != "" {
+		return rootOpts.traceID
+	}
+	return newTraceID()
+}
+
 // newTraceID creates a correlation identifier for CLI↔backend requests.
 func newTraceID() string {
 	var buf [16]byte
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	renderio "github.com/linux-rag-t2/cli/ragman/internal/io"
+	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/spf13/cobra"
+)
+
+// maxReplLineBytes bounds a single question read from the interactive prompt.
+const maxReplLineBytes = 1 << 20
+
+// newReplCommand constructs the `repl` subcommand that keeps one backend session open across questions.
+func newReplCommand() *cobra.Command {
+	var (
+		maxContextTokens int
+		queryTimeoutSecs = 30
+	)
+
+	cmd := &cobra.Command{
+		Use:   "repl",
+		Short: "Start an interactive question session with the local RAG backend",
+		Long: "repl keeps a single backend connection open and answers questions read line-by-line from stdin.\n" +
+			"Commands: /new starts a new conversation, /plain, /json and /markdown switch the presenter, /quit exits.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateContextTokens(maxContextTokens); err != nil {
+				return err
+			}
+			state, err := obtainState(cmd)
+			if err != nil {
+				return err
+			}
+
+			session := &replSession{
+				state:            state,
+				out:              cmd.OutOrStdout(),
+				errOut:           cmd.ErrOrStderr(),
+				logger:           state.Logger.With(slog.String("command", "repl")),
+				format:           resolveFormat(presenterFlags{}, state.Config.Presenter()),
+				maxContextTokens: maxContextTokens,
+				timeout:          resolveQueryTimeout(cmd, queryTimeoutSecs, state),
+			}
+			defer session.close()
+
+			return session.run(cmd.Context(), cmd.InOrStdin())
+		},
+	}
+
+	cmd.Flags().IntVar(&maxContextTokens, "context-tokens", 0, "Override maximum context tokens sent to the backend")
+	cmd.Flags().IntVar(&queryTimeoutSecs, "timeout-seconds", 30, "Timeout in seconds for each backend query (defaults to query_timeout_seconds in config)")
+
+	return cmd
+}
+
+// replSession holds the connection and presentation state shared by every prompt in a REPL run.
+type replSession struct {
+	state  *runtimeState
+	out    io.Writer
+	errOut io.Writer
+	logger *slog.Logger
+	client *ipc.Client
+
+	format           renderio.Format
+	conversationID   string
+	maxContextTokens int
+	timeout          time.Duration
+}
+
+// run reads questions until `/quit` or EOF, answering each over the shared connection.
+func (s *replSession) run(ctx context.Context, in io.Reader) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := s.connect(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxReplLineBytes)
+	for {
+		if _, err := fmt.Fprintf(s.out, "ragman [%s]> ", s.format); err != nil {
+			return err
+		}
+		if !scanner.Scan() {
+			_, _ = fmt.Fprintln(s.out)
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("ragman: read input: %w", err)
+			}
+			return nil
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "/") {
+			if quit := s.handleCommand(line); quit {
+				return nil
+			}
+			continue
+		}
+
+		if err := s.ask(ctx, line); err != nil {
+			return err
+		}
+	}
+}
+
+// handleCommand applies a slash command and reports whether the session should end.
+func (s *replSession) handleCommand(line string) bool {
+	switch strings.ToLower(line) {
+	case "/quit":
+		return true
+	case "/new":
+		s.conversationID = ""
+		_, _ = fmt.Fprintln(s.out, "Started a new conversation.")
+	case "/plain":
+		s.format = renderio.FormatPlain
+	case "/json":
+		s.format = renderio.FormatJSON
+	case "/markdown":
+		s.format = renderio.FormatMarkdown
+	default:
+		_, _ = fmt.Fprintf(s.errOut, "Unknown command %q (expected /new, /plain, /json, /markdown or /quit)\n", line)
+	}
+	return false
+}
+
+// ask sends a single question, reconnecting once when the backend connection drops.
+// Only a failed reconnect ends the session; other query errors are reported and the prompt continues.
+// A transport failure such as a timeout leaves the connection unusable, so the next question redials.
+func (s *replSession) ask(ctx context.Context, question string) error {
+	if s.client == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	if s.conversationID == "" {
+		s.conversationID = newTraceID()
+	}
+	traceID := requestTraceID()
+	logger := s.logger.With(
+		slog.String("trace_id", traceID),
+		slog.String("conversation_id", s.conversationID),
+	)
+	logger.Info("ragman repl query started", slog.String("presenter", string(s.format)))
+
+	request := ipc.QueryRequest{
+		Question:         question,
+		ConversationID:   s.conversationID,
+		MaxContextTokens: s.maxContextTokens,
+		TraceID:          traceID,
+	}
+
+	response, err := s.query(ctx, request)
+	if err != nil && isDisconnectError(err) {
+		logger.Warn("ragman repl connection lost; reconnecting", slog.String("error", err.Error()))
+		if connErr := s.connect(); connErr != nil {
+			logger.Error("ragman repl reconnect failed", slog.String("error", connErr.Error()))
+			return connErr
+		}
+		response, err = s.query(ctx, request)
+	}
+	if err != nil {
+		logger.Error("ragman repl query failed", slog.String("error", err.Error()))
+		_, _ = fmt.Fprintf(s.errOut, "ragman: query backend: %v\n", err)
+		printBackendRemediation(s.errOut, err)
+		var backendErr *ipc.BackendError
+		if !errors.As(err, &backendErr) {
+			s.close()
+		}
+		return nil
+	}
+
+	output, err := renderio.Render(response, renderio.Options{
+		ConfidenceThreshold:  s.state.Config.ConfidenceThreshold(),
+		WarnBandWidth:        s.state.Config.ConfidenceWarnBand(),
+		TraceID:              coalesce(response.TraceID, traceID),
+		Presenter:            s.format,
+		MarkdownTemplatePath: s.state.Config.MarkdownTemplatePath(),
+		PlainTemplatePath:    s.state.Config.PlainTemplatePath(),
+		Logger:               logger,
+	})
+	if err != nil {
+		logger.Error("ragman render failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	if _, err := fmt.Fprintln(s.out, output); err != nil {
+		return err
+	}
+	logger.Info(
+		"ragman repl query completed",
+		slog.Float64("confidence", response.Confidence),
+		slog.Bool("no_answer", response.NoAnswer),
+		slog.Int("latency_ms", response.LatencyMS),
+	)
+	return nil
+}
+
+// query issues the request on the current connection using the per-question timeout.
+func (s *replSession) query(ctx context.Context, request ipc.QueryRequest) (ipc.QueryResponse, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.client.Query(queryCtx, request)
+}
+
+// connect replaces any existing client with a freshly handshaken connection.
+func (s *replSession) connect() error {
+	s.close()
+	client, err := newBackendClient(s.state)
+	if err != nil {
+		s.logger.Error("ragman repl connection failed", slog.String("error", err.Error()))
+		return fmt.Errorf("ragman: connect backend: %w", err)
+	}
+	s.client = client
+	return nil
+}
+
+// close releases the current backend connection, if any.
+func (s *replSession) close() {
+	if s.client == nil {
+		return
+	}
+	_ = s.client.Close()
+	s.client = nil
+}
+
+// isDisconnectError reports whether the error indicates the backend connection was lost.
+func isDisconnectError(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
@@ -4,36 +4,61 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
-	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/linux-rag-t2/cli/ragman/internal/config"
+	"github.com/linux-rag-t2/cli/ragman/internal/history"
+	"github.com/linux-rag-t2/cli/shared/audit"
+	"github.com/linux-rag-t2/cli/shared/cliflags"
+	"github.com/linux-rag-t2/cli/shared/ipc"
 	"github.com/spf13/cobra"
 )
 
 type appStateKey struct{}
 
 type runtimeState struct {
-	Config     config.Config
-	ConfigPath string
-	SocketPath string
-	Logger     *slog.Logger
+	Config        config.Config
+	ConfigPath    string
+	SocketPath    string
+	Logger        *slog.Logger
+	DialTimeout   time.Duration
+	RetrySchedule []time.Duration
+	History       *history.Logger
+	Audit         *audit.Logger
 }
 
+const (
+	// defaultClientID identifies ragman in the handshake unless the config overrides it.
+	defaultClientID = "ragman-cli"
+	// defaultDialTimeout bounds connecting to the backend socket unless --dial-timeout overrides it.
+	defaultDialTimeout = 2 * time.Second
+)
+
 type rootOptions struct {
-	configPath string
-	socketPath string
+	configPath  string
+	socketPath  string
+	traceID     string
+	logLevel    string
+	verbosity   int
+	dialTimeout time.Duration
+	retryDelays string
 }
 
 var (
 	rootCmd  = newRootCommand()
 	rootOpts = &rootOptions{}
+	// offlineGuard keeps outbound HTTP on loopback while a command runs unless --offline=false is given.
+	offlineGuard = &cliflags.OfflineGuard{}
 )
 
 // Execute runs the ragman command hierarchy.
 func Execute() error {
+	// PersistentPostRun is skipped when a command fails, so restore the guard here as well.
+	defer offlineGuard.Disable()
 	return rootCmd.Execute()
 }
 
@@ -43,8 +68,12 @@ func newRootCommand() *cobra.Command {
 		Short: "Ask Linux questions backed by the local rag backend",
 		Long:  "ragman connects to the local RAG backend over a Unix socket to answer Linux questions with citations.",
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			offlineGuard.Enable()
 			return initializeState(cmd)
 		},
+		PersistentPostRun: func(*cobra.Command, []string) {
+			offlineGuard.Disable()
+		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
 		},
@@ -56,13 +85,21 @@ func newRootCommand() *cobra.Command {
 	if err != nil {
 		defaultConfigPath = ""
 	}
-	defaultSocket := defaultSocketPath("")
 
 	cmd.PersistentFlags().StringVar(&rootOpts.configPath, "config", defaultConfigPath, "Path to the ragcli configuration file")
-	cmd.PersistentFlags().StringVar(&rootOpts.socketPath, "socket", defaultSocket, "Unix socket path for the rag backend")
+	offlineGuard.AddFlag(cmd.PersistentFlags())
+	cmd.PersistentFlags().DurationVar(&rootOpts.dialTimeout, "dial-timeout", defaultDialTimeout, "Timeout for connecting to the backend socket (e.g. 500ms, 5s)")
+	cmd.PersistentFlags().StringVar(&rootOpts.retryDelays, "retry-delays", "", "Comma-separated delays between backend read retries (e.g. 250ms,500ms,1s)")
+	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "", "Log level for diagnostics on stderr (debug|info|warn|error); overrides $RAGMAN_LOG_LEVEL")
+	cmd.PersistentFlags().CountVarP(&rootOpts.verbosity, "verbose", "v", "Increase log verbosity (-v for info, -vv for debug)")
+	cmd.PersistentFlags().StringVar(&rootOpts.traceID, "trace-id", "", "Reuse an external trace ID for backend requests, output, and audit entries instead of generating one")
+	cmd.PersistentFlags().StringVar(&rootOpts.socketPath, "socket", "", "Unix socket path for the rag backend (defaults to shared.socket_path, $RAGCLI_SOCKET, then $XDG_RUNTIME_DIR/ragcli/backend.sock)")
 
 	cmd.SetContext(context.Background())
 	cmd.AddCommand(newQueryCommand())
+	cmd.AddCommand(newReplCommand())
+	cmd.AddCommand(newHistoryCommand())
+	cmd.AddCommand(newConfigCommand())
 	return cmd
 }
 
@@ -76,21 +113,62 @@ func initializeState(cmd *cobra.Command) error {
 		return nil
 	}
 
+	if rootOpts.traceID != "" {
+		if err := ipc.ValidateTraceID(rootOpts.traceID); err != nil {
+			return fmt.Errorf("ragman: invalid --trace-id: %w", err)
+		}
+	}
+	if err := cliflags.ValidateDialTimeout(rootOpts.dialTimeout); err != nil {
+		return fmt.Errorf("ragman: %w", err)
+	}
+	retrySchedule, err := ipc.ParseRetrySchedule(rootOpts.retryDelays)
+	if err != nil {
+		return fmt.Errorf("ragman: invalid --retry-delays: %w", err)
+	}
+	level, err := cliflags.ResolveLogLevel(rootOpts.logLevel, rootOpts.verbosity, os.Getenv("RAGMAN_LOG_LEVEL"))
+	if err != nil {
+		return fmt.Errorf("ragman: %w", err)
+	}
+	logger := newLogger(level)
+
 	cfgPath, err := resolveConfigPath(rootOpts.configPath)
 	if err != nil {
 		return err
 	}
+	logger.Debug("ragman loading config", slog.String("path", cfgPath))
 	cfg, err := config.Load(cfgPath)
 	if err != nil {
+		logger.Debug("ragman config load failed", slog.String("path", cfgPath), slog.String("error", err.Error()))
 		return err
 	}
 
-	socket := defaultSocketPath(rootOpts.socketPath)
+	historyLogger, err := history.NewLogger("")
+	if err != nil {
+		// History is best-effort; queries proceed without it.
+		logger.Warn("ragman history unavailable", slog.String("error", err.Error()))
+		historyLogger = nil
+	}
+
+	var auditLogger *audit.Logger
+	if cfg.AuditQueries() {
+		auditLogger, err = audit.NewLoggerWithRedaction("", cfg.AuditRedaction(), cfg.AuditOptions()...)
+		if err != nil {
+			// Query auditing is best-effort; queries proceed without it.
+			logger.Warn("ragman audit log unavailable", slog.String("error", err.Error()))
+			auditLogger = nil
+		}
+	}
+
+	socket := cliflags.ResolveSocketPath(rootOpts.socketPath, cfg.SocketPath())
 	state := &runtimeState{
-		Config:     cfg,
-		ConfigPath: cfgPath,
-		SocketPath: socket,
-		Logger:     newLogger(),
+		Config:        cfg,
+		ConfigPath:    cfgPath,
+		SocketPath:    socket,
+		Logger:        logger,
+		DialTimeout:   rootOpts.dialTimeout,
+		RetrySchedule: retrySchedule,
+		History:       historyLogger,
+		Audit:         auditLogger,
 	}
 
 	root.SetContext(context.WithValue(ctx, appStateKey{}, state))
@@ -117,35 +195,8 @@ func resolveConfigPath(flagValue string) (string, error) {
 	return config.DefaultPath()
 }
 
-// defaultSocketPath determines the backend socket path, respecting flags and environment overrides.
-func defaultSocketPath(flagValue string) string {
-	if trimmed := strings.TrimSpace(flagValue); trimmed != "" {
-		return trimmed
-	}
-	if env := strings.TrimSpace(os.Getenv("RAGCLI_SOCKET")); env != "" {
-		return env
-	}
-	if runtimeDir := strings.TrimSpace(os.Getenv("XDG_RUNTIME_DIR")); runtimeDir != "" {
-		return filepath.Join(runtimeDir, "ragcli", "backend.sock")
-	}
-	return filepath.Join(os.TempDir(), "ragcli", "backend.sock")
-}
-
 // newLogger constructs the structured logger used by the CLI for telemetry.
-func newLogger() *slog.Logger {
-	level := slog.LevelWarn
-	if raw := strings.TrimSpace(os.Getenv("RAGMAN_LOG_LEVEL")); raw != "" {
-		switch strings.ToLower(raw) {
-		case "debug":
-			level = slog.LevelDebug
-		case "info":
-			level = slog.LevelInfo
-		case "warn", "warning":
-			level = slog.LevelWarn
-		case "error":
-			level = slog.LevelError
-		}
-	}
+func newLogger(level slog.Level) *slog.Logger {
 	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
 	return slog.New(handler)
 }
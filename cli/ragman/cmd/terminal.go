@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/linux-rag-t2/cli/shared/termutil"
+)
+
+// Supported values for the --color flag.
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// resolveColor decides whether ANSI styling should be emitted for the given writer.
+// In auto mode color is used only for terminals and when NO_COLOR is unset.
+func resolveColor(mode string, out io.Writer) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case colorAlways:
+		return true, nil
+	case colorNever:
+		return false, nil
+	case colorAuto, "":
+		if _, set := os.LookupEnv("NO_COLOR"); set {
+			return false, nil
+		}
+		return termutil.IsTerminal(out), nil
+	default:
+		return false, fmt.Errorf("ragman: unsupported --color value %q (expected auto|always|never)", mode)
+	}
+}
+
+// defaultWrapWidth is used for plain output when the terminal width cannot be detected.
+const defaultWrapWidth = 80
+
+// resolveWrapWidth picks the plain-output wrap width: an explicit --width (0 disables wrapping),
+// then the detected terminal width, then defaultWrapWidth.
+func resolveWrapWidth(flagValue int, flagSet bool, out io.Writer) int {
+	if flagSet {
+		return flagValue
+	}
+	if termutil.IsTerminal(out) {
+		if cols := termutil.Width(out); cols > 0 {
+			return cols
+		}
+	}
+	return defaultWrapWidth
+}
@@ -4,27 +4,44 @@ package config
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/linux-rag-t2/cli/shared/audit"
+	"github.com/linux-rag-t2/cli/shared/configfile"
 	"gopkg.in/yaml.v3"
 )
 
 const (
 	defaultPresenter           = "markdown"
 	defaultConfidenceThreshold = 0.35
+	defaultConfidenceWarnBand  = 0.1
+	defaultQueryTimeoutSeconds = 30
+	minQueryTimeoutSeconds     = 1
+	maxQueryTimeoutSeconds     = 600
 )
 
 // Config represents the ragcli configuration file.
 type Config struct {
-	Ragman RagmanConfig `yaml:"ragman"`
+	Ragman RagmanConfig      `yaml:"ragman"`
+	Shared configfile.Shared `yaml:"shared"`
 }
 
 // RagmanConfig captures ragman-specific presentation settings.
 type RagmanConfig struct {
-	ConfidenceThreshold float64 `yaml:"confidence_threshold"`
-	PresenterDefault    string  `yaml:"presenter_default"`
+	ConfidenceThreshold  float64  `yaml:"confidence_threshold"`
+	ConfidenceWarnBand   *float64 `yaml:"confidence_warn_band"`
+	PresenterDefault     string   `yaml:"presenter_default"`
+	MarkdownTemplatePath string   `yaml:"markdown_template_path"`
+	PlainTemplatePath    string   `yaml:"plain_template_path"`
+	QueryTimeoutSeconds  int      `yaml:"query_timeout_seconds"`
+	HistoryEnabled       *bool    `yaml:"history_enabled"`
+	AuditQueries         bool     `yaml:"audit_queries"`
+	ClientID             string   `yaml:"client_id"`
 }
 
 // Default returns the default configuration used when no file exists.
@@ -33,37 +50,79 @@ func Default() Config {
 		Ragman: RagmanConfig{
 			ConfidenceThreshold: defaultConfidenceThreshold,
 			PresenterDefault:    defaultPresenter,
+			QueryTimeoutSeconds: defaultQueryTimeoutSeconds,
 		},
 	}
 }
 
+// Environment variables that override file settings for quick experiments.
+const (
+	envConfidenceThreshold = "RAGMAN_CONFIDENCE_THRESHOLD"
+	envPresenter           = "RAGMAN_PRESENTER"
+)
+
 // Load reads the configuration from the provided path. When the file does not exist,
-// the default configuration is returned without error.
+// the default configuration is used. RAGMAN_CONFIDENCE_THRESHOLD and RAGMAN_PRESENTER
+// then override the file values; CLI presenter flags still take precedence over both.
 func Load(path string) (Config, error) {
 	cfg := Default()
+	if err := cfg.loadFile(path); err != nil {
+		return cfg, err
+	}
+
+	cfg.applyEnv()
+	cfg.normalize()
+	return cfg, nil
+}
+
+// loadFile merges the settings found at path into c; a missing or empty file leaves c unchanged.
+func (c *Config) loadFile(path string) error {
 	if strings.TrimSpace(path) == "" {
-		return cfg, nil
+		return nil
 	}
 
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return cfg, nil
+			return nil
 		}
-		return cfg, fmt.Errorf("config: read file: %w", err)
+		return fmt.Errorf("config: read file: %w", err)
 	}
 	if len(data) == 0 {
-		return cfg, nil
+		return nil
 	}
 
-	var raw Config
-	if err := yaml.Unmarshal(data, &raw); err != nil {
-		return cfg, fmt.Errorf("config: decode: %w", err)
+	var schema fileSchema
+	unknown, err := configfile.DecodeStrict(data, &schema)
+	if err != nil {
+		return fmt.Errorf("config: decode: %w", err)
+	}
+	if len(unknown) > 0 {
+		slog.Warn("config: ignoring unknown keys", slog.String("path", path), slog.Any("keys", unknown))
 	}
 
-	cfg.apply(raw)
-	cfg.normalize()
-	return cfg, nil
+	c.apply(Config{Ragman: schema.Ragman, Shared: schema.Shared})
+	return nil
+}
+
+// applyEnv applies environment overrides. Unparseable values are ignored with a warning;
+// out-of-range thresholds are clamped by normalize like file values.
+func (c *Config) applyEnv() {
+	if raw := strings.TrimSpace(os.Getenv(envConfidenceThreshold)); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(value) {
+			slog.Warn("config: ignoring invalid environment override", slog.String("variable", envConfidenceThreshold), slog.String("value", raw))
+		} else {
+			c.Ragman.ConfidenceThreshold = value
+		}
+	}
+	if raw := strings.TrimSpace(os.Getenv(envPresenter)); raw != "" {
+		if isValidPresenter(raw) {
+			c.Ragman.PresenterDefault = raw
+		} else {
+			slog.Warn("config: ignoring invalid environment override", slog.String("variable", envPresenter), slog.String("value", raw))
+		}
+	}
 }
 
 // DefaultPath returns the preferred configuration path derived from XDG conventions.
@@ -83,7 +142,7 @@ func DefaultPath() (string, error) {
 	return filepath.Join(home, ".config", "ragcli", "config.yaml"), nil
 }
 
-// Presenter selects the default presenter identifier (markdown/plain/json).
+// Presenter selects the default presenter identifier (markdown/plain/json/html/yaml).
 func (c Config) Presenter() string {
 	return c.Ragman.PresenterDefault
 }
@@ -93,13 +152,144 @@ func (c Config) ConfidenceThreshold() float64 {
 	return c.Ragman.ConfidenceThreshold
 }
 
+// ConfidenceWarnBand returns the width of the band above the threshold in which answers are shown
+// in yellow with a marginal-confidence note. It defaults to 0.1 when unset; an explicit zero
+// disables both the colour band and the note.
+func (c Config) ConfidenceWarnBand() float64 {
+	if c.Ragman.ConfidenceWarnBand == nil {
+		return defaultConfidenceWarnBand
+	}
+	return *c.Ragman.ConfidenceWarnBand
+}
+
+// QueryTimeoutSeconds returns the default backend query timeout used when --timeout-seconds is not set.
+func (c Config) QueryTimeoutSeconds() int {
+	return c.Ragman.QueryTimeoutSeconds
+}
+
+// HistoryEnabled reports whether answered questions are recorded in the local query log.
+// History is enabled unless the configuration explicitly disables it.
+func (c Config) HistoryEnabled() bool {
+	return c.Ragman.HistoryEnabled == nil || *c.Ragman.HistoryEnabled
+}
+
+// AuditQueries reports whether answered questions are appended to the shared audit log.
+// Query auditing is off unless the configuration enables it.
+func (c Config) AuditQueries() bool {
+	return c.Ragman.AuditQueries
+}
+
+// AuditOptions returns the shared audit log rotation limits, so query entries rotate audit.log the same
+// way ragadmin does.
+func (c Config) AuditOptions() []audit.Option {
+	return c.Shared.AuditOptions()
+}
+
+// AuditRedaction returns the shared masking rules for audit log entries.
+func (c Config) AuditRedaction() audit.RedactionRules {
+	return c.Shared.AuditRedaction()
+}
+
+// SocketPath returns the backend socket pinned in the shared section, or an empty string when unset.
+func (c Config) SocketPath() string {
+	return c.Shared.SocketPath
+}
+
+// ClientID returns the handshake client identifier override, or an empty string for the built-in default.
+func (c Config) ClientID() string {
+	return c.Ragman.ClientID
+}
+
+// MarkdownTemplatePath returns the optional user template replacing the built-in markdown layout.
+func (c Config) MarkdownTemplatePath() string {
+	return c.Ragman.MarkdownTemplatePath
+}
+
+// PlainTemplatePath returns the optional user template replacing the built-in plain layout.
+func (c Config) PlainTemplatePath() string {
+	return c.Ragman.PlainTemplatePath
+}
+
+// fileSchema mirrors the whole shared ragcli file so strict decoding tolerates the sections owned by
+// ragadmin and the backend.
+type fileSchema struct {
+	Ragman   RagmanConfig      `yaml:"ragman"`
+	Shared   configfile.Shared `yaml:"shared"`
+	Ragadmin yaml.Node         `yaml:"ragadmin"`
+	Backend  yaml.Node         `yaml:"backend"`
+}
+
+// Validate strictly decodes the configuration file and returns every problem found: unknown keys,
+// values of the wrong type, out-of-range numbers, and unsupported presenter names. Unlike Load it
+// never falls back to defaults; an error is returned only when the file cannot be read.
+func Validate(path string) ([]string, error) {
+	var raw fileSchema
+	problems, err := configfile.Validate(path, &raw)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, raw.Ragman.validate()...)
+	return append(problems, raw.Shared.Validate()...), nil
+}
+
+// validate reports values that Load would silently clamp or replace.
+func (r RagmanConfig) validate() []string {
+	var problems []string
+	if r.ConfidenceThreshold < 0 || r.ConfidenceThreshold > 1 {
+		problems = append(problems, fmt.Sprintf("ragman.confidence_threshold must be between 0 and 1, got %g", r.ConfidenceThreshold))
+	}
+	if band := r.ConfidenceWarnBand; band != nil && (*band < 0 || *band > 1) {
+		problems = append(problems, fmt.Sprintf("ragman.confidence_warn_band must be between 0 and 1, got %g", *band))
+	}
+	if r.QueryTimeoutSeconds != 0 && (r.QueryTimeoutSeconds < minQueryTimeoutSeconds || r.QueryTimeoutSeconds > maxQueryTimeoutSeconds) {
+		problems = append(problems, fmt.Sprintf("ragman.query_timeout_seconds must be between %d and %d, got %d", minQueryTimeoutSeconds, maxQueryTimeoutSeconds, r.QueryTimeoutSeconds))
+	}
+	if presenter := strings.TrimSpace(r.PresenterDefault); presenter != "" && !isValidPresenter(presenter) {
+		problems = append(problems, fmt.Sprintf("ragman.presenter_default %q is not one of markdown, plain, json, html, yaml", presenter))
+	}
+	return problems
+}
+
+func isValidPresenter(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "markdown", "plain", "json", "html", "yaml":
+		return true
+	default:
+		return false
+	}
+}
+
 func (c *Config) apply(raw Config) {
 	if raw.Ragman.ConfidenceThreshold != 0 {
 		c.Ragman.ConfidenceThreshold = raw.Ragman.ConfidenceThreshold
 	}
+	if raw.Ragman.ConfidenceWarnBand != nil {
+		band := *raw.Ragman.ConfidenceWarnBand
+		c.Ragman.ConfidenceWarnBand = &band
+	}
 	if strings.TrimSpace(raw.Ragman.PresenterDefault) != "" {
 		c.Ragman.PresenterDefault = raw.Ragman.PresenterDefault
 	}
+	if raw.Ragman.QueryTimeoutSeconds != 0 {
+		c.Ragman.QueryTimeoutSeconds = raw.Ragman.QueryTimeoutSeconds
+	}
+	if raw.Ragman.AuditQueries {
+		c.Ragman.AuditQueries = true
+	}
+	if raw.Ragman.HistoryEnabled != nil {
+		enabled := *raw.Ragman.HistoryEnabled
+		c.Ragman.HistoryEnabled = &enabled
+	}
+	if trimmed := strings.TrimSpace(raw.Ragman.MarkdownTemplatePath); trimmed != "" {
+		c.Ragman.MarkdownTemplatePath = trimmed
+	}
+	if trimmed := strings.TrimSpace(raw.Ragman.PlainTemplatePath); trimmed != "" {
+		c.Ragman.PlainTemplatePath = trimmed
+	}
+	if trimmed := strings.TrimSpace(raw.Ragman.ClientID); trimmed != "" {
+		c.Ragman.ClientID = trimmed
+	}
+	c.Shared.Merge(raw.Shared)
 }
 
 func (c *Config) normalize() {
@@ -108,11 +298,23 @@ func (c *Config) normalize() {
 	} else if c.Ragman.ConfidenceThreshold > 1 {
 		c.Ragman.ConfidenceThreshold = 1
 	}
+	if band := c.Ragman.ConfidenceWarnBand; band != nil {
+		if *band < 0 {
+			*band = 0
+		} else if *band > 1 {
+			*band = 1
+		}
+	}
+
+	if c.Ragman.QueryTimeoutSeconds < minQueryTimeoutSeconds {
+		c.Ragman.QueryTimeoutSeconds = minQueryTimeoutSeconds
+	} else if c.Ragman.QueryTimeoutSeconds > maxQueryTimeoutSeconds {
+		c.Ragman.QueryTimeoutSeconds = maxQueryTimeoutSeconds
+	}
 
-	switch strings.ToLower(strings.TrimSpace(c.Ragman.PresenterDefault)) {
-	case "markdown", "plain", "json":
+	if isValidPresenter(c.Ragman.PresenterDefault) {
 		c.Ragman.PresenterDefault = strings.ToLower(strings.TrimSpace(c.Ragman.PresenterDefault))
-	default:
+	} else {
 		c.Ragman.PresenterDefault = defaultPresenter
 	}
 }
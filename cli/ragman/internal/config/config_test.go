@@ -0,0 +1,267 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadQueryTimeoutSeconds(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{name: "unset uses default", content: "ragman:\n  presenter_default: plain\n", want: 30},
+		{name: "explicit value", content: "ragman:\n  query_timeout_seconds: 120\n", want: 120},
+		{name: "negative clamps to minimum", content: "ragman:\n  query_timeout_seconds: -5\n", want: 1},
+		{name: "large clamps to maximum", content: "ragman:\n  query_timeout_seconds: 3600\n", want: 600},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.QueryTimeoutSeconds(); got != tc.want {
+				t.Fatalf("QueryTimeoutSeconds() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFileUsesDefaultTimeout(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.QueryTimeoutSeconds(); got != defaultQueryTimeoutSeconds {
+		t.Fatalf("QueryTimeoutSeconds() = %d, want %d", got, defaultQueryTimeoutSeconds)
+	}
+}
+
+func TestLoadConfidenceWarnBand(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    float64
+	}{
+		{name: "unset uses the default band", content: "ragman:\n  presenter_default: plain\n", want: 0.1},
+		{name: "explicit zero disables the band", content: "ragman:\n  confidence_warn_band: 0\n", want: 0},
+		{name: "explicit value", content: "ragman:\n  confidence_warn_band: 0.15\n", want: 0.15},
+		{name: "negative clamps to zero", content: "ragman:\n  confidence_warn_band: -0.2\n", want: 0},
+		{name: "large clamps to one", content: "ragman:\n  confidence_warn_band: 3\n", want: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.ConfidenceWarnBand(); got != tc.want {
+				t.Fatalf("ConfidenceWarnBand() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadHistoryEnabled(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.HistoryEnabled() {
+		t.Fatal("expected history to be enabled by default")
+	}
+
+	if err := os.WriteFile(path, []byte("ragman:\n  history_enabled: false\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.HistoryEnabled() {
+		t.Fatal("expected history_enabled: false to disable history")
+	}
+}
+
+func TestValidateReportsProblems(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "valid shared file",
+			content: "ragman:\n  confidence_threshold: 0.5\n  presenter_default: plain\nragadmin:\n  output_default: json\n",
+		},
+		{
+			name:    "unknown key",
+			content: "ragman:\n  presenter_defualt: plain\n",
+			want:    []string{`line 2: unknown key "presenter_defualt"`},
+		},
+		{
+			name:    "wrong type",
+			content: "ragman:\n  confidence_threshold: high\n",
+			want:    []string{"cannot unmarshal !!str `high` into float64"},
+		},
+		{
+			name:    "out of range and bad enum",
+			content: "ragman:\n  confidence_threshold: 1.5\n  confidence_warn_band: -0.1\n  query_timeout_seconds: 900\n  presenter_default: fancy\n",
+			want: []string{
+				"confidence_threshold must be between 0 and 1",
+				"confidence_warn_band must be between 0 and 1",
+				"query_timeout_seconds must be between 1 and 600",
+				`presenter_default "fancy"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			problems, err := Validate(path)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if len(problems) != len(tc.want) {
+				t.Fatalf("Validate() = %q, want %d problem(s)", problems, len(tc.want))
+			}
+			for idx, want := range tc.want {
+				if !strings.Contains(problems[idx], want) {
+					t.Fatalf("problem %d = %q, want it to mention %q", idx, problems[idx], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMissingFile(t *testing.T) {
+	if _, err := Validate(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("expected an error for a missing config file")
+	}
+}
+
+func TestLoadWarnsOnUnknownKeys(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "ragman:\n  presenter_defualt: plain\n  confidence_threshold: 0.6\nragadmin:\n  output_default: json\nbackend:\n  log_level: INFO\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.ConfidenceThreshold(); got != 0.6 {
+		t.Fatalf("ConfidenceThreshold() = %v, want 0.6", got)
+	}
+	if got := cfg.Presenter(); got != defaultPresenter {
+		t.Fatalf("Presenter() = %q, want default %q", got, defaultPresenter)
+	}
+	if !strings.Contains(logs.String(), "presenter_defualt") {
+		t.Fatalf("expected warning naming the unknown key, got %q", logs.String())
+	}
+	if strings.Contains(logs.String(), "backend") || strings.Contains(logs.String(), "output_default") {
+		t.Fatalf("expected sections owned by other components to be accepted, got %q", logs.String())
+	}
+}
+
+func TestLoadSharedSocketPathAndClientID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "shared:\n  socket_path: \" /run/ragcli/backend.sock \"\n  audit_max_backups: 1\nragman:\n  client_id: ops-ragman\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.SocketPath(); got != "/run/ragcli/backend.sock" {
+		t.Fatalf("SocketPath() = %q, want /run/ragcli/backend.sock", got)
+	}
+	if got := cfg.ClientID(); got != "ops-ragman" {
+		t.Fatalf("ClientID() = %q, want ops-ragman", got)
+	}
+	if cfg.Shared.AuditMaxBackups == nil || *cfg.Shared.AuditMaxBackups != 1 {
+		t.Fatalf("expected shared.audit_max_backups to load, got %v", cfg.Shared.AuditMaxBackups)
+	}
+}
+
+func TestLoadEnvironmentOverrides(t *testing.T) {
+	cases := []struct {
+		name          string
+		threshold     string
+		presenter     string
+		wantThreshold float64
+		wantPresenter string
+	}{
+		{name: "valid overrides", threshold: "0.7", presenter: "JSON", wantThreshold: 0.7, wantPresenter: "json"},
+		{name: "threshold clamps", threshold: "1.8", wantThreshold: 1, wantPresenter: "plain"},
+		{name: "invalid values ignored", threshold: "high", presenter: "fancy", wantThreshold: 0.5, wantPresenter: "plain"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(envConfidenceThreshold, tc.threshold)
+			t.Setenv(envPresenter, tc.presenter)
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte("ragman:\n  confidence_threshold: 0.5\n  presenter_default: plain\n"), 0o600); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.ConfidenceThreshold(); got != tc.wantThreshold {
+				t.Fatalf("ConfidenceThreshold() = %v, want %v", got, tc.wantThreshold)
+			}
+			if got := cfg.Presenter(); got != tc.wantPresenter {
+				t.Fatalf("Presenter() = %q, want %q", got, tc.wantPresenter)
+			}
+		})
+	}
+}
+
+func TestLoadEnvironmentOverridesWithoutFile(t *testing.T) {
+	t.Setenv(envPresenter, "yaml")
+
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.Presenter(); got != "yaml" {
+		t.Fatalf("Presenter() = %q, want yaml", got)
+	}
+}
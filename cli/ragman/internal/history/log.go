@@ -0,0 +1,124 @@
+// Package history records ragman questions in a local JSON-lines query log.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxEntryBytes bounds a single log line when reading history back.
+const maxEntryBytes = 1 << 20
+
+// Entry captures a single answered question.
+type Entry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Question   string    `json:"question"`
+	Answer     string    `json:"answer,omitempty"`
+	TraceID    string    `json:"trace_id"`
+	Confidence float64   `json:"confidence"`
+}
+
+// Logger appends newline-delimited JSON history entries.
+type Logger struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewLogger creates a logger using the provided path. When empty, the default
+// XDG-compliant history path is used.
+func NewLogger(path string) (*Logger, error) {
+	resolved := strings.TrimSpace(path)
+	if resolved == "" {
+		var err error
+		resolved, err = defaultLogPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Logger{path: resolved}, nil
+}
+
+// Append writes the entry as a JSON line to the history log.
+func (l *Logger) Append(entry Entry) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("history: create directory: %w", err)
+	}
+
+	handle, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("history: open log: %w", err)
+	}
+	defer handle.Close()
+
+	if err := json.NewEncoder(handle).Encode(entry); err != nil {
+		return fmt.Errorf("history: encode entry: %w", err)
+	}
+	return nil
+}
+
+// Recent returns up to limit of the newest entries in chronological order.
+// A missing log yields no entries; malformed lines are skipped.
+func (l *Logger) Recent(limit int) ([]Entry, error) {
+	if l == nil || limit <= 0 {
+		return nil, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	handle, err := os.Open(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("history: open log: %w", err)
+	}
+	defer handle.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(handle)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntryBytes)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+		if len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("history: read log: %w", err)
+	}
+	return entries, nil
+}
+
+func defaultLogPath() (string, error) {
+	if xdg := strings.TrimSpace(os.Getenv("XDG_DATA_HOME")); xdg != "" {
+		return filepath.Join(xdg, "ragcli", "ragman", "history.log"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("history: determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "ragcli", "ragman", "history.log"), nil
+}
@@ -0,0 +1,51 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecentReturnsNewestEntriesInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ragman", "history.log")
+	logger, err := NewLogger(path)
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, question := range []string{"first", "second", "third"} {
+		if err := logger.Append(Entry{Timestamp: base.Add(time.Duration(i) * time.Minute), Question: question}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	handle, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	if _, err := handle.WriteString("not json\n"); err != nil {
+		t.Fatalf("write malformed line: %v", err)
+	}
+	handle.Close()
+
+	entries, err := logger.Recent(2)
+	if err != nil {
+		t.Fatalf("Recent() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Question != "second" || entries[1].Question != "third" {
+		t.Fatalf("unexpected entries: %#v", entries)
+	}
+}
+
+func TestRecentMissingLogReturnsNothing(t *testing.T) {
+	logger, err := NewLogger(filepath.Join(t.TempDir(), "history.log"))
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	entries, err := logger.Recent(10)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected no entries and no error, got %#v, %v", entries, err)
+	}
+}
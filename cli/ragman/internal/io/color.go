@@ -0,0 +1,42 @@
+package io
+
+// ANSI escape sequences used by the colorized markdown/plain presenters.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// styleText wraps text in the ANSI style when color output is enabled.
+func styleText(enabled bool, style, text string) string {
+	if !enabled || text == "" {
+		return text
+	}
+	return style + text + ansiReset
+}
+
+// boldText is exposed to templates for section headings.
+func boldText(enabled bool, text string) string {
+	return styleText(enabled, ansiBold, text)
+}
+
+// dimText is exposed to templates for secondary details such as reference indices.
+func dimText(enabled bool, text string) string {
+	return styleText(enabled, ansiDim, text)
+}
+
+// confidenceStyle picks green above the warning band, yellow inside it, and red below the threshold.
+// A zero band width leaves no yellow band.
+func confidenceStyle(confidence, threshold, bandWidth float64) string {
+	switch {
+	case confidence < threshold:
+		return ansiRed
+	case bandWidth > 0 && confidence < threshold+bandWidth:
+		return ansiYellow
+	default:
+		return ansiGreen
+	}
+}
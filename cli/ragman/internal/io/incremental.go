@@ -0,0 +1,45 @@
+package io
+
+import (
+	"fmt"
+	stdio "io"
+	"strings"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+// IncrementalRenderer prints a query summary as it grows across streamed response chunks.
+type IncrementalRenderer struct {
+	out     stdio.Writer
+	printed string
+}
+
+// NewIncrementalRenderer returns a renderer that writes summary text to out as chunks arrive.
+func NewIncrementalRenderer(out stdio.Writer) *IncrementalRenderer {
+	return &IncrementalRenderer{out: out}
+}
+
+// Update writes the portion of the chunk summary that has not been printed yet.
+// When the backend rewrites earlier text instead of appending, the new summary is printed on a fresh line.
+func (r *IncrementalRenderer) Update(chunk ipc.QueryResponse) error {
+	summary := strings.TrimLeft(chunk.Summary, " \t\r\n")
+	if summary == "" || summary == r.printed {
+		return nil
+	}
+
+	delta := summary
+	if strings.HasPrefix(summary, r.printed) {
+		delta = summary[len(r.printed):]
+	} else if r.printed != "" {
+		delta = "\n" + summary
+	}
+	r.printed = summary
+
+	_, err := fmt.Fprint(r.out, delta)
+	return err
+}
+
+// Streamed reports whether any summary text has been printed.
+func (r *IncrementalRenderer) Streamed() bool {
+	return r.printed != ""
+}
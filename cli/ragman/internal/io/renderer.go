@@ -5,11 +5,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"net/url"
 	"sort"
 	"strings"
-	"text/template"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/linux-rag-t2/cli/shared/ipc"
+	"gopkg.in/yaml.v3"
 )
 
 // Format identifies the output presenter used by the CLI.
@@ -20,6 +25,27 @@ const (
 	FormatMarkdown Format = "markdown"
 	FormatPlain    Format = "plain"
 	FormatJSON     Format = "json"
+	FormatHTML     Format = "html"
+	FormatYAML     Format = "yaml"
+)
+
+// CitationOrder selects how the human-readable presenters order and number references.
+type CitationOrder string
+
+// Supported citation orderings.
+const (
+	CitationOrderAlias CitationOrder = "alias"
+	CitationOrderScore CitationOrder = "score"
+)
+
+// ConfidenceFormat selects how confidence values are printed by the human-readable presenters.
+type ConfidenceFormat string
+
+// Supported confidence formats.
+const (
+	ConfidenceFormatPercent  ConfidenceFormat = "percent"
+	ConfidenceFormatPercent1 ConfidenceFormat = "percent1"
+	ConfidenceFormatFraction ConfidenceFormat = "fraction"
 )
 
 // Options customise the rendering of a query response.
@@ -27,6 +53,40 @@ type Options struct {
 	ConfidenceThreshold float64
 	TraceID             string
 	Presenter           Format
+	// SummaryStreamed omits the summary section because an IncrementalRenderer already printed it.
+	SummaryStreamed bool
+	// Color enables ANSI styling for the markdown and plain presenters.
+	Color bool
+	// MarkdownTemplatePath and PlainTemplatePath replace the built-in text templates when set.
+	MarkdownTemplatePath string
+	PlainTemplatePath    string
+	// Logger receives warnings when a custom template cannot be used; nil disables them.
+	Logger *slog.Logger
+	// MaxReferences caps the reference entries shown by the human-readable presenters; the rest
+	// are summarised as "(+N more)". Zero means unlimited. JSON and YAML output are never capped.
+	MaxReferences int
+	// MaxExcerptRunes truncates citation excerpts in the human-readable presenters to this many
+	// runes followed by an ellipsis. Zero means unlimited. JSON and YAML output are never truncated.
+	MaxExcerptRunes int
+	// Width word-wraps plain output to this many columns without breaking words or URLs.
+	// Zero disables wrapping.
+	Width int
+	// RequestedIndexVersion is the catalog version pinned via --index-version. A warning is shown
+	// when the backend reports answering from a different version.
+	RequestedIndexVersion string
+	// CitationOrder lists the most relevant citations first when set to CitationOrderScore and
+	// every citation carries a score; otherwise citations are ordered by alias and document.
+	CitationOrder CitationOrder
+	// ReferencesOnly limits the output to the references section and trace ID, or to the
+	// references, citations and no_answer keys for JSON and YAML. No-answer responses still show
+	// the fallback, which structured output carries under a fallback key.
+	ReferencesOnly bool
+	// WarnBandWidth is the margin above the threshold in which answers are coloured yellow and
+	// carry a marginal-confidence note. Zero disables both; the 0.1 default comes from the config.
+	WarnBandWidth float64
+	// ConfidenceFormat prints confidence as whole percent (default), percent with one decimal, or a
+	// raw fraction. JSON and YAML output always carry the raw value.
+	ConfidenceFormat ConfidenceFormat
 }
 
 // Render generates a formatted representation of the backend query response.
@@ -36,6 +96,10 @@ func Render(resp ipc.QueryResponse, opts Options) (string, error) {
 		return renderPlain(resp, opts), nil
 	case FormatJSON:
 		return renderJSON(resp, opts)
+	case FormatHTML:
+		return renderHTML(resp, opts)
+	case FormatYAML:
+		return renderYAML(resp, opts)
 	case FormatMarkdown, "":
 		return renderMarkdown(resp, opts), nil
 	default:
@@ -44,10 +108,49 @@ func Render(resp ipc.QueryResponse, opts Options) (string, error) {
 }
 
 func renderJSON(resp ipc.QueryResponse, opts Options) (string, error) {
+	data, err := json.MarshalIndent(structuredPayload(resp, opts), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("renderer: encode json: %w", err)
+	}
+	return string(data), nil
+}
+
+// renderYAML emits the same field set as renderJSON encoded as YAML.
+// The payload is normalised through JSON first so nested records keep their JSON field names.
+func renderYAML(resp ipc.QueryResponse, opts Options) (string, error) {
+	raw, err := json.Marshal(structuredPayload(resp, opts))
+	if err != nil {
+		return "", fmt.Errorf("renderer: encode yaml: %w", err)
+	}
+	var generic map[string]any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return "", fmt.Errorf("renderer: encode yaml: %w", err)
+	}
+	data, err := yaml.Marshal(generic)
+	if err != nil {
+		return "", fmt.Errorf("renderer: encode yaml: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// structuredPayload assembles the machine-readable field set shared by the JSON and YAML presenters.
+// Optional telemetry pointers are only included when the backend provided them.
+func structuredPayload(resp ipc.QueryResponse, opts Options) map[string]any {
+	if opts.ReferencesOnly {
+		payload := map[string]any{
+			"references": dedupeReferences(resp.References),
+			"citations":  resp.Citations,
+			"no_answer":  resp.NoAnswer,
+		}
+		if guidance, fallback := fallbackGuidance(resp, opts); fallback {
+			payload["fallback"] = guidance
+		}
+		return payload
+	}
 	payload := map[string]any{
 		"summary":              resp.Summary,
 		"steps":                resp.Steps,
-		"references":           resp.References,
+		"references":           dedupeReferences(resp.References),
 		"citations":            resp.Citations,
 		"confidence":           resp.Confidence,
 		"confidence_threshold": opts.ConfidenceThreshold,
@@ -78,106 +181,197 @@ func renderJSON(resp ipc.QueryResponse, opts Options) (string, error) {
 	if resp.Answer != nil {
 		payload["answer"] = *resp.Answer
 	}
-
-	data, err := json.MarshalIndent(payload, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("renderer: encode json: %w", err)
+	if len(resp.Warnings) > 0 {
+		payload["warnings"] = resp.Warnings
 	}
-	return string(data), nil
+	return payload
 }
 
-var (
-	templateFuncs = template.FuncMap{
-		"inc": func(i int) int { return i + 1 },
-	}
+const markdownTemplateSrc = `{{if not .ReferencesOnly}}{{.ConfidenceLine}}{{end}}{{if .HasTruncationWarning}}
+{{.TruncationWarning}}{{end}}{{if .HasStaleWarning}}
 
-	markdownTemplate = template.Must(template.New("markdown").
-				Funcs(templateFuncs).
-				Parse(markdownTemplateSrc))
+{{.StaleWarning}}{{end}}{{if .HasMarginalNote}}
 
-	plainTemplate = template.Must(template.New("plain").
-			Funcs(templateFuncs).
-			Parse(plainTemplateSrc))
-)
+{{.MarginalNote}}{{end}}{{if .HasIndexPinWarning}}
 
-const markdownTemplateSrc = `{{.ConfidenceLine}}{{if .HasTruncationWarning}}
-{{.TruncationWarning}}{{end}}{{if .Fallback}}
+{{.IndexPinWarning}}{{end}}{{if .HasWarnings}}
 
-No answer found
+{{bold $.Color "Warnings"}}
+--------{{range .Warnings}}
+- {{.}}{{end}}{{end}}{{if .Fallback}}
+
+{{bold $.Color "No answer found"}}
 ---------------
-{{.FallbackBody}}{{else}}
+{{.FallbackBody}}{{else}}{{if .HasSummary}}
 
-Summary
+{{bold $.Color "Summary"}}
 -------
-{{.Summary}}{{if .HasSteps}}
+{{.Summary}}{{end}}{{if .HasSteps}}
 
-Steps
+{{bold $.Color "Steps"}}
 -----
 {{range $idx, $step := .Steps}}{{printf "%d. %s\n" (inc $idx) $step}}{{end}}{{end}}{{if .HasReferences}}
 
-References
+{{bold $.Color "References"}}
 ----------
-{{range .References}}[{{.Index}}] {{.Alias}} — {{.DocumentRef}}
+{{range .References}}{{dim $.Color (printf "[%d]" .Index)}} {{.Alias}} — {{.DocumentRef}}{{if .HasRelevance}} {{dim $.Color (printf "(relevance %s)" .Relevance)}}{{end}}
 {{if .HasExcerpt}}    {{.Excerpt}}
 {{end}}{{if .HasURL}}    Link: {{.URL}}
+{{end}}{{range .ExtraURLs}}    Link: {{.}}
 {{end}}{{if .HasNotes}}    Notes: {{.Notes}}
 {{end}}
-{{end}}{{end}}{{end}}
+{{end}}{{if .HasHiddenReferences}}{{.HiddenReferencesNote}}
+{{end}}{{end}}{{if .HasTiming}}
+
+{{bold $.Color "Timing"}}
+------
+{{.Timing}}{{end}}{{end}}
+{{if .HasIndexVersion}}
+Index version: {{.IndexVersion}}{{end}}
+Trace ID: {{.TraceID}}{{if .HasBackendCorrelationID}}
+Backend correlation ID: {{.BackendCorrelationID}}{{end}}`
+
+const plainTemplateSrc = `{{if not .ReferencesOnly}}{{.ConfidenceLine}}{{end}}{{if .HasTruncationWarning}}
+{{.TruncationWarning}}{{end}}{{if .HasStaleWarning}}
+
+{{.StaleWarning}}{{end}}{{if .HasMarginalNote}}
+
+{{.MarginalNote}}{{end}}{{if .HasIndexPinWarning}}
 
-Trace ID: {{.TraceID}}`
+{{.IndexPinWarning}}{{end}}{{if .HasWarnings}}
 
-const plainTemplateSrc = `{{.ConfidenceLine}}{{if .HasTruncationWarning}}
-{{.TruncationWarning}}{{end}}{{if .Fallback}}
+{{bold $.Color "WARNINGS:"}}{{range .Warnings}}
+- {{.}}{{end}}{{end}}{{if .Fallback}}
 
-No answer found
+{{bold $.Color "No answer found"}}
 ---------------
-{{.FallbackBody}}{{else}}
+{{.FallbackBody}}{{else}}{{if .HasSummary}}
 
-SUMMARY:
-{{.Summary}}{{if .HasSteps}}
+{{bold $.Color "SUMMARY:"}}
+{{.Summary}}{{end}}{{if .HasSteps}}
 
-STEPS:
+{{bold $.Color "STEPS:"}}
 {{range $idx, $step := .Steps}}{{printf "%d) %s\n" (inc $idx) $step}}{{end}}{{end}}{{if .HasReferences}}
 
-REFERENCES:
-{{range .References}}[{{.Index}}] {{.Alias}} :: {{.DocumentRef}}
+{{bold $.Color "REFERENCES:"}}
+{{range .References}}{{dim $.Color (printf "[%d]" .Index)}} {{.Alias}} :: {{.DocumentRef}}{{if .HasRelevance}} {{dim $.Color (printf "(relevance %s)" .Relevance)}}{{end}}
 {{if .HasExcerpt}}    {{.Excerpt}}
 {{end}}{{if .HasURL}}    LINK: {{.URL}}
+{{end}}{{range .ExtraURLs}}    LINK: {{.}}
 {{end}}{{if .HasNotes}}    NOTES: {{.Notes}}
 {{end}}
-{{end}}{{end}}{{end}}
+{{end}}{{if .HasHiddenReferences}}{{.HiddenReferencesNote}}
+{{end}}{{end}}{{if .HasTiming}}
+
+{{bold $.Color "TIMING:"}}
+{{.Timing}}{{end}}{{end}}
+{{if .HasIndexVersion}}
+INDEX VERSION: {{.IndexVersion}}{{end}}
+TRACE ID: {{.TraceID}}{{if .HasBackendCorrelationID}}
+BACKEND CORRELATION ID: {{.BackendCorrelationID}}{{end}}`
+
+const htmlTemplateSrc = `<div class="ragman-answer">
+{{if not .ReferencesOnly}}<div class="confidence">{{.ConfidenceLine}}</div>{{end}}{{if .HasTruncationWarning}}
+<div class="warning">{{.TruncationWarning}}</div>{{end}}{{if .HasStaleWarning}}
+<div class="warning">{{.StaleWarning}}</div>{{end}}{{if .HasMarginalNote}}
+<div class="warning">{{.MarginalNote}}</div>{{end}}{{if .HasIndexPinWarning}}
+<div class="warning">{{.IndexPinWarning}}</div>{{end}}{{if .HasWarnings}}
+<ul class="warnings">{{range .Warnings}}
+<li>{{.}}</li>{{end}}
+</ul>{{end}}{{if .Fallback}}
+<h2>No answer found</h2>
+<p>{{.FallbackBody}}</p>{{else}}{{if .HasSummary}}
+<h2>Summary</h2>
+<p>{{.Summary}}</p>{{end}}{{if .HasSteps}}
+<h2>Steps</h2>
+<ol>{{range .Steps}}
+<li>{{.}}</li>{{end}}
+</ol>{{end}}{{if .HasReferences}}
+<h2>References</h2>
+<ol class="references">{{range .References}}
+<li id="ref-{{.Index}}">{{if isLink .URL}}<a href="{{.URL}}">{{.Alias}} — {{.DocumentRef}}</a>{{else}}{{.Alias}} — {{.DocumentRef}}{{end}}{{if .HasRelevance}} <span class="relevance">(relevance {{.Relevance}})</span>{{end}}{{if .HasExcerpt}}
+<blockquote>{{.Excerpt}}</blockquote>{{end}}{{if and .HasURL (not (isLink .URL))}}
+<div class="link">Link: {{.URL}}</div>{{end}}{{range .ExtraURLs}}
+<div class="link">Link: {{if isLink .}}<a href="{{.}}">{{.}}</a>{{else}}{{.}}{{end}}</div>{{end}}{{if .HasNotes}}
+<div class="notes">Notes: {{.Notes}}</div>{{end}}
+</li>{{end}}
+</ol>{{if .HasHiddenReferences}}
+<p class="more-references">{{.HiddenReferencesNote}}</p>{{end}}{{end}}{{end}}{{if .HasIndexVersion}}
+<p class="index-version">Index version: {{.IndexVersion}}</p>{{end}}
+<p class="trace">Trace ID: {{.TraceID}}</p>{{if .HasBackendCorrelationID}}
+<p class="trace">Backend correlation ID: {{.BackendCorrelationID}}</p>{{end}}
+</div>`
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").
+	Funcs(htmltemplate.FuncMap{"isLink": isWebLink}).
+	Parse(htmlTemplateSrc))
+
+// renderHTML produces a self-contained HTML fragment suitable for embedding in dashboards.
+func renderHTML(resp ipc.QueryResponse, opts Options) (string, error) {
+	opts.Color = false
+	view := buildViewModel(resp, opts)
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, view); err != nil {
+		return "", fmt.Errorf("renderer: encode html: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
 
-TRACE ID: {{.TraceID}}`
+// isWebLink reports whether a reference URL can be rendered as a clickable anchor.
+// Other schemes (e.g. man:) are shown as text because html/template rejects them in href attributes.
+func isWebLink(raw string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+		return parsed.Host != ""
+	default:
+		return false
+	}
+}
 
 func renderMarkdown(resp ipc.QueryResponse, opts Options) string {
 	view := buildViewModel(resp, opts)
-	var buf bytes.Buffer
-	_ = markdownTemplate.Execute(&buf, view)
-	return strings.TrimSpace(buf.String())
+	return executeTextTemplate(markdownTemplateName, markdownTemplateSrc, opts.MarkdownTemplatePath, view, opts.Logger)
 }
 
 func renderPlain(resp ipc.QueryResponse, opts Options) string {
 	view := buildViewModel(resp, opts)
-	var buf bytes.Buffer
-	_ = plainTemplate.Execute(&buf, view)
-	return strings.TrimSpace(buf.String())
+	output := executeTextTemplate(plainTemplateName, plainTemplateSrc, opts.PlainTemplatePath, view, opts.Logger)
+	return wrapText(output, opts.Width)
 }
 
-func buildViewModel(resp ipc.QueryResponse, opts Options) rendererViewModel {
-	traceID := coalesce(resp.TraceID, opts.TraceID)
-	fallback := resp.NoAnswer || resp.Confidence < opts.ConfidenceThreshold
+// indexPinWarningFormat is shown when the backend answered from a different catalog version than pinned.
+const indexPinWarningFormat = "⚠ Requested index version %s was not honored; the backend answered from %s"
 
-	var fallbackBody string
+// fallbackGuidance returns the guidance shown instead of an answer when the backend reported no
+// answer or the confidence is below the threshold, and whether that fallback applies.
+func fallbackGuidance(resp ipc.QueryResponse, opts Options) (string, bool) {
+	if !resp.NoAnswer && resp.Confidence >= opts.ConfidenceThreshold {
+		return "", false
+	}
 	defaultFallback := "Answer is below the confidence threshold. Please rephrase your query or refresh sources via ragadmin."
-	if fallback {
-		fallbackBody = strings.TrimSpace(resp.Summary)
-		lowered := strings.ToLower(fallbackBody)
-		if fallbackBody == "" {
-			fallbackBody = defaultFallback
-		} else if !strings.Contains(lowered, "rephrase your query") {
-			fallbackBody = fallbackBody + "\n\n" + defaultFallback
-		}
+	body := strings.TrimSpace(resp.Summary)
+	if body == "" {
+		return defaultFallback, true
+	}
+	if !strings.Contains(strings.ToLower(body), "rephrase your query") {
+		body = body + "\n\n" + defaultFallback
 	}
+	return body, true
+}
+
+// marginalConfidenceNote is shown when the answer clears the threshold by less than the warn band.
+const marginalConfidenceNote = "⚠ Confidence is marginal; the answer may be incomplete"
+
+// staleIndexWarning is shown above the answer when the backend reports an outdated catalog.
+const staleIndexWarning = "⚠ Index is stale — run `ragadmin reindex`"
+
+func buildViewModel(resp ipc.QueryResponse, opts Options) rendererViewModel {
+	traceID := coalesce(resp.TraceID, opts.TraceID)
+	fallbackBody, fallback := fallbackGuidance(resp, opts)
 
 	truncationWarning := ""
 	if resp.ContextTruncated {
@@ -188,8 +382,30 @@ func buildViewModel(resp ipc.QueryResponse, opts Options) rendererViewModel {
 		truncationWarning = fmt.Sprintf("Context truncated: %s", message)
 	}
 
-	citations := enumerateCitations(resp)
-	references := buildReferenceViews(citations, resp.References)
+	staleWarning := ""
+	if resp.StaleIndexDetected {
+		staleWarning = styleText(opts.Color, ansiYellow, staleIndexWarning)
+	}
+
+	indexVersion := ""
+	if resp.IndexVersion != nil {
+		indexVersion = strings.TrimSpace(*resp.IndexVersion)
+	}
+	indexPinWarning := ""
+	if pinned := strings.TrimSpace(opts.RequestedIndexVersion); pinned != "" && indexVersion != "" && indexVersion != pinned {
+		indexPinWarning = styleText(opts.Color, ansiYellow, fmt.Sprintf(indexPinWarningFormat, pinned, indexVersion))
+	}
+
+	marginalNote := ""
+	if !fallback && opts.WarnBandWidth > 0 && resp.Confidence < opts.ConfidenceThreshold+opts.WarnBandWidth {
+		marginalNote = styleText(opts.Color, ansiYellow, marginalConfidenceNote)
+	}
+
+	correlationID := strings.TrimSpace(resp.BackendCorrelationID)
+	timing := formatTiming(resp)
+	confidenceLine := fmt.Sprintf("Confidence %s (threshold %s)", formatConfidence(resp.Confidence, opts.ConfidenceFormat), formatConfidence(opts.ConfidenceThreshold, opts.ConfidenceFormat))
+	citations := enumerateCitations(resp, opts.CitationOrder)
+	references, hiddenReferences := buildReferenceViews(citations, dedupeReferences(resp.References), opts.MaxReferences, opts.MaxExcerptRunes)
 
 	cleanSteps := make([]string, 0, len(resp.Steps))
 	for _, step := range resp.Steps {
@@ -201,39 +417,91 @@ func buildViewModel(resp ipc.QueryResponse, opts Options) rendererViewModel {
 	}
 
 	view := rendererViewModel{
-		ConfidenceLine:       fmt.Sprintf("Confidence %s (threshold %s)", percentage(resp.Confidence), percentage(opts.ConfidenceThreshold)),
-		TraceID:              traceID,
-		Fallback:             fallback,
-		FallbackBody:         fallbackBody,
-		Summary:              strings.TrimSpace(resp.Summary),
-		Steps:                cleanSteps,
-		HasSteps:             len(cleanSteps) > 0 && !fallback,
-		References:           references,
-		HasReferences:        len(references) > 0 && !fallback,
-		HasTruncationWarning: resp.ContextTruncated,
-		TruncationWarning:    truncationWarning,
+		ConfidenceLine:          styleText(opts.Color, confidenceStyle(resp.Confidence, opts.ConfidenceThreshold, opts.WarnBandWidth), confidenceLine),
+		TraceID:                 traceID,
+		BackendCorrelationID:    correlationID,
+		HasBackendCorrelationID: correlationID != "",
+		Fallback:                fallback,
+		FallbackBody:            fallbackBody,
+		Summary:                 strings.TrimSpace(resp.Summary),
+		HasSummary:              !opts.SummaryStreamed,
+		Steps:                   cleanSteps,
+		HasSteps:                len(cleanSteps) > 0 && !fallback,
+		References:              references,
+		HasReferences:           len(references) > 0 && !fallback,
+		HasHiddenReferences:     hiddenReferences > 0,
+		HiddenReferencesNote:    fmt.Sprintf("(+%d more)", hiddenReferences),
+		HasTruncationWarning:    resp.ContextTruncated,
+		TruncationWarning:       truncationWarning,
+		HasStaleWarning:         resp.StaleIndexDetected,
+		StaleWarning:            staleWarning,
+		HasMarginalNote:         marginalNote != "",
+		MarginalNote:            marginalNote,
+		HasIndexPinWarning:      indexPinWarning != "",
+		IndexPinWarning:         indexPinWarning,
+		Warnings:                resp.Warnings,
+		HasWarnings:             len(resp.Warnings) > 0,
+		IndexVersion:            indexVersion,
+		HasIndexVersion:         indexVersion != "",
+		Timing:                  timing,
+		HasTiming:               timing != "" && !fallback,
+		Color:                   opts.Color,
 	}
 
 	if fallback {
 		view.HasSteps = false
 		view.HasReferences = false
+		view.HasTiming = false
+	}
+	if opts.ReferencesOnly {
+		view.ReferencesOnly = true
+		view.HasSummary = false
+		view.HasSteps = false
+		view.HasTiming = false
+		view.HasTruncationWarning = false
+		view.HasStaleWarning = false
+		view.HasMarginalNote = false
+		view.HasIndexPinWarning = false
+		view.HasWarnings = false
+		view.HasIndexVersion = false
 	}
 
 	return view
 }
 
 type rendererViewModel struct {
-	ConfidenceLine       string
-	TraceID              string
-	Fallback             bool
-	FallbackBody         string
-	Summary              string
-	Steps                []string
-	HasSteps             bool
-	References           []referenceView
-	HasReferences        bool
-	HasTruncationWarning bool
-	TruncationWarning    string
+	// ReferencesOnly hides the confidence line and answer sections; see Options.ReferencesOnly.
+	ReferencesOnly bool
+	ConfidenceLine string
+	TraceID        string
+	// BackendCorrelationID is printed next to the trace ID so both can be quoted in bug reports.
+	BackendCorrelationID    string
+	HasBackendCorrelationID bool
+	Fallback                bool
+	FallbackBody            string
+	Summary                 string
+	HasSummary              bool
+	Steps                   []string
+	HasSteps                bool
+	References              []referenceView
+	HasReferences           bool
+	HasHiddenReferences     bool
+	HiddenReferencesNote    string
+	HasTruncationWarning    bool
+	TruncationWarning       string
+	HasStaleWarning         bool
+	StaleWarning            string
+	HasMarginalNote         bool
+	MarginalNote            string
+	HasIndexPinWarning      bool
+	IndexPinWarning         string
+	Warnings                []string
+	HasWarnings             bool
+	IndexVersion            string
+	HasIndexVersion         bool
+	Timing                  string
+	HasTiming               bool
+	Color                   bool
 }
 
 type referenceView struct {
@@ -242,17 +510,30 @@ type referenceView struct {
 	DocumentRef string
 	Excerpt     string
 	URL         string
-	Notes       string
-	HasExcerpt  bool
-	HasURL      bool
-	HasNotes    bool
+	// ExtraURLs lists further distinct links when several references share the cited label.
+	ExtraURLs []string
+	Notes     string
+	// Relevance is the backend's citation score formatted to two decimals, empty when unscored.
+	Relevance    string
+	HasExcerpt   bool
+	HasURL       bool
+	HasNotes     bool
+	HasRelevance bool
 }
 
-func buildReferenceViews(entries []citationEntry, refs []ipc.QueryReference) []referenceView {
+// buildReferenceViews pairs each citation with its reference details. When limit is positive only
+// the first limit entries are returned, along with the number of entries that were left out.
+// Excerpts longer than maxExcerptRunes are shortened when that bound is positive.
+func buildReferenceViews(entries []citationEntry, refs []ipc.QueryReference, limit, maxExcerptRunes int) ([]referenceView, int) {
+	hidden := 0
+	if limit > 0 && len(entries) > limit {
+		hidden = len(entries) - limit
+		entries = entries[:limit]
+	}
 	var results []referenceView
 	for _, entry := range entries {
-		ref := lookupReference(entry.DocumentRef, refs)
-		excerpt := strings.TrimSpace(entry.Excerpt)
+		ref, found := lookupReference(entry.DocumentRef, refs)
+		excerpt := truncateRunes(strings.TrimSpace(entry.Excerpt), maxExcerptRunes)
 		view := referenceView{
 			Index:       entry.Index,
 			Alias:       entry.Alias,
@@ -260,15 +541,30 @@ func buildReferenceViews(entries []citationEntry, refs []ipc.QueryReference) []r
 			Excerpt:     excerpt,
 			HasExcerpt:  excerpt != "",
 		}
-		if ref != nil {
-			view.URL = strings.TrimSpace(ref.URL)
-			view.Notes = strings.TrimSpace(ref.Notes)
+		if entry.Score != nil {
+			view.Relevance = fmt.Sprintf("%.2f", *entry.Score)
+			view.HasRelevance = true
+		}
+		if found {
+			view.URL = ref.URL
+			view.ExtraURLs = ref.ExtraURLs
+			view.Notes = ref.Notes
 			view.HasURL = view.URL != ""
 			view.HasNotes = view.Notes != ""
 		}
 		results = append(results, view)
 	}
-	return results
+	return results, hidden
+}
+
+// truncateRunes shortens text to at most limit runes plus a trailing ellipsis, cutting on a rune
+// boundary so multi-byte characters are never split. A non-positive limit leaves text unchanged.
+func truncateRunes(text string, limit int) string {
+	if limit <= 0 || utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimRightFunc(string(runes[:limit]), unicode.IsSpace) + "…"
 }
 
 type citationEntry struct {
@@ -276,9 +572,12 @@ type citationEntry struct {
 	Alias       string
 	DocumentRef string
 	Excerpt     string
+	Score       *float64
 }
 
-func enumerateCitations(resp ipc.QueryResponse) []citationEntry {
+// enumerateCitations dedupes citations by alias and document and numbers them in the requested
+// order. Score ordering only applies when every citation is scored so the numbering stays deterministic.
+func enumerateCitations(resp ipc.QueryResponse, order CitationOrder) []citationEntry {
 	type key struct {
 		Alias string
 		Doc   string
@@ -301,12 +600,25 @@ func enumerateCitations(resp ipc.QueryResponse) []citationEntry {
 			Alias:       k.Alias,
 			DocumentRef: k.Doc,
 			Excerpt:     strings.TrimSpace(citation.Excerpt),
+			Score:       citation.Score,
 		}
 		seen[k] = entry
 		keys = append(keys, k)
 	}
 
+	byScore := order == CitationOrderScore && len(keys) > 0
+	for _, k := range keys {
+		if seen[k].Score == nil {
+			byScore = false
+			break
+		}
+	}
 	sort.Slice(keys, func(i, j int) bool {
+		if byScore {
+			if left, right := *seen[keys[i]].Score, *seen[keys[j]].Score; left != right {
+				return left > right
+			}
+		}
 		if keys[i].Alias == keys[j].Alias {
 			return keys[i].Doc < keys[j].Doc
 		}
@@ -322,13 +634,105 @@ func enumerateCitations(resp ipc.QueryResponse) []citationEntry {
 	return results
 }
 
-func lookupReference(document string, references []ipc.QueryReference) *ipc.QueryReference {
-	for idx := range references {
-		if strings.EqualFold(strings.TrimSpace(references[idx].Label), strings.TrimSpace(document)) {
-			return &references[idx]
+// dedupeReferences drops repeated references, keeping the first entry for each label (case-insensitive)
+// and URL pair so every presenter sees a unique reference set.
+func dedupeReferences(refs []ipc.QueryReference) []ipc.QueryReference {
+	if len(refs) == 0 {
+		return refs
+	}
+	type key struct {
+		Label string
+		URL   string
+	}
+	seen := make(map[key]bool, len(refs))
+	results := make([]ipc.QueryReference, 0, len(refs))
+	for _, ref := range refs {
+		k := key{
+			Label: strings.ToLower(strings.TrimSpace(ref.Label)),
+			URL:   strings.TrimSpace(ref.URL),
+		}
+		if seen[k] {
+			continue
 		}
+		seen[k] = true
+		results = append(results, ref)
+	}
+	return results
+}
+
+// referenceMatch is the link metadata resolved for a cited document label.
+type referenceMatch struct {
+	URL       string
+	ExtraURLs []string
+	Notes     string
+}
+
+// lookupReference resolves the references whose label matches the document case-insensitively.
+// When several share the label, the first one with a URL is preferred and any other distinct URLs are
+// kept in ExtraURLs so the choice never depends on backend ordering alone.
+func lookupReference(document string, references []ipc.QueryReference) (referenceMatch, bool) {
+	document = strings.TrimSpace(document)
+	var matches []ipc.QueryReference
+	for _, ref := range references {
+		if strings.EqualFold(strings.TrimSpace(ref.Label), document) {
+			matches = append(matches, ref)
+		}
+	}
+	if len(matches) == 0 {
+		return referenceMatch{}, false
+	}
+
+	primary := matches[0]
+	for _, ref := range matches {
+		if strings.TrimSpace(ref.URL) != "" {
+			primary = ref
+			break
+		}
+	}
+
+	match := referenceMatch{
+		URL:   strings.TrimSpace(primary.URL),
+		Notes: strings.TrimSpace(primary.Notes),
+	}
+	seen := map[string]bool{match.URL: true}
+	for _, ref := range matches {
+		link := strings.TrimSpace(ref.URL)
+		if link != "" && !seen[link] {
+			seen[link] = true
+			match.ExtraURLs = append(match.ExtraURLs, link)
+		}
+		if match.Notes == "" {
+			match.Notes = strings.TrimSpace(ref.Notes)
+		}
+	}
+	return match, true
+}
+
+// formatTiming summarises the latency breakdown when the backend reported per-stage timings.
+func formatTiming(resp ipc.QueryResponse) string {
+	var stages []string
+	if resp.RetrievalLatencyMS != nil {
+		stages = append(stages, fmt.Sprintf("retrieval %dms", *resp.RetrievalLatencyMS))
+	}
+	if resp.LLMLatencyMS != nil {
+		stages = append(stages, fmt.Sprintf("LLM %dms", *resp.LLMLatencyMS))
+	}
+	if len(stages) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Total %dms (%s)", resp.LatencyMS, strings.Join(stages, ", "))
+}
+
+// formatConfidence renders a confidence value in the requested format, defaulting to whole percent.
+func formatConfidence(value float64, format ConfidenceFormat) string {
+	switch format {
+	case ConfidenceFormatPercent1:
+		return fmt.Sprintf("%.1f%%", value*100)
+	case ConfidenceFormatFraction:
+		return fmt.Sprintf("%.2f", value)
+	default:
+		return percentage(value)
 	}
-	return nil
 }
 
 func percentage(value float64) string {
@@ -0,0 +1,812 @@
+package io_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	renderio "github.com/linux-rag-t2/cli/ragman/internal/io"
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestRenderMarkdownStructuredSections(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary: "Use chmod to update file permissions.",
+		Steps:   []string{"Inspect current permissions with ls -l.", "Run chmod with the desired mode."},
+		References: []ipc.QueryReference{
+			{Label: "chmod(1)", URL: "man:chmod", Notes: "POSIX manual"},
+			{Label: "chmod(1)", URL: "man:chmod", Notes: "POSIX manual"},
+		},
+		Citations: []ipc.QueryCitation{
+			{Alias: "man-pages", DocumentRef: "chmod(1)", Excerpt: "chmod changes file mode bits."},
+			{Alias: "man-pages", DocumentRef: "chmod(1)", Excerpt: "chmod changes file mode bits."},
+		},
+		Confidence: 0.82,
+		TraceID:    "trace-response",
+		LatencyMS:  420,
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		TraceID:             "trace-cli",
+		Presenter:           "markdown",
+	})
+
+	lines := strings.Split(output, "\n")
+	if len(lines) == 0 || lines[0] != "Confidence 82% (threshold 35%)" {
+		t.Fatalf("expected confidence header, got:\n%s", output)
+	}
+	requireContains(t, output, "Summary", "Steps", "References")
+	if count := strings.Count(output, "man-pages"); count != 1 {
+		t.Fatalf("expected deduplicated citation alias, got %d occurrences\n%s", count, output)
+	}
+	if !strings.Contains(output, "Trace ID: trace-response") {
+		t.Fatalf("expected trace id from response in output:\n%s", output)
+	}
+}
+
+func TestRenderPlainLowConfidenceFallback(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:    "Some backend-specific guidance that should be wrapped by the CLI.",
+		Confidence: 0.14,
+		NoAnswer:   true,
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		TraceID:             "trace-low-confidence",
+		Presenter:           "plain",
+	})
+
+	if !strings.Contains(output, "No answer found") {
+		t.Fatalf("expected fallback block, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Confidence 14% (threshold 35%)") {
+		t.Fatalf("expected confidence percentage in fallback:\n%s", output)
+	}
+	if !strings.Contains(strings.ToLower(output), "rephrase your query") {
+		t.Fatalf("expected guidance to rephrase query:\n%s", output)
+	}
+}
+
+func TestRenderJSONIncludesTelemetryFields(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:            "Context truncated message from backend.",
+		Citations:          []ipc.QueryCitation{{Alias: "man-pages", DocumentRef: "chmod(1)"}},
+		Confidence:         0.62,
+		TraceID:            "",
+		LatencyMS:          512,
+		RetrievalLatencyMS: ptr(220),
+		LLMLatencyMS:       ptr(292),
+		IndexVersion:       ptr("catalog/v1"),
+		ContextTruncated:   true,
+		SemanticChunkCount: ptr(7),
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.5,
+		TraceID:             "trace-from-cli",
+		Presenter:           "json",
+	})
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(output), &payload); err != nil {
+		t.Fatalf("decode json: %v\noutput:\n%s", err, output)
+	}
+
+	for _, key := range []string{"summary", "citations", "confidence", "confidence_threshold", "trace_id", "context_truncated"} {
+		if _, ok := payload[key]; !ok {
+			t.Fatalf("expected key %q in payload: %v", key, payload)
+		}
+	}
+	if got := payload["trace_id"]; got != "trace-from-cli" {
+		t.Fatalf("expected trace id fallback, got %v", got)
+	}
+	if got := payload["context_truncated"]; got != true {
+		t.Fatalf("expected context_truncated true, got %v", got)
+	}
+	if got := payload["semantic_chunk_count"]; got != float64(7) {
+		t.Fatalf("expected semantic_chunk_count 7, got %v", got)
+	}
+}
+
+func TestRenderJSONDeduplicatesReferences(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary: "Use chmod to update file permissions.",
+		References: []ipc.QueryReference{
+			{Label: "chmod(1)", URL: "man:chmod", Notes: "POSIX manual"},
+			{Label: "chmod(1)", URL: "man:chmod", Notes: "POSIX manual"},
+			{Label: "Chmod(1) ", URL: "man:chmod"},
+		},
+		Confidence: 0.82,
+		TraceID:    "trace-dedupe",
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "json",
+	})
+
+	var payload struct {
+		References []ipc.QueryReference `json:"references"`
+	}
+	if err := json.Unmarshal([]byte(output), &payload); err != nil {
+		t.Fatalf("decode json: %v\noutput:\n%s", err, output)
+	}
+	if len(payload.References) != 1 {
+		t.Fatalf("expected a single rendered reference, got %#v", payload.References)
+	}
+	if got := payload.References[0]; got.Label != "chmod(1)" || got.Notes != "POSIX manual" {
+		t.Fatalf("expected the first duplicate to be kept, got %#v", got)
+	}
+}
+
+func TestRenderYAMLMatchesJSONFieldSet(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:      "Use chmod to update file permissions.",
+		Citations:    []ipc.QueryCitation{{Alias: "man-pages", DocumentRef: "chmod(1)"}},
+		Confidence:   0.82,
+		LatencyMS:    420,
+		LLMLatencyMS: ptr(260),
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		TraceID:             "trace-yaml",
+		Presenter:           "yaml",
+	})
+
+	requireContains(t, output,
+		"summary: Use chmod to update file permissions.",
+		"trace_id: trace-yaml",
+		"llm_latency_ms: 260",
+		"document_ref: chmod(1)",
+	)
+	if strings.Contains(output, "retrieval_latency_ms") || strings.Contains(output, "index_version") {
+		t.Fatalf("expected nil telemetry fields to be omitted:\n%s", output)
+	}
+}
+
+func TestRenderMarkdownContextTruncatedWarning(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:          "The retrieved context exceeded the configured token budget and was truncated.",
+		Confidence:       0.91,
+		ContextTruncated: true,
+		TraceID:          "trace-truncation",
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		TraceID:             "cli-trace",
+		Presenter:           "markdown",
+	})
+
+	if !strings.Contains(output, "Context truncated") {
+		t.Fatalf("expected truncation warning in output:\n%s", output)
+	}
+	if strings.Contains(output, "Steps") || strings.Contains(output, "References") {
+		t.Fatalf("truncation fallback should omit steps and references:\n%s", output)
+	}
+}
+
+func TestRenderHTMLFragmentSections(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary: "Use <chmod> to update file permissions.",
+		Steps:   []string{"Inspect permissions.", "Run chmod."},
+		References: []ipc.QueryReference{
+			{Label: "chmod(1)", URL: "man:chmod"},
+			{Label: "permissions-guide", URL: "https://example.org/permissions"},
+		},
+		Citations: []ipc.QueryCitation{
+			{Alias: "man-pages", DocumentRef: "chmod(1)"},
+			{Alias: "wiki", DocumentRef: "permissions-guide"},
+		},
+		Confidence: 0.82,
+		TraceID:    "trace-html",
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "html",
+	})
+
+	requireContains(t, output,
+		`<div class="confidence">Confidence 82% (threshold 35%)</div>`,
+		"<h2>Summary</h2>",
+		"<h2>Steps</h2>",
+		"<ol>\n<li>Inspect permissions.</li>",
+		"<h2>References</h2>",
+		`<a href="https://example.org/permissions">wiki — permissions-guide</a>`,
+		"Use &lt;chmod&gt; to update file permissions.",
+	)
+	if strings.Contains(output, `href="man:chmod"`) {
+		t.Fatalf("expected non-web reference URLs to stay as text:\n%s", output)
+	}
+}
+
+func TestRenderMarkdownCollidingReferenceLabels(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary: "Use chmod to update file permissions.",
+		References: []ipc.QueryReference{
+			{Label: "chmod(1)", Notes: "Label without a link"},
+			{Label: "CHMOD(1)", URL: "man:chmod"},
+			{Label: "chmod(1)", URL: "https://man7.org/linux/man-pages/man1/chmod.1.html"},
+			{Label: "chmod(1)", URL: "man:chmod"},
+		},
+		Citations: []ipc.QueryCitation{
+			{Alias: "man-pages", DocumentRef: "chmod(1)"},
+		},
+		Confidence: 0.82,
+		TraceID:    "trace-collision",
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "markdown",
+	})
+
+	requireContains(t, output,
+		"[1] man-pages — chmod(1)\n    Link: man:chmod\n    Link: https://man7.org/linux/man-pages/man1/chmod.1.html\n    Notes: Label without a link",
+	)
+	if count := strings.Count(output, "Link: man:chmod"); count != 1 {
+		t.Fatalf("expected duplicate URLs to collapse, got %d occurrences\n%s", count, output)
+	}
+}
+
+func TestRenderMaxReferencesCapsHumanOutputOnly(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:    "Use the listed tools to inspect disks.",
+		Confidence: 0.82,
+		TraceID:    "trace-max-references",
+	}
+	for _, page := range []string{"lsblk(8)", "fdisk(8)", "blkid(8)", "parted(8)", "df(1)"} {
+		resp.Citations = append(resp.Citations, ipc.QueryCitation{Alias: "man-pages", DocumentRef: page})
+		resp.References = append(resp.References, ipc.QueryReference{Label: page})
+	}
+
+	markdown := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "markdown",
+		MaxReferences:       2,
+	})
+	requireContains(t, markdown, "[1] man-pages — blkid(8)", "[2] man-pages — df(1)", "(+3 more)")
+	if strings.Contains(markdown, "fdisk(8)") {
+		t.Fatalf("expected references beyond the cap to be hidden:\n%s", markdown)
+	}
+
+	plain := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "plain",
+		MaxReferences:       4,
+	})
+	requireContains(t, plain, "[4] man-pages :: lsblk(8)", "(+1 more)")
+
+	jsonOutput := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "json",
+		MaxReferences:       2,
+	})
+	var payload struct {
+		Citations  []ipc.QueryCitation  `json:"citations"`
+		References []ipc.QueryReference `json:"references"`
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &payload); err != nil {
+		t.Fatalf("decode json output: %v\n%s", err, jsonOutput)
+	}
+	if len(payload.Citations) != 5 || len(payload.References) != 5 {
+		t.Fatalf("expected JSON output to keep every citation and reference, got %d/%d", len(payload.Citations), len(payload.References))
+	}
+}
+
+func TestRenderExcerptTruncationKeepsRunesIntact(t *testing.T) {
+	excerpt := "Größenänderung des Dateisystems — résumé ✓ complete"
+	resp := ipc.QueryResponse{
+		Summary:    "Use resize2fs.",
+		Citations:  []ipc.QueryCitation{{Alias: "man-pages", DocumentRef: "resize2fs(8)", Excerpt: excerpt}},
+		Confidence: 0.82,
+		TraceID:    "trace-excerpt",
+	}
+
+	plain := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "plain",
+		MaxExcerptRunes:     6,
+	})
+	requireContains(t, plain, "    Größen…\n")
+	if !utf8.ValidString(plain) {
+		t.Fatalf("expected truncated output to remain valid UTF-8:\n%q", plain)
+	}
+
+	jsonOutput := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "json",
+		MaxExcerptRunes:     6,
+	})
+	requireContains(t, jsonOutput, excerpt)
+}
+
+func TestRenderPlainWrapsLongLinesAtWidth(t *testing.T) {
+	link := "https://wiki.archlinux.org/title/File_permissions_and_attributes#Changing_permissions"
+	resp := ipc.QueryResponse{
+		Summary:    "Ändern Sie die Berechtigungen mit chmod, prüfen Sie das Ergebnis mit ls -l und lesen Sie " + link + " für Details.",
+		Steps:      []string{"Run chmod with the desired mode on every file that needs a permission change."},
+		Confidence: 0.82,
+		TraceID:    "trace-wrap",
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "plain",
+		Width:               40,
+	})
+
+	requireContains(t, output, link, "1) Run chmod with the desired mode on\n")
+	for _, line := range strings.Split(output, "\n") {
+		if utf8.RuneCountInString(line) > 40 && line != link {
+			t.Fatalf("expected lines of at most 40 runes except the unbreakable URL, got %q in:\n%s", line, output)
+		}
+	}
+}
+
+func TestRenderMarkdownColorizesHeadingsAndConfidence(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:    "Use chmod to update file permissions.",
+		Citations:  []ipc.QueryCitation{{Alias: "man-pages", DocumentRef: "chmod(1)"}},
+		Confidence: 0.4,
+		TraceID:    "trace-color",
+	}
+
+	colored := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "markdown",
+		Color:               true,
+		WarnBandWidth:       0.1,
+	})
+	requireContains(t, colored,
+		"\x1b[33mConfidence 40% (threshold 35%)\x1b[0m",
+		"\x1b[1mSummary\x1b[0m",
+		"\x1b[2m[1]\x1b[0m man-pages",
+	)
+
+	plain := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "markdown",
+	})
+	if strings.Contains(plain, "\x1b[") {
+		t.Fatalf("expected no ANSI sequences without color:\n%q", plain)
+	}
+}
+
+func TestRenderMarkdownTimingSection(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:            "Use chmod to update file permissions.",
+		Citations:          []ipc.QueryCitation{{Alias: "man-pages", DocumentRef: "chmod(1)"}},
+		Confidence:         0.82,
+		TraceID:            "trace-timing",
+		LatencyMS:          420,
+		RetrievalLatencyMS: ptr(120),
+		LLMLatencyMS:       ptr(260),
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "markdown",
+	})
+	requireContains(t, output, "Timing", "Total 420ms (retrieval 120ms, LLM 260ms)")
+
+	resp.NoAnswer = true
+	fallback := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "plain",
+	})
+	if strings.Contains(fallback, "TIMING") {
+		t.Fatalf("expected timing to be suppressed in fallback output:\n%s", fallback)
+	}
+
+	resp.NoAnswer = false
+	resp.RetrievalLatencyMS = nil
+	resp.LLMLatencyMS = nil
+	withoutStages := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "markdown",
+	})
+	if strings.Contains(withoutStages, "Timing") {
+		t.Fatalf("expected timing to be omitted without stage latencies:\n%s", withoutStages)
+	}
+}
+
+func TestRenderMarkdownStaleIndexWarning(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:            "Use chmod to update file permissions.",
+		Confidence:         0.82,
+		TraceID:            "trace-stale",
+		IndexVersion:       ptr("catalog-2024-06"),
+		StaleIndexDetected: true,
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "markdown",
+	})
+	requireContains(t, output,
+		"⚠ Index is stale — run `ragadmin reindex`",
+		"Index version: catalog-2024-06\nTrace ID: trace-stale",
+	)
+	if strings.Index(output, "Index is stale") > strings.Index(output, "Summary") {
+		t.Fatalf("expected stale warning above the summary:\n%s", output)
+	}
+}
+
+func TestRenderHTMLStaleIndexWarning(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:            "Use chmod to update file permissions.",
+		Confidence:         0.82,
+		TraceID:            "trace-stale-html",
+		IndexVersion:       ptr("catalog-2024-06"),
+		StaleIndexDetected: true,
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "html",
+	})
+	requireContains(t, output,
+		`<div class="warning">⚠ Index is stale — run `+"`ragadmin reindex`"+`</div>`,
+		`<p class="index-version">Index version: catalog-2024-06</p>`+"\n"+`<p class="trace">Trace ID: trace-stale-html</p>`,
+	)
+}
+
+func TestRenderPlainFreshIndexOmitsWarning(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:      "Use chmod to update file permissions.",
+		Confidence:   0.82,
+		TraceID:      "trace-fresh",
+		IndexVersion: ptr("catalog-2024-07"),
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "plain",
+	})
+	requireContains(t, output, "INDEX VERSION: catalog-2024-07\nTRACE ID: trace-fresh")
+	if strings.Contains(output, "Index is stale") {
+		t.Fatalf("expected no stale warning for a fresh index:\n%s", output)
+	}
+}
+
+func TestRenderCustomTemplateOverridesAndFallsBack(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "custom.tmpl")
+	if err := os.WriteFile(custom, []byte("ANSWER {{.Summary}} [{{.TraceID}}]"), 0o600); err != nil {
+		t.Fatalf("write custom template: %v", err)
+	}
+	broken := filepath.Join(dir, "broken.tmpl")
+	if err := os.WriteFile(broken, []byte("{{if .Summary}"), 0o600); err != nil {
+		t.Fatalf("write broken template: %v", err)
+	}
+
+	resp := ipc.QueryResponse{
+		Summary:    "Use chmod to update file permissions.",
+		Confidence: 0.82,
+		TraceID:    "trace-template",
+	}
+
+	output := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold:  0.35,
+		Presenter:            "markdown",
+		MarkdownTemplatePath: custom,
+	})
+	if output != "ANSWER Use chmod to update file permissions. [trace-template]" {
+		t.Fatalf("expected custom markdown template output, got:\n%s", output)
+	}
+
+	fallback := invokeRenderer(t, resp, renderio.Options{
+		ConfidenceThreshold: 0.35,
+		Presenter:           "plain",
+		PlainTemplatePath:   broken,
+	})
+	requireContains(t, fallback, "SUMMARY:", "TRACE ID: trace-template")
+}
+
+func TestRenderWarningsBlockIncludingFallback(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:    "Use journalctl to inspect the unit logs.",
+		Citations:  []ipc.QueryCitation{{Alias: "man-pages", DocumentRef: "journalctl(1)"}},
+		Confidence: 0.82,
+		TraceID:    "trace-warnings",
+		Warnings:   []string{"kiwix source last indexed 30 days ago", "man-pages index is partial"},
+	}
+
+	markdown := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "markdown"})
+	requireContains(t, markdown, "Warnings\n--------\n- kiwix source last indexed 30 days ago\n- man-pages index is partial\n\nSummary")
+
+	plain := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain", Width: 0})
+	requireContains(t, plain, "WARNINGS:\n- kiwix source last indexed 30 days ago\n- man-pages index is partial\n\nSUMMARY:")
+
+	resp.NoAnswer = true
+	fallback := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain"})
+	requireContains(t, fallback, "WARNINGS:\n- kiwix source last indexed 30 days ago", "No answer found")
+
+	jsonOutput := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "json"})
+	var payload struct {
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &payload); err != nil {
+		t.Fatalf("decode json output: %v\n%s", err, jsonOutput)
+	}
+	if len(payload.Warnings) != 2 || payload.Warnings[0] != "kiwix source last indexed 30 days ago" {
+		t.Fatalf("expected warnings key in JSON output, got %q", payload.Warnings)
+	}
+
+	resp.Warnings = nil
+	withoutWarnings := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "json"})
+	if strings.Contains(withoutWarnings, `"warnings"`) {
+		t.Fatalf("expected warnings key to be omitted when the backend sends none:\n%s", withoutWarnings)
+	}
+}
+
+func TestRenderCitationRelevanceScores(t *testing.T) {
+	high, low := 0.87, 0.4
+	resp := ipc.QueryResponse{
+		Summary: "Use ip to inspect interfaces.",
+		Citations: []ipc.QueryCitation{
+			{Alias: "man-pages", DocumentRef: "ip(8)", Score: &high},
+			{Alias: "man-pages", DocumentRef: "ifconfig(8)"},
+			{Alias: "man-pages", DocumentRef: "ss(8)", Score: &low},
+		},
+		Confidence: 0.82,
+		TraceID:    "trace-relevance",
+	}
+
+	markdown := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "markdown"})
+	requireContains(t, markdown,
+		"[1] man-pages — ifconfig(8)\n",
+		"[2] man-pages — ip(8) (relevance 0.87)\n",
+		"[3] man-pages — ss(8) (relevance 0.40)\n",
+	)
+
+	plain := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain"})
+	requireContains(t, plain,
+		"[1] man-pages :: ifconfig(8)\n",
+		"[2] man-pages :: ip(8) (relevance 0.87)\n",
+	)
+
+	jsonOutput := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "json"})
+	var payload struct {
+		Citations []ipc.QueryCitation `json:"citations"`
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &payload); err != nil {
+		t.Fatalf("decode json output: %v\n%s", err, jsonOutput)
+	}
+	if len(payload.Citations) != 3 || payload.Citations[0].Score == nil || *payload.Citations[0].Score != high || payload.Citations[1].Score != nil {
+		t.Fatalf("expected JSON citations to carry scores only where provided:\n%s", jsonOutput)
+	}
+}
+
+func TestRenderCitationOrderByScore(t *testing.T) {
+	high, mid, low := 0.91, 0.55, 0.2
+	resp := ipc.QueryResponse{
+		Summary: "Use ip to inspect interfaces.",
+		Citations: []ipc.QueryCitation{
+			{Alias: "man-pages", DocumentRef: "ifconfig(8)", Score: &low},
+			{Alias: "man-pages", DocumentRef: "ip(8)", Score: &high},
+			{Alias: "kiwix", DocumentRef: "Iproute2", Score: &mid},
+		},
+		Confidence: 0.82,
+		TraceID:    "trace-citation-order",
+	}
+
+	byScore := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "markdown", CitationOrder: "score"})
+	requireContains(t, byScore,
+		"[1] man-pages — ip(8) (relevance 0.91)\n",
+		"[2] kiwix — Iproute2 (relevance 0.55)\n",
+		"[3] man-pages — ifconfig(8) (relevance 0.20)\n",
+	)
+
+	byAlias := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "markdown", CitationOrder: "alias"})
+	requireContains(t, byAlias,
+		"[1] kiwix — Iproute2",
+		"[2] man-pages — ifconfig(8)",
+		"[3] man-pages — ip(8)",
+	)
+
+	resp.Citations[0].Score = nil
+	partial := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain", CitationOrder: "score"})
+	requireContains(t, partial,
+		"[1] kiwix :: Iproute2",
+		"[2] man-pages :: ifconfig(8)\n",
+		"[3] man-pages :: ip(8)",
+	)
+}
+
+func TestRenderReferencesOnly(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:    "Use chmod to update file permissions.",
+		Steps:      []string{"Run chmod with the desired mode."},
+		References: []ipc.QueryReference{{Label: "chmod(1)", URL: "man:chmod"}},
+		Citations:  []ipc.QueryCitation{{Alias: "man-pages", DocumentRef: "chmod(1)"}},
+		Confidence: 0.82,
+		TraceID:    "trace-references-only",
+		LatencyMS:  120,
+		Warnings:   []string{"man-pages index is partial"},
+	}
+
+	markdown := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "markdown", ReferencesOnly: true})
+	if !strings.HasPrefix(markdown, "References\n----------\n[1] man-pages — chmod(1)\n    Link: man:chmod") {
+		t.Fatalf("expected output to start with the references section:\n%s", markdown)
+	}
+	requireContains(t, markdown, "Trace ID: trace-references-only")
+	for _, hidden := range []string{"Confidence", "Summary", "Steps", "Timing", "Warnings"} {
+		if strings.Contains(markdown, hidden) {
+			t.Fatalf("expected %q to be hidden in references-only output:\n%s", hidden, markdown)
+		}
+	}
+
+	plain := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain", ReferencesOnly: true})
+	if !strings.HasPrefix(plain, "REFERENCES:\n[1] man-pages :: chmod(1)") || strings.Contains(plain, "SUMMARY:") {
+		t.Fatalf("expected plain output to contain only references:\n%s", plain)
+	}
+
+	jsonOutput := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "json", ReferencesOnly: true})
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(jsonOutput), &payload); err != nil {
+		t.Fatalf("decode json output: %v\n%s", err, jsonOutput)
+	}
+	if len(payload) != 3 || payload["references"] == nil || payload["citations"] == nil || string(payload["no_answer"]) != "false" {
+		t.Fatalf("expected only references, citations and no_answer keys, got:\n%s", jsonOutput)
+	}
+
+	resp.NoAnswer = true
+	fallback := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "markdown", ReferencesOnly: true})
+	requireContains(t, fallback, "No answer found", "Please rephrase your query", "Trace ID: trace-references-only")
+	if strings.Contains(fallback, "References") {
+		t.Fatalf("expected the fallback guidance instead of references:\n%s", fallback)
+	}
+
+	for _, presenter := range []renderio.Format{"json", "yaml"} {
+		structured := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: presenter, ReferencesOnly: true})
+		requireContains(t, structured, "no_answer", "Please rephrase your query")
+	}
+	jsonFallback := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "json", ReferencesOnly: true})
+	var fallbackPayload struct {
+		NoAnswer bool   `json:"no_answer"`
+		Fallback string `json:"fallback"`
+	}
+	if err := json.Unmarshal([]byte(jsonFallback), &fallbackPayload); err != nil {
+		t.Fatalf("decode json fallback: %v\n%s", err, jsonFallback)
+	}
+	if !fallbackPayload.NoAnswer || !strings.Contains(fallbackPayload.Fallback, "Please rephrase your query") {
+		t.Fatalf("expected no_answer and the fallback guidance in references-only json, got:\n%s", jsonFallback)
+	}
+}
+
+func TestRenderBackendCorrelationIDFooter(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:              "Use df to check free space.",
+		Confidence:           0.82,
+		TraceID:              "trace-correlation",
+		BackendCorrelationID: "backend-7f3a",
+	}
+
+	markdown := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "markdown"})
+	requireContains(t, markdown, "Trace ID: trace-correlation\nBackend correlation ID: backend-7f3a")
+
+	plain := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain"})
+	requireContains(t, plain, "TRACE ID: trace-correlation\nBACKEND CORRELATION ID: backend-7f3a")
+
+	resp.BackendCorrelationID = ""
+	for _, presenter := range []renderio.Format{"markdown", "plain"} {
+		output := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: presenter})
+		if strings.Contains(strings.ToLower(output), "correlation id:") {
+			t.Fatalf("%s: expected no correlation ID line when the backend sends none:\n%s", presenter, output)
+		}
+	}
+}
+
+func TestRenderMarginalConfidenceBand(t *testing.T) {
+	const note = "Confidence is marginal; the answer may be incomplete"
+	cases := []struct {
+		name         string
+		confidence   float64
+		wantNote     bool
+		wantFallback bool
+	}{
+		{name: "below threshold", confidence: 0.30, wantFallback: true},
+		{name: "marginal", confidence: 0.42, wantNote: true},
+		{name: "high confidence", confidence: 0.82},
+	}
+
+	for _, tc := range cases {
+		resp := ipc.QueryResponse{
+			Summary:    "Use systemctl status to inspect the unit.",
+			Confidence: tc.confidence,
+			TraceID:    "trace-warn-band",
+		}
+		output := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "markdown", WarnBandWidth: 0.15})
+		if got := strings.Contains(output, note); got != tc.wantNote {
+			t.Fatalf("%s: marginal note present = %v, want %v:\n%s", tc.name, got, tc.wantNote, output)
+		}
+		if got := strings.Contains(output, "No answer found"); got != tc.wantFallback {
+			t.Fatalf("%s: fallback present = %v, want %v:\n%s", tc.name, got, tc.wantFallback, output)
+		}
+	}
+
+	disabled := invokeRenderer(t, ipc.QueryResponse{Summary: "Use systemctl.", Confidence: 0.42}, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain"})
+	if strings.Contains(disabled, note) {
+		t.Fatalf("expected no marginal note without a warn band:\n%s", disabled)
+	}
+}
+
+func TestRenderWarnBandDrivesColorAndNote(t *testing.T) {
+	const note = "Confidence is marginal; the answer may be incomplete"
+	resp := ipc.QueryResponse{Summary: "Use journalctl -u to read unit logs.", Confidence: 0.5}
+
+	wide := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain", Color: true, WarnBandWidth: 0.25})
+	requireContains(t, wide, "\x1b[33mConfidence 50% (threshold 35%)\x1b[0m", note)
+
+	disabled := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain", Color: true})
+	requireContains(t, disabled, "\x1b[32mConfidence 50% (threshold 35%)\x1b[0m")
+	if strings.Contains(disabled, note) {
+		t.Fatalf("expected no marginal note with the band disabled:\n%s", disabled)
+	}
+}
+
+func TestRenderConfidenceFormats(t *testing.T) {
+	resp := ipc.QueryResponse{
+		Summary:    "Use lsof to find open files.",
+		Confidence: 0.8234,
+		TraceID:    "trace-confidence-format",
+	}
+	cases := []struct {
+		format renderio.ConfidenceFormat
+		want   string
+	}{
+		{format: "", want: "Confidence 82% (threshold 35%)"},
+		{format: "percent", want: "Confidence 82% (threshold 35%)"},
+		{format: "percent1", want: "Confidence 82.3% (threshold 35.0%)"},
+		{format: "fraction", want: "Confidence 0.82 (threshold 0.35)"},
+	}
+	for _, tc := range cases {
+		output := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "plain", ConfidenceFormat: tc.format})
+		if !strings.HasPrefix(output, tc.want) {
+			t.Fatalf("format %q: expected confidence line %q:\n%s", tc.format, tc.want, output)
+		}
+	}
+
+	jsonOutput := invokeRenderer(t, resp, renderio.Options{ConfidenceThreshold: 0.35, Presenter: "json", ConfidenceFormat: "percent1"})
+	var payload struct {
+		Confidence float64 `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &payload); err != nil {
+		t.Fatalf("decode json output: %v\n%s", err, jsonOutput)
+	}
+	if payload.Confidence != 0.8234 {
+		t.Fatalf("expected JSON to keep the raw confidence, got %v", payload.Confidence)
+	}
+}
+
+func invokeRenderer(t *testing.T, resp ipc.QueryResponse, opts renderio.Options) string {
+	t.Helper()
+
+	output, err := renderio.Render(resp, opts)
+	if err != nil {
+		t.Fatalf("renderer returned error: %v", err)
+	}
+	return output
+}
+
+func requireContains(t *testing.T, haystack string, needles ...string) {
+	t.Helper()
+	for _, needle := range needles {
+		if !strings.Contains(haystack, needle) {
+			t.Fatalf("expected %q to contain %q", haystack, needle)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,103 @@
+package io
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const (
+	markdownTemplateName = "markdown"
+	plainTemplateName    = "plain"
+)
+
+var templateFuncs = template.FuncMap{
+	"inc":  func(i int) int { return i + 1 },
+	"bold": boldText,
+	"dim":  dimText,
+}
+
+// templateCache keeps parsed text templates keyed by presenter and source path so
+// user-supplied templates are read once per process rather than once per query.
+// Load failures are cached as well so a broken file is only reported once.
+type templateCache struct {
+	mu      sync.Mutex
+	entries map[string]templateEntry
+}
+
+type templateEntry struct {
+	tmpl *template.Template
+	err  error
+}
+
+var textTemplates = &templateCache{entries: make(map[string]templateEntry)}
+
+// builtin returns the parsed embedded template for the presenter.
+func (c *templateCache) builtin(name, src string) *template.Template {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := name + "\x00"
+	if entry, ok := c.entries[key]; ok {
+		return entry.tmpl
+	}
+	tmpl := template.Must(template.New(name).Funcs(templateFuncs).Parse(src))
+	c.entries[key] = templateEntry{tmpl: tmpl}
+	return tmpl
+}
+
+// load returns the template parsed from path, reusing the cached result for repeat calls.
+func (c *templateCache) load(name, path string) (*template.Template, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := name + "\x00" + path
+	if entry, ok := c.entries[key]; ok {
+		return entry.tmpl, entry.err
+	}
+	entry := parseTemplateFile(name, path)
+	c.entries[key] = entry
+	return entry.tmpl, entry.err
+}
+
+func parseTemplateFile(name, path string) templateEntry {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return templateEntry{err: fmt.Errorf("renderer: read %s template: %w", name, err)}
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return templateEntry{err: fmt.Errorf("renderer: parse %s template: %w", name, err)}
+	}
+	return templateEntry{tmpl: tmpl}
+}
+
+// executeTextTemplate renders view with the custom template at path when provided, falling back
+// to the built-in source when the custom template cannot be loaded or executed.
+func executeTextTemplate(name, src, path string, view rendererViewModel, logger *slog.Logger) string {
+	if path = strings.TrimSpace(path); path != "" {
+		tmpl, err := textTemplates.load(name, path)
+		if err == nil {
+			var buf bytes.Buffer
+			if err = tmpl.Execute(&buf, view); err == nil {
+				return strings.TrimSpace(buf.String())
+			}
+			err = fmt.Errorf("renderer: execute %s template: %w", name, err)
+		}
+		if logger != nil {
+			logger.Warn("ragman custom template unavailable; using built-in",
+				slog.String("presenter", name),
+				slog.String("path", path),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	var buf bytes.Buffer
+	_ = textTemplates.builtin(name, src).Execute(&buf, view)
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,63 @@
+package io
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ansiEscape matches the SGR sequences emitted by styleText so they do not count towards line width.
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// wrapText word-wraps every line of text that is wider than width runes. Continuation lines keep
+// the original line's leading indentation. Words are never split, so a URL or any other token
+// longer than width is left intact on a line of its own. A non-positive width disables wrapping.
+func wrapText(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		if displayWidth(line) > width {
+			lines[idx] = wrapLine(line, width)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func wrapLine(line string, width int) string {
+	body := strings.TrimLeftFunc(line, unicode.IsSpace)
+	indent := line[:len(line)-len(body)]
+	indentWidth := displayWidth(indent)
+
+	var (
+		out          strings.Builder
+		current      strings.Builder
+		currentWidth int
+	)
+	for _, word := range strings.Fields(body) {
+		wordWidth := displayWidth(word)
+		if currentWidth > 0 && indentWidth+currentWidth+1+wordWidth > width {
+			out.WriteString(indent)
+			out.WriteString(current.String())
+			out.WriteByte('\n')
+			current.Reset()
+			currentWidth = 0
+		}
+		if currentWidth > 0 {
+			current.WriteByte(' ')
+			currentWidth++
+		}
+		current.WriteString(word)
+		currentWidth += wordWidth
+	}
+	out.WriteString(indent)
+	out.WriteString(current.String())
+	return out.String()
+}
+
+// displayWidth counts the runes of s that occupy a terminal column, ignoring ANSI styling.
+func displayWidth(s string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(s, ""))
+}
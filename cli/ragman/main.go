@@ -3,12 +3,15 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/linux-rag-t2/cli/ragman/cmd"
+	"github.com/linux-rag-t2/cli/shared/cliflags"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		log.Fatal(err)
+		log.Print(err)
+		os.Exit(cliflags.ExitCode(err))
 	}
 }
@@ -0,0 +1,166 @@
+// Package audit provides JSON-line audit logging helpers for the CLI.
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Rotation defaults applied when no explicit limits are configured.
+const (
+	DefaultMaxSizeBytes = 10 << 20
+	DefaultMaxBackups   = 3
+)
+
+// Logger appends newline-delimited JSON audit entries, rotating the file once it
+// would grow past maxSize.
+type Logger struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	redactor   *redactor
+	mu         sync.Mutex
+}
+
+// Option customises a Logger.
+type Option func(*Logger)
+
+// WithMaxSizeBytes sets the size at which the log is rotated. Zero or negative
+// values keep the default.
+func WithMaxSizeBytes(size int64) Option {
+	return func(l *Logger) {
+		if size > 0 {
+			l.maxSize = size
+		}
+	}
+}
+
+// WithMaxBackups sets how many rotated files (audit.log.1 … audit.log.N) are kept.
+// Zero discards the old log on rotation; negative values keep the default.
+func WithMaxBackups(count int) Option {
+	return func(l *Logger) {
+		if count >= 0 {
+			l.maxBackups = count
+		}
+	}
+}
+
+// NewLogger creates a logger using the provided path. When empty, the default
+// XDG-compliant audit path is used.
+func NewLogger(path string, opts ...Option) (*Logger, error) {
+	resolved := strings.TrimSpace(path)
+	if resolved == "" {
+		var err error
+		resolved, err = defaultLogPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	logger := &Logger{
+		path:       resolved,
+		maxSize:    DefaultMaxSizeBytes,
+		maxBackups: DefaultMaxBackups,
+	}
+	for _, opt := range opts {
+		opt(logger)
+	}
+	return logger, nil
+}
+
+// Path returns the audit log location the logger writes to.
+func (l *Logger) Path() string {
+	if l == nil {
+		return ""
+	}
+	return l.path
+}
+
+// Append writes the entry as a JSON line to the audit log.
+func (l *Logger) Append(entry map[string]any) error {
+	if l == nil || entry == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("audit: create directory: %w", err)
+	}
+
+	line, err := json.Marshal(l.redactor.apply(entry))
+	if err != nil {
+		return fmt.Errorf("audit: encode entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	if err := l.rotateIfNeeded(int64(len(line))); err != nil {
+		return err
+	}
+
+	handle, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("audit: open log: %w", err)
+	}
+	defer handle.Close()
+
+	if _, err := handle.Write(line); err != nil {
+		return fmt.Errorf("audit: write entry: %w", err)
+	}
+	return nil
+}
+
+// rotateIfNeeded shifts audit.log to audit.log.1 (and older backups up by one) when
+// appending pending bytes would exceed maxSize. Callers must hold l.mu.
+func (l *Logger) rotateIfNeeded(pending int64) error {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("audit: stat log: %w", err)
+	}
+	if info.Size() == 0 || info.Size()+pending <= l.maxSize {
+		return nil
+	}
+
+	if l.maxBackups == 0 {
+		if err := os.Remove(l.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("audit: rotate log: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Remove(l.backupPath(l.maxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("audit: rotate log: %w", err)
+	}
+	for i := l.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(l.backupPath(i), l.backupPath(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("audit: rotate log: %w", err)
+		}
+	}
+	if err := os.Rename(l.path, l.backupPath(1)); err != nil {
+		return fmt.Errorf("audit: rotate log: %w", err)
+	}
+	return nil
+}
+
+func (l *Logger) backupPath(index int) string {
+	return fmt.Sprintf("%s.%d", l.path, index)
+}
+
+func defaultLogPath() (string, error) {
+	if xdg := strings.TrimSpace(os.Getenv("XDG_DATA_HOME")); xdg != "" {
+		return filepath.Join(xdg, "ragcli", "audit.log"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("audit: determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "ragcli", "audit.log"), nil
+}
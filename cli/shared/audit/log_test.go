@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAppendRotatesAndKeepsBoundedBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewLogger(path, WithMaxSizeBytes(200), WithMaxBackups(2))
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+
+	// Each entry is roughly 70 bytes, so 20 entries force several rotations.
+	for i := 0; i < 20; i++ {
+		if err := logger.Append(map[string]any{"action": "source_add", "target": fmt.Sprintf("alias-%02d", i)}); err != nil {
+			t.Fatalf("Append() #%d error = %v", i, err)
+		}
+	}
+
+	for _, name := range []string{"audit.log", "audit.log.1", "audit.log.2"} {
+		info, err := os.Stat(filepath.Join(filepath.Dir(path), name))
+		if err != nil {
+			t.Fatalf("expected %s to exist: %v", name, err)
+		}
+		if info.Size() > 200 {
+			t.Fatalf("expected %s to stay within the size limit, got %d bytes", name, info.Size())
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("expected no third backup, stat err = %v", err)
+	}
+
+	entries, err := NewReader(path).Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if len(entries) == 0 || entries[len(entries)-1].Target != "alias-19" {
+		t.Fatalf("expected newest entry in active log, got %+v", entries)
+	}
+	backup, err := NewReader(path + ".1").Next()
+	if err != nil || len(backup) == 0 {
+		t.Fatalf("expected entries in first backup, got %+v (err %v)", backup, err)
+	}
+	if backup[len(backup)-1].Target >= entries[0].Target {
+		t.Fatalf("expected backup to hold older entries: backup ends %q, active starts %q", backup[len(backup)-1].Target, entries[0].Target)
+	}
+}
+
+func TestAppendWithoutBackupsDiscardsOldLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewLogger(path, WithMaxSizeBytes(100), WithMaxBackups(0))
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := logger.Append(map[string]any{"action": "admin_health", "target": fmt.Sprintf("%d", i)}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("expected no backups when MaxBackups is 0, stat err = %v", err)
+	}
+}
+
+func TestConcurrentAppendsSurviveRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewLogger(path, WithMaxSizeBytes(512), WithMaxBackups(50))
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+
+	const writers, perWriter = 8, 25
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				_ = logger.Append(map[string]any{"action": "source_update", "target": fmt.Sprintf("%d-%d", w, i)})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	total := 0
+	files, _ := filepath.Glob(path + "*")
+	for _, file := range files {
+		entries, err := NewReader(file).Next()
+		if err != nil {
+			t.Fatalf("Next(%s) error = %v", file, err)
+		}
+		total += len(entries)
+	}
+	if total != writers*perWriter {
+		t.Fatalf("expected %d intact entries across rotated files, got %d", writers*perWriter, total)
+	}
+}
@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Entry is a decoded audit log line.
+type Entry struct {
+	Timestamp string `json:"timestamp"`
+	Actor     string `json:"actor"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	Status    string `json:"status"`
+	TraceID   string `json:"trace_id,omitempty"`
+	Details   string `json:"details,omitempty"`
+}
+
+// Reader incrementally decodes entries from an audit log, remembering how far it has read
+// so later calls only return newly appended lines.
+type Reader struct {
+	path    string
+	offset  int64
+	partial []byte
+	file    os.FileInfo
+}
+
+// NewReader creates a reader positioned at the start of the log at path.
+func NewReader(path string) *Reader {
+	return &Reader{path: path}
+}
+
+// Next returns entries appended since the previous call. A missing log yields no entries,
+// malformed lines are skipped, and a trailing line without a newline is held back until it
+// is complete. If the file was replaced or shrank (for example after rotation) reading
+// restarts at the top of the new file.
+func (r *Reader) Next() ([]Entry, error) {
+	handle, err := os.Open(r.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("audit: open log: %w", err)
+	}
+	defer handle.Close()
+
+	info, err := handle.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("audit: stat log: %w", err)
+	}
+	if info.Size() < r.offset || (r.file != nil && !os.SameFile(r.file, info)) {
+		r.offset = 0
+		r.partial = nil
+	}
+	r.file = info
+	if _, err := handle.Seek(r.offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("audit: seek log: %w", err)
+	}
+	data, err := io.ReadAll(handle)
+	if err != nil {
+		return nil, fmt.Errorf("audit: read log: %w", err)
+	}
+	r.offset += int64(len(data))
+
+	data = append(r.partial, data...)
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		r.partial = data
+		return nil, nil
+	}
+	r.partial = append([]byte(nil), data[end+1:]...)
+
+	var entries []Entry
+	for _, line := range bytes.Split(data[:end], []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Follow polls the log every interval and passes each newly appended entry to fn until ctx
+// is cancelled, mirroring `tail -f`.
+func (r *Reader) Follow(ctx context.Context, interval time.Duration, fn func(Entry) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		entries, err := r.Next()
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReaderNextReturnsOnlyNewCompleteEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewLogger(path)
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	reader := NewReader(path)
+
+	entries, err := reader.Next()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected no entries for missing log, got %v (err %v)", entries, err)
+	}
+
+	for _, action := range []string{"source_add", "index_reindex"} {
+		if err := logger.Append(map[string]any{"action": action, "status": "success"}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	appendRaw(t, path, "not json\n{\"action\":\"source_rem")
+
+	entries, err = reader.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != "source_add" || entries[1].Action != "index_reindex" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+
+	appendRaw(t, path, "ove\",\"status\":\"success\"}\n")
+	entries, err = reader.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Action != "source_remove" {
+		t.Fatalf("expected completed partial line, got %+v", entries)
+	}
+}
+
+func TestReaderFollowStreamsAppendedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewLogger(path)
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	reader := NewReader(path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = logger.Append(map[string]any{"action": "admin_health", "status": "success"})
+	}()
+
+	var seen []Entry
+	err = reader.Follow(ctx, 5*time.Millisecond, func(entry Entry) error {
+		seen = append(seen, entry)
+		cancel()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+	if len(seen) != 1 || seen[0].Action != "admin_health" {
+		t.Fatalf("expected followed entry, got %+v", seen)
+	}
+}
+
+func appendRaw(t *testing.T, path, data string) {
+	t.Helper()
+	handle, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer handle.Close()
+	if _, err := handle.WriteString(data); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// redactedValue replaces sensitive values before an entry is written.
+const redactedValue = "***"
+
+// RedactionRules selects audit values to mask. Values stored under any of Keys
+// (matched case-insensitively, at any nesting depth) are replaced wholesale;
+// substrings of string values matching any of Patterns are replaced in place.
+type RedactionRules struct {
+	Keys     []string
+	Patterns []string
+}
+
+type redactor struct {
+	keys     map[string]struct{}
+	patterns []*regexp.Regexp
+}
+
+// NewLoggerWithRedaction creates a logger that masks values selected by rules
+// before each entry is written. Invalid patterns are reported as errors.
+func NewLoggerWithRedaction(path string, rules RedactionRules, opts ...Option) (*Logger, error) {
+	r, err := newRedactor(rules)
+	if err != nil {
+		return nil, err
+	}
+	logger, err := NewLogger(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+	logger.redactor = r
+	return logger, nil
+}
+
+func newRedactor(rules RedactionRules) (*redactor, error) {
+	r := &redactor{keys: make(map[string]struct{}, len(rules.Keys))}
+	for _, key := range rules.Keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			r.keys[key] = struct{}{}
+		}
+	}
+	for _, pattern := range rules.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("audit: compile redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// apply returns a redacted copy of entry; the caller's map is left untouched.
+func (r *redactor) apply(entry map[string]any) map[string]any {
+	if r == nil || (len(r.keys) == 0 && len(r.patterns) == 0) {
+		return entry
+	}
+	return r.redactMap(entry)
+}
+
+func (r *redactor) redactMap(values map[string]any) map[string]any {
+	out := make(map[string]any, len(values))
+	for key, value := range values {
+		if _, ok := r.keys[strings.ToLower(key)]; ok {
+			out[key] = redactedValue
+			continue
+		}
+		out[key] = r.redactValue(value)
+	}
+	return out
+}
+
+func (r *redactor) redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		for _, re := range r.patterns {
+			v = re.ReplaceAllLiteralString(v, redactedValue)
+		}
+		return v
+	case map[string]any:
+		return r.redactMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = r.redactValue(item)
+		}
+		return out
+	case []string:
+		out := make([]string, len(v))
+		for i, item := range v {
+			out[i], _ = r.redactValue(item).(string)
+		}
+		return out
+	default:
+		return value
+	}
+}
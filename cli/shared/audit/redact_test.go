@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewLoggerWithRedactionMasksMatchingValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewLoggerWithRedaction(path, RedactionRules{
+		Keys:     []string{"token"},
+		Patterns: []string{`/home/[^/\s]+`},
+	})
+	if err != nil {
+		t.Fatalf("NewLoggerWithRedaction() error = %v", err)
+	}
+
+	entry := map[string]any{
+		"action":  "source_add",
+		"status":  "success",
+		"details": "location=/home/alice/docs/wiki.zim",
+		"request": map[string]any{
+			"Token": "s3cret",
+			"paths": []any{"/home/bob/man", "/usr/share/man"},
+		},
+	}
+	if err := logger.Append(entry); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	for _, secret := range []string{"alice", "bob", "s3cret"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("expected %q to be redacted:\n%s", secret, data)
+		}
+	}
+
+	var written map[string]any
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("decode entry: %v", err)
+	}
+	if written["action"] != "source_add" || written["status"] != "success" {
+		t.Fatalf("expected unrelated fields to pass through, got %v", written)
+	}
+	if written["details"] != "location=***/docs/wiki.zim" {
+		t.Fatalf("unexpected redacted details %q", written["details"])
+	}
+	request, _ := written["request"].(map[string]any)
+	if request["Token"] != redactedValue {
+		t.Fatalf("expected nested key to be redacted, got %v", request["Token"])
+	}
+	if paths, _ := request["paths"].([]any); len(paths) != 2 || paths[0] != "***/man" || paths[1] != "/usr/share/man" {
+		t.Fatalf("unexpected redacted paths %v", request["paths"])
+	}
+
+	if entry["details"] != "location=/home/alice/docs/wiki.zim" {
+		t.Fatalf("expected caller's entry to stay untouched, got %v", entry["details"])
+	}
+}
+
+func TestNewLoggerWithRedactionRejectsInvalidPattern(t *testing.T) {
+	if _, err := NewLoggerWithRedaction(filepath.Join(t.TempDir(), "audit.log"), RedactionRules{Patterns: []string{"("}}); err == nil {
+		t.Fatal("expected invalid pattern to be rejected")
+	}
+}
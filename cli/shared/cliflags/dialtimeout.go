@@ -0,0 +1,14 @@
+package cliflags
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidateDialTimeout rejects a non-positive --dial-timeout before any connection is attempted.
+func ValidateDialTimeout(value time.Duration) error {
+	if value <= 0 {
+		return fmt.Errorf("--dial-timeout must be positive, got %s", value)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package cliflags
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateDialTimeout(t *testing.T) {
+	if err := ValidateDialTimeout(2 * time.Second); err != nil {
+		t.Fatalf("ValidateDialTimeout(2s) error = %v", err)
+	}
+	for _, value := range []time.Duration{0, -time.Second} {
+		if err := ValidateDialTimeout(value); err == nil {
+			t.Fatalf("expected ValidateDialTimeout(%s) to fail", value)
+		}
+	}
+}
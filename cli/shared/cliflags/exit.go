@@ -0,0 +1,37 @@
+package cliflags
+
+import "errors"
+
+// Exit codes shared by ragman and ragadmin; each CLI defines its own codes from 2 upwards.
+const (
+	// ExitOK signals success.
+	ExitOK = 0
+	// ExitFailure covers usage errors and any failure without a more specific code.
+	ExitFailure = 1
+)
+
+// ExitError attaches a process exit code to a command error.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode maps an error returned by a command tree's Execute to the process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
@@ -0,0 +1,25 @@
+package cliflags
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", want: ExitOK},
+		{name: "plain error", err: errors.New("boom"), want: ExitFailure},
+		{name: "exit error", err: &ExitError{Code: 5, Err: errors.New("dependency failed")}, want: 5},
+		{name: "wrapped exit error", err: fmt.Errorf("init: %w", &ExitError{Code: 130, Err: errors.New("interrupted")}), want: 130},
+	}
+	for _, tc := range cases {
+		if got := ExitCode(tc.err); got != tc.want {
+			t.Fatalf("%s: ExitCode() = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package cliflags holds the root-flag handling shared by the ragman and ragadmin command trees.
+package cliflags
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ResolveLogLevel picks the logger level. Precedence: --log-level, -v/-vv, the environment
+// variable, then warn. An unrecognised --log-level is an error; an unrecognised env value is ignored.
+func ResolveLogLevel(flagValue string, verbosity int, envValue string) (slog.Level, error) {
+	if trimmed := strings.TrimSpace(flagValue); trimmed != "" {
+		level, ok := parseLogLevel(trimmed)
+		if !ok {
+			return slog.LevelWarn, fmt.Errorf("invalid --log-level %q (expected debug, info, warn, or error)", flagValue)
+		}
+		return level, nil
+	}
+	switch {
+	case verbosity >= 2:
+		return slog.LevelDebug, nil
+	case verbosity == 1:
+		return slog.LevelInfo, nil
+	}
+	if level, ok := parseLogLevel(envValue); ok {
+		return level, nil
+	}
+	return slog.LevelWarn, nil
+}
+
+func parseLogLevel(raw string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn", "warning":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return slog.LevelWarn, false
+	}
+}
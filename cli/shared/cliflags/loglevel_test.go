@@ -0,0 +1,39 @@
+package cliflags
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestResolveLogLevelPrecedence(t *testing.T) {
+	cases := []struct {
+		name      string
+		flag      string
+		verbosity int
+		env       string
+		want      slog.Level
+	}{
+		{name: "default warn", want: slog.LevelWarn},
+		{name: "env fallback", env: "debug", want: slog.LevelDebug},
+		{name: "unknown env ignored", env: "loud", want: slog.LevelWarn},
+		{name: "single v", verbosity: 1, env: "error", want: slog.LevelInfo},
+		{name: "double v", verbosity: 2, want: slog.LevelDebug},
+		{name: "flag wins", flag: "error", verbosity: 2, env: "debug", want: slog.LevelError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ResolveLogLevel(tc.flag, tc.verbosity, tc.env)
+			if err != nil {
+				t.Fatalf("ResolveLogLevel() error = %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("ResolveLogLevel() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := ResolveLogLevel("loud", 0, ""); err == nil {
+		t.Fatal("expected an unknown --log-level to be rejected")
+	}
+}
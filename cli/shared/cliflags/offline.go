@@ -0,0 +1,36 @@
+package cliflags
+
+import (
+	"github.com/linux-rag-t2/cli/shared/ipc"
+	"github.com/spf13/pflag"
+)
+
+// OfflineGuard installs the loopback-only HTTP guard for the duration of a command run. The zero value
+// is ready to use once AddFlag has bound --offline.
+type OfflineGuard struct {
+	enabled bool
+	// restore reverts the HTTP transport installed by Enable; nil when no guard is active.
+	restore func()
+}
+
+// AddFlag registers --offline on flags. The guard is on unless --offline=false is given.
+func (g *OfflineGuard) AddFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(&g.enabled, "offline", true, "Block outbound HTTP requests to non-loopback hosts for the duration of the command (--offline=false to allow them)")
+}
+
+// Enable installs the loopback-only HTTP guard unless --offline=false was given.
+func (g *OfflineGuard) Enable() {
+	if !g.enabled || g.restore != nil {
+		return
+	}
+	g.restore = ipc.InstallOfflineHTTPGuard()
+}
+
+// Disable restores the original HTTP transport after a command run.
+func (g *OfflineGuard) Disable() {
+	if g.restore == nil {
+		return
+	}
+	g.restore()
+	g.restore = nil
+}
@@ -0,0 +1,60 @@
+package cliflags
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestOfflineGuardActiveDuringCommand(t *testing.T) {
+	original := http.DefaultTransport
+
+	cases := []struct {
+		name      string
+		args      []string
+		wantGuard bool
+	}{
+		{name: "default", wantGuard: true},
+		{name: "disabled", args: []string{"--offline=false"}, wantGuard: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var guard OfflineGuard
+			root := &cobra.Command{
+				Use:               "tool",
+				PersistentPreRun:  func(*cobra.Command, []string) { guard.Enable() },
+				PersistentPostRun: func(*cobra.Command, []string) { guard.Disable() },
+			}
+			guard.AddFlag(root.PersistentFlags())
+			var guarded bool
+			root.AddCommand(&cobra.Command{
+				Use: "probe",
+				RunE: func(*cobra.Command, []string) error {
+					guarded = http.DefaultTransport != original
+					return nil
+				},
+			})
+			root.SetArgs(append(tc.args, "probe"))
+			if err := root.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if guarded != tc.wantGuard {
+				t.Fatalf("guard active during run = %v, want %v", guarded, tc.wantGuard)
+			}
+			if http.DefaultTransport != original {
+				t.Fatal("expected the original HTTP transport to be restored after the run")
+			}
+		})
+	}
+}
+
+func TestOfflineGuardDisableWithoutEnable(t *testing.T) {
+	original := http.DefaultTransport
+	var guard OfflineGuard
+	guard.Disable()
+	if http.DefaultTransport != original {
+		t.Fatal("expected Disable without Enable to leave the transport untouched")
+	}
+}
@@ -0,0 +1,25 @@
+package cliflags
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveSocketPath determines the backend socket path. Precedence: --socket flag, shared.socket_path
+// in the config, $RAGCLI_SOCKET, $XDG_RUNTIME_DIR/ragcli/backend.sock, then the temp directory.
+func ResolveSocketPath(flagValue, configured string) string {
+	if trimmed := strings.TrimSpace(flagValue); trimmed != "" {
+		return trimmed
+	}
+	if trimmed := strings.TrimSpace(configured); trimmed != "" {
+		return trimmed
+	}
+	if env := strings.TrimSpace(os.Getenv("RAGCLI_SOCKET")); env != "" {
+		return env
+	}
+	if runtimeDir := strings.TrimSpace(os.Getenv("XDG_RUNTIME_DIR")); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "ragcli", "backend.sock")
+	}
+	return filepath.Join(os.TempDir(), "ragcli", "backend.sock")
+}
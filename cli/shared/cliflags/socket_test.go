@@ -0,0 +1,35 @@
+package cliflags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveSocketPathPrecedence(t *testing.T) {
+	runtimeDir := t.TempDir()
+	cases := []struct {
+		name       string
+		flag       string
+		configured string
+		env        string
+		runtimeDir string
+		want       string
+	}{
+		{name: "flag wins", flag: "/flag.sock", configured: "/config.sock", env: "/env.sock", runtimeDir: runtimeDir, want: "/flag.sock"},
+		{name: "config before env", configured: "/config.sock", env: "/env.sock", runtimeDir: runtimeDir, want: "/config.sock"},
+		{name: "env before xdg", env: "/env.sock", runtimeDir: runtimeDir, want: "/env.sock"},
+		{name: "xdg runtime dir", runtimeDir: runtimeDir, want: filepath.Join(runtimeDir, "ragcli", "backend.sock")},
+		{name: "temp fallback", want: filepath.Join(os.TempDir(), "ragcli", "backend.sock")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("RAGCLI_SOCKET", tc.env)
+			t.Setenv("XDG_RUNTIME_DIR", tc.runtimeDir)
+			if got := ResolveSocketPath(tc.flag, tc.configured); got != tc.want {
+				t.Fatalf("ResolveSocketPath() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
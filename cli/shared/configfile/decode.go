@@ -0,0 +1,66 @@
+// Package configfile decodes the ragcli configuration file shared by ragman and ragadmin.
+package configfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// unknownFieldPattern matches the yaml.v3 strict-decoding message for keys absent from the schema.
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type \S+$`)
+
+// DecodeStrict decodes data into out, which should mirror the whole file, with unknown keys rejected.
+// Unknown keys are returned separately from the error so callers can decide whether they are fatal;
+// any other decode failure is returned as the error, with the rest of the file still decoded where
+// possible.
+func DecodeStrict(data []byte, out any) ([]string, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err := decoder.Decode(out)
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return nil, err
+	}
+	var unknown, other []string
+	for _, msg := range typeErr.Errors {
+		if match := unknownFieldPattern.FindStringSubmatch(msg); match != nil {
+			unknown = append(unknown, fmt.Sprintf("line %s: unknown key %q", match[1], match[2]))
+			continue
+		}
+		other = append(other, msg)
+	}
+	if len(other) > 0 {
+		return unknown, &yaml.TypeError{Errors: other}
+	}
+	return unknown, nil
+}
+
+// Validate reads the file at path and strictly decodes it into out, returning unknown keys and values
+// of the wrong type as problems. A file that is not valid YAML yields that parse error as its only
+// problem. An error is returned only when the file cannot be read.
+func Validate(path string, out any) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: read file: %w", err)
+	}
+
+	problems, err := DecodeStrict(data, out)
+	if err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return []string{err.Error()}, nil
+		}
+		problems = append(problems, typeErr.Errors...)
+	}
+	return problems, nil
+}
@@ -0,0 +1,84 @@
+package configfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type testSchema struct {
+	Tool testSection `yaml:"tool"`
+}
+
+type testSection struct {
+	Name  string `yaml:"name"`
+	Limit int    `yaml:"limit"`
+}
+
+func TestDecodeStrictSeparatesUnknownKeys(t *testing.T) {
+	var raw testSchema
+	unknown, err := DecodeStrict([]byte("tool:\n  name: demo\n  colour: blue\n"), &raw)
+	if err != nil {
+		t.Fatalf("DecodeStrict() error = %v", err)
+	}
+	if want := []string{`line 3: unknown key "colour"`}; !reflect.DeepEqual(unknown, want) {
+		t.Fatalf("unknown = %v, want %v", unknown, want)
+	}
+	if raw.Tool.Name != "demo" {
+		t.Fatalf("expected known keys to still decode, got %+v", raw)
+	}
+}
+
+func TestDecodeStrictReturnsTypeErrors(t *testing.T) {
+	var raw testSchema
+	unknown, err := DecodeStrict([]byte("tool:\n  name: demo\n  limit: lots\n  colour: blue\n"), &raw)
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) || len(typeErr.Errors) != 1 {
+		t.Fatalf("expected a single type error, got %v", err)
+	}
+	if len(unknown) != 1 {
+		t.Fatalf("expected the unknown key alongside the type error, got %v", unknown)
+	}
+	if raw.Tool.Name != "demo" {
+		t.Fatalf("expected the rest of the file to decode, got %+v", raw)
+	}
+}
+
+func TestDecodeStrictEmptyDocument(t *testing.T) {
+	var raw testSchema
+	unknown, err := DecodeStrict(nil, &raw)
+	if err != nil || unknown != nil {
+		t.Fatalf("DecodeStrict(nil) = %v, %v; want no problems", unknown, err)
+	}
+}
+
+func TestValidateCollectsProblems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("tool:\n  limit: lots\n  colour: blue\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	var raw testSchema
+	problems, err := Validate(path, &raw)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected the unknown key and the type error, got %v", problems)
+	}
+
+	if err := os.WriteFile(path, []byte("tool: [unclosed\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	problems, err = Validate(path, &raw)
+	if err != nil || len(problems) != 1 {
+		t.Fatalf("Validate(malformed) = %v, %v; want a single parse problem", problems, err)
+	}
+
+	if _, err := Validate(filepath.Join(t.TempDir(), "missing.yaml"), &raw); err == nil {
+		t.Fatal("expected an unreadable file to be an error")
+	}
+}
@@ -0,0 +1,78 @@
+package configfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/linux-rag-t2/cli/shared/audit"
+)
+
+// Shared captures the `shared` section read by both ragman and ragadmin.
+type Shared struct {
+	// SocketPath pins the backend socket; the --socket flag still takes precedence.
+	SocketPath string `yaml:"socket_path"`
+	// AuditMaxSizeBytes rotates audit.log once it would exceed this size (0 uses the default).
+	AuditMaxSizeBytes int64 `yaml:"audit_max_size_bytes"`
+	// AuditMaxBackups is the number of rotated audit logs to keep; nil uses the default.
+	AuditMaxBackups *int `yaml:"audit_max_backups"`
+	// AuditRedactKeys masks audit entry values stored under these keys, matched case-insensitively.
+	AuditRedactKeys []string `yaml:"audit_redact_keys"`
+	// AuditRedactPatterns masks the parts of audit entry strings matching these regular expressions.
+	AuditRedactPatterns []string `yaml:"audit_redact_patterns"`
+}
+
+// Merge copies the values set in raw over s, skipping blanks and negative limits. Redaction patterns are
+// copied as given so a bad pattern fails audit logger construction instead of silently masking nothing.
+func (s *Shared) Merge(raw Shared) {
+	if trimmed := strings.TrimSpace(raw.SocketPath); trimmed != "" {
+		s.SocketPath = trimmed
+	}
+	if raw.AuditMaxSizeBytes > 0 {
+		s.AuditMaxSizeBytes = raw.AuditMaxSizeBytes
+	}
+	if raw.AuditMaxBackups != nil && *raw.AuditMaxBackups >= 0 {
+		backups := *raw.AuditMaxBackups
+		s.AuditMaxBackups = &backups
+	}
+	if len(raw.AuditRedactKeys) > 0 {
+		s.AuditRedactKeys = append([]string(nil), raw.AuditRedactKeys...)
+	}
+	if len(raw.AuditRedactPatterns) > 0 {
+		s.AuditRedactPatterns = append([]string(nil), raw.AuditRedactPatterns...)
+	}
+}
+
+// Validate reports shared values that Merge would silently ignore.
+func (s Shared) Validate() []string {
+	var problems []string
+	if s.AuditMaxSizeBytes < 0 {
+		problems = append(problems, fmt.Sprintf("shared.audit_max_size_bytes must not be negative, got %d", s.AuditMaxSizeBytes))
+	}
+	if s.AuditMaxBackups != nil && *s.AuditMaxBackups < 0 {
+		problems = append(problems, fmt.Sprintf("shared.audit_max_backups must not be negative, got %d", *s.AuditMaxBackups))
+	}
+	for idx, pattern := range s.AuditRedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("shared.audit_redact_patterns[%d] %q is not a valid regular expression: %v", idx, pattern, err))
+		}
+	}
+	return problems
+}
+
+// AuditOptions returns the rotation limits every writer of the shared audit.log must agree on.
+func (s Shared) AuditOptions() []audit.Option {
+	backups := -1
+	if s.AuditMaxBackups != nil {
+		backups = *s.AuditMaxBackups
+	}
+	return []audit.Option{
+		audit.WithMaxSizeBytes(s.AuditMaxSizeBytes),
+		audit.WithMaxBackups(backups),
+	}
+}
+
+// AuditRedaction returns the masking rules applied to every entry written to the shared audit.log.
+func (s Shared) AuditRedaction() audit.RedactionRules {
+	return audit.RedactionRules{Keys: s.AuditRedactKeys, Patterns: s.AuditRedactPatterns}
+}
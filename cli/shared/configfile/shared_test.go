@@ -0,0 +1,61 @@
+package configfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/linux-rag-t2/cli/shared/audit"
+)
+
+func TestSharedMergeSkipsInvalidValues(t *testing.T) {
+	backups, negative := 1, -2
+	var shared Shared
+	shared.Merge(Shared{SocketPath: " /run/ragcli/backend.sock ", AuditMaxSizeBytes: 4096, AuditMaxBackups: &backups})
+	shared.Merge(Shared{AuditMaxSizeBytes: -1, AuditMaxBackups: &negative})
+
+	if shared.SocketPath != "/run/ragcli/backend.sock" {
+		t.Fatalf("SocketPath = %q, want the trimmed path", shared.SocketPath)
+	}
+	if shared.AuditMaxSizeBytes != 4096 || shared.AuditMaxBackups == nil || *shared.AuditMaxBackups != 1 {
+		t.Fatalf("expected the invalid values to be skipped, got %+v", shared)
+	}
+	if problems := (Shared{AuditMaxSizeBytes: -1, AuditMaxBackups: &negative}).Validate(); len(problems) != 2 {
+		t.Fatalf("Validate() = %v, want both negative limits reported", problems)
+	}
+}
+
+func TestSharedAuditOptionsApplyRotationLimits(t *testing.T) {
+	backups := 1
+	shared := Shared{AuditMaxSizeBytes: 200, AuditMaxBackups: &backups}
+	dir := t.TempDir()
+	logger, err := audit.NewLogger(filepath.Join(dir, "audit.log"), shared.AuditOptions()...)
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		if err := logger.Append(map[string]any{"action": "query", "target": fmt.Sprintf("q-%02d", i)}); err != nil {
+			t.Fatalf("Append() #%d error = %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "audit.log.1")); err != nil {
+		t.Fatalf("expected a rotated backup: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "audit.log.2")); !os.IsNotExist(err) {
+		t.Fatalf("expected only one backup to be kept, stat err = %v", err)
+	}
+}
+
+func TestSharedValidateReportsBadRedactionPattern(t *testing.T) {
+	shared := Shared{AuditRedactPatterns: []string{`/home/[^/]+`, `([unclosed`}}
+	problems := shared.Validate()
+	if len(problems) != 1 || !strings.Contains(problems[0], "shared.audit_redact_patterns[1]") {
+		t.Fatalf("Validate() = %v, want the second pattern reported", problems)
+	}
+	if _, err := audit.NewLoggerWithRedaction(filepath.Join(t.TempDir(), "audit.log"), shared.AuditRedaction()); err == nil {
+		t.Fatal("expected the audit logger to reject the bad pattern")
+	}
+}
@@ -2,5 +2,14 @@ module github.com/linux-rag-t2/cli/shared
 
 go 1.23
 
+require (
+	github.com/spf13/cobra v1.9.1
+	github.com/spf13/pflag v1.0.6
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require github.com/inconshreveable/mousetrap v1.1.0 // indirect
+
 replace github.com/linux-rag-t2/cli/ragman => ../ragman
+
 replace github.com/linux-rag-t2/cli/ragadmin => ../ragadmin
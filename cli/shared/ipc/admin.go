@@ -14,6 +14,8 @@ const (
 // InitRequest triggers backend initialization workflows.
 type InitRequest struct {
 	TraceID string `json:"trace_id"`
+	// DryRun asks the backend to report planned directories and seeded sources without mutating state.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // InitResponse captures the initialization summary payload.
@@ -65,8 +67,8 @@ func (c *Client) InitSystem(ctx context.Context, req InitRequest) (InitResponse,
 	if err != nil {
 		return InitResponse{}, err
 	}
-	if frame.Status != statusOK {
-		return InitResponse{}, fmt.Errorf("ipc: admin init unexpected status %d", frame.Status)
+	if err := expectStatus("admin init", frame, statusOK, req.TraceID); err != nil {
+		return InitResponse{}, err
 	}
 	resp, err := decodeInitResponse(frame.Body)
 	if err != nil {
@@ -89,8 +91,8 @@ func (c *Client) HealthCheck(ctx context.Context, req HealthRequest) (HealthSumm
 	if err != nil {
 		return HealthSummary{}, err
 	}
-	if frame.Status != statusOK {
-		return HealthSummary{}, fmt.Errorf("ipc: admin health unexpected status %d", frame.Status)
+	if err := expectStatus("admin health", frame, statusOK, req.TraceID); err != nil {
+		return HealthSummary{}, err
 	}
 	summary, err := decodeHealthSummary(frame.Body)
 	if err != nil {
@@ -11,26 +11,61 @@ import (
 	"log/slog"
 	"net"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
+// ErrHandshakeTimeout is returned when the server accepts the connection but does not acknowledge
+// the handshake within Config.HandshakeTimeout.
+var ErrHandshakeTimeout = errors.New("ipc: handshake timed out")
+
+// ErrClientShutdown is returned for requests issued after Shutdown has been called.
+var ErrClientShutdown = errors.New("ipc: client is shutting down")
+
+// ErrStreamClosed is returned by DoStream when the backend closes the stream before the caller saw a terminal frame.
+var ErrStreamClosed = errors.New("ipc: response stream closed before completion")
+
 // Client is a newline-delimited JSON IPC client that communicates with the backend server.
+//
+// A single Client may issue any number of sequential calls (Query, ListSources, Do, ...).
+// Calls are serialised by an internal mutex, and the handshake acknowledgement is consumed
+// once by the first request; later requests go straight to their response frames.
+// If a transport error leaves a response unread, the connection is marked broken. With
+// AutoReconnect the next call redials first; otherwise every later call fails fast with that
+// error, and the caller must close the client and dial a new one to recover.
 type Client struct {
 	conn   net.Conn
 	reader *bufio.Reader
 	writer *bufio.Writer
 	log    *slog.Logger
 
+	socket            string
+	dialTimeout       time.Duration
+	handshakeTimeout  time.Duration
 	clientID          string
 	awaitHandshakeAck bool
+	autoReconnect     bool
+	eagerHandshake    bool
+	serverCaps        []string
+	negotiatedVersion int
+	compressFrames    bool
+	maxFrameSize      int
+	broken            error
 	mu                sync.Mutex
 	retrySchedule     []time.Duration
+
+	// live mirrors conn outside the mutex so Shutdown can force-close a connection that an
+	// in-flight call is still holding once the drain deadline passes.
+	live         atomic.Pointer[net.Conn]
+	shuttingDown atomic.Bool
 }
 
 // responseIterator yields additional response frames while a streaming call remains active.
-type responseIterator func(context.Context) (responseFrame, bool, error)
+type responseIterator func(context.Context) (ResponseFrame, bool, error)
 
 // NewClient establishes a Unix socket connection, performs the handshake, and returns a ready client.
 func NewClient(cfg Config) (*Client, error) {
@@ -47,6 +82,11 @@ func NewClient(cfg Config) (*Client, error) {
 		dialTimeout = defaultDialTimout
 	}
 
+	handshakeTimeout := cfg.HandshakeTimeout
+	if handshakeTimeout <= 0 {
+		handshakeTimeout = defaultHandshakeTimeout
+	}
+
 	socket := cfg.SocketPath
 	if !filepath.IsAbs(socket) {
 		socket = filepath.Clean(socket)
@@ -58,35 +98,101 @@ func NewClient(cfg Config) (*Client, error) {
 	}
 	log := logger.With("socket", socket, "client", clientID)
 	retrySchedule := normalizeRetrySchedule(cfg.RetrySchedule)
+	maxFrameSize, err := normalizeMaxFrameSize(cfg.MaxFrameSize)
+	if err != nil {
+		return nil, err
+	}
 	log.Info("IPCClient.NewClient(config) :: dial")
 
-	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	c := &Client{
+		socket:           socket,
+		dialTimeout:      dialTimeout,
+		handshakeTimeout: handshakeTimeout,
+		clientID:         clientID,
+		retrySchedule:    retrySchedule,
+		autoReconnect:    cfg.AutoReconnect,
+		eagerHandshake:   cfg.EagerHandshake,
+		maxFrameSize:     maxFrameSize,
+		log:              log,
+	}
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	log.Info("IPCClient.NewClient(config) :: ready")
+	return c, nil
+}
+
+// connect dials the backend socket and sends the handshake. The ack is consumed here in eager
+// mode and otherwise by the next request.
+func (c *Client) connect() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.dialTimeout)
 	defer cancel()
 
 	var d net.Dialer
-	conn, err := d.DialContext(ctx, "unix", socket)
+	conn, err := d.DialContext(ctx, "unix", c.socket)
 	if err != nil {
-		log.Error("IPCClient.NewClient(config) :: dial_failed", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("ipc: dial unix socket: %w", err)
+		c.log.Error("IPCClient.connect() :: dial_failed", slog.String("error", err.Error()))
+		return fmt.Errorf("ipc: dial unix socket: %w", err)
 	}
 
-	c := &Client{
-		conn:              conn,
-		reader:            bufio.NewReader(conn),
-		writer:            bufio.NewWriter(conn),
-		clientID:          clientID,
-		retrySchedule:     retrySchedule,
-		log:               log,
-		awaitHandshakeAck: true,
-	}
+	c.conn = conn
+	c.live.Store(&conn)
+	c.reader = bufio.NewReader(conn)
+	c.writer = bufio.NewWriter(conn)
+	c.broken = nil
 
 	if err := c.sendHandshake(); err != nil {
-		_ = c.Close()
-		return nil, err
+		_ = conn.Close()
+		c.conn = nil
+		return err
+	}
+	if c.eagerHandshake {
+		if err := c.consumeHandshakeAck(context.Background()); err != nil {
+			_ = conn.Close()
+			c.conn = nil
+			return err
+		}
 	}
+	return nil
+}
 
-	log.Info("IPCClient.NewClient(config) :: ready")
-	return c, nil
+// reconnect replaces a closed connection with a fresh dial and handshake, waiting for the
+// first retry delay so a restarting backend has a moment to listen again.
+func (c *Client) reconnect(ctx context.Context, cause error) error {
+	c.log.Warn("IPCClient.reconnect(ctx) :: start", slog.String("cause", cause.Error()))
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+	if err := sleepWithContext(ctx, c.retrySchedule[0]); err != nil {
+		return err
+	}
+	if err := c.connect(); err != nil {
+		return fmt.Errorf("ipc: reconnect after %v: %w", cause, err)
+	}
+	c.log.Info("IPCClient.reconnect(ctx) :: ready")
+	return nil
+}
+
+// shouldReconnect reports whether err indicates a closed connection that AutoReconnect may recover.
+func (c *Client) shouldReconnect(err error) bool {
+	if !c.autoReconnect || err == nil {
+		return false
+	}
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// recoverBroken redials a connection marked broken by an earlier failure (a timed-out read,
+// for example) when AutoReconnect is enabled, so one failed call does not disable the client.
+func (c *Client) recoverBroken(ctx context.Context) error {
+	if !c.autoReconnect || c.broken == nil || c.conn == nil || c.shuttingDown.Load() {
+		return nil
+	}
+	return c.reconnect(ctx, c.broken)
 }
 
 // sendHandshake sends the initial identification frame to the backend.
@@ -94,10 +200,12 @@ func (c *Client) sendHandshake() error {
 	c.log.Info("IPCClient.sendHandshake() :: start")
 
 	frame := handshakeFrame{
-		Type:     handshakeType,
-		Protocol: protocolName,
-		Version:  protocolVersion,
-		Client:   c.clientID,
+		Type:         handshakeType,
+		Protocol:     protocolName,
+		Version:      protocolVersion,
+		Client:       c.clientID,
+		Versions:     supportedProtocolVersions(),
+		Capabilities: clientCapabilities,
 	}
 	if err := writeFrame(c.writer, frame); err != nil {
 		c.log.Error("IPCClient.sendHandshake() :: write_failed", slog.String("error", err.Error()))
@@ -109,47 +217,98 @@ func (c *Client) sendHandshake() error {
 	return nil
 }
 
-// Close releases the underlying socket connection.
+// Close releases the underlying socket connection immediately, aborting any call or stream
+// still in flight. Use Shutdown to let an in-flight stream reach its terminal frame first.
 func (c *Client) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	return c.closeLocked()
+}
+
+// Shutdown stops the client gracefully: new requests fail with ErrClientShutdown, an in-flight
+// call or stream is allowed to finish, and the connection is closed afterwards. When ctx ends
+// before the in-flight work drains, the connection is closed anyway and ctx's error is returned.
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.shuttingDown.Store(true)
+
+	drained := make(chan struct{})
+	go func() {
+		c.mu.Lock()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		defer c.mu.Unlock()
+		return c.closeLocked()
+	case <-ctx.Done():
+		c.log.Warn("IPCClient.Shutdown(ctx) :: drain_timeout", slog.String("error", ctx.Err().Error()))
+		if conn := c.live.Load(); conn != nil {
+			_ = (*conn).Close()
+		}
+		<-drained
+		defer c.mu.Unlock()
+		_ = c.closeLocked()
+		return fmt.Errorf("ipc: shutdown: %w", ctx.Err())
+	}
+}
+
+func (c *Client) closeLocked() error {
 	if c.conn == nil {
 		return nil
 	}
 	err := c.conn.Close()
 	c.conn = nil
+	c.live.Store(nil)
 	return err
 }
 
+// RequestMeta describes transport details of a completed request for debugging.
+type RequestMeta struct {
+	// CorrelationID is the frame identifier generated for the request.
+	CorrelationID string
+	// TraceID is the trace echoed by the backend, or the request trace when none was returned.
+	TraceID string
+	// Status is the raw response frame status.
+	Status int
+}
+
 // Query sends a /v1/query request and decodes the structured response.
 func (c *Client) Query(ctx context.Context, req QueryRequest) (QueryResponse, error) {
+	resp, _, err := c.QueryWithMeta(ctx, req)
+	return resp, err
+}
+
+// QueryWithMeta behaves like Query and additionally returns the request metadata.
+// The metadata is populated as far as the exchange progressed, including for backend errors.
+func (c *Client) QueryWithMeta(ctx context.Context, req QueryRequest) (QueryResponse, RequestMeta, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if c.conn == nil {
-		return QueryResponse{}, errors.New("ipc: client closed")
+		return QueryResponse{}, RequestMeta{}, errors.New("ipc: client closed")
 	}
-	req.Question = strings.TrimSpace(req.Question)
-	if req.Question == "" {
-		return QueryResponse{}, errors.New("ipc: question must be provided")
-	}
-	req.ConversationID = strings.TrimSpace(req.ConversationID)
-	req.TraceID = strings.TrimSpace(req.TraceID)
-	if req.MaxContextTokens <= 0 {
-		req.MaxContextTokens = defaultMaxContextTokens
+	if err := normalizeQueryRequest(&req); err != nil {
+		return QueryResponse{}, RequestMeta{}, err
 	}
 
+	meta := RequestMeta{TraceID: req.TraceID}
 	respFrame, err := c.call(ctx, queryPath, req)
 	if err != nil {
-		return QueryResponse{}, err
+		return QueryResponse{}, meta, err
 	}
-	if respFrame.Status != 200 {
-		return QueryResponse{}, fmt.Errorf("ipc: backend returned status %d", respFrame.Status)
+	meta.CorrelationID = respFrame.CorrelationID
+	meta.Status = respFrame.Status
+	if err := expectStatus("query", respFrame, statusOK, req.TraceID); err != nil {
+		return QueryResponse{}, meta, err
 	}
 
-	queryResp, err := DecodeQueryResponse(respFrame.Body)
+	queryResp, err := decodeQueryResponse(respFrame.Body, c.log)
 	if err != nil {
-		return QueryResponse{}, fmt.Errorf("ipc: decode query response: %w", err)
+		return QueryResponse{}, meta, fmt.Errorf("ipc: decode query response: %w", err)
+	}
+	if queryResp.TraceID != "" {
+		meta.TraceID = queryResp.TraceID
 	}
 
 	c.log.Info(
@@ -158,13 +317,91 @@ func (c *Client) Query(ctx context.Context, req QueryRequest) (QueryResponse, er
 		slog.String("trace_id", queryResp.TraceID),
 	)
 
-	return queryResp, nil
+	return queryResp, meta, nil
+}
+
+// Do sends a request to an arbitrary backend path and returns the raw response frame.
+// It shares the handshake-validated connection, correlation ID checks, and frame size guard
+// used by the typed helpers, but leaves status handling and body decoding to the caller.
+func (c *Client) Do(ctx context.Context, path string, body any) (ResponseFrame, error) {
+	path, err := normalizeRequestPath(path)
+	if err != nil {
+		return ResponseFrame{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.call(ctx, path, body)
+}
+
+// DoStream sends a request to an arbitrary backend path and hands every response frame
+// sharing its correlation ID to onFrame. The callback returns false once it has seen the
+// terminal frame; ErrStreamClosed is returned if the backend ends the stream first.
+// The connection stays locked until the stream completes.
+func (c *Client) DoStream(ctx context.Context, path string, body any, onFrame func(ResponseFrame) (bool, error)) error {
+	if onFrame == nil {
+		return errors.New("ipc: stream callback must be provided")
+	}
+	path, err := normalizeRequestPath(path)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frame, iter, err := c.callStream(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	for {
+		more, err := onFrame(frame)
+		if err != nil {
+			return fmt.Errorf("ipc: stream callback: %w", err)
+		}
+		if !more {
+			return nil
+		}
+
+		next, ok, err := iter(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrStreamClosed
+		}
+		frame = next
+	}
+}
+
+// normalizeRequestPath validates caller-supplied backend paths for Do and DoStream.
+func normalizeRequestPath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("ipc: request path %q must be absolute", path)
+	}
+	return path, nil
+}
+
+func (c *Client) call(ctx context.Context, path string, body any) (ResponseFrame, error) {
+	if err := c.recoverBroken(ctx); err != nil {
+		return ResponseFrame{}, err
+	}
+	frame, err := c.callOnce(ctx, path, body)
+	if c.shouldReconnect(err) {
+		if reconnectErr := c.reconnect(ctx, err); reconnectErr != nil {
+			return ResponseFrame{}, reconnectErr
+		}
+		frame, err = c.callOnce(ctx, path, body)
+	}
+	return frame, err
 }
 
-func (c *Client) call(ctx context.Context, path string, body any) (responseFrame, error) {
+func (c *Client) callOnce(ctx context.Context, path string, body any) (ResponseFrame, error) {
 	correlationID, err := c.sendRequest(ctx, path, body)
 	if err != nil {
-		return responseFrame{}, err
+		return ResponseFrame{}, err
 	}
 
 	frame, err := c.readResponseFrame(ctx, correlationID)
@@ -173,27 +410,29 @@ func (c *Client) call(ctx context.Context, path string, body any) (responseFrame
 			"IPCClient.call(ctx, request) :: read_failed",
 			slog.String("error", err.Error()),
 		)
-		return responseFrame{}, err
+		return ResponseFrame{}, c.markBroken(err)
 	}
 	return frame, nil
 }
 
-func (c *Client) callStream(ctx context.Context, path string, body any) (responseFrame, responseIterator, error) {
-	correlationID, err := c.sendRequest(ctx, path, body)
-	if err != nil {
-		return responseFrame{}, nil, err
+// callStream sends a streaming request. AutoReconnect only retries until the first frame
+// arrives; a stream interrupted afterwards is reported to the caller.
+func (c *Client) callStream(ctx context.Context, path string, body any) (ResponseFrame, responseIterator, error) {
+	if err := c.recoverBroken(ctx); err != nil {
+		return ResponseFrame{}, nil, err
+	}
+	correlationID, firstFrame, err := c.openStream(ctx, path, body)
+	if c.shouldReconnect(err) {
+		if reconnectErr := c.reconnect(ctx, err); reconnectErr != nil {
+			return ResponseFrame{}, nil, reconnectErr
+		}
+		correlationID, firstFrame, err = c.openStream(ctx, path, body)
 	}
-
-	firstFrame, err := c.readResponseFrame(ctx, correlationID)
 	if err != nil {
-		c.log.Error(
-			"IPCClient.callStream(ctx, request) :: read_failed",
-			slog.String("error", err.Error()),
-		)
-		return responseFrame{}, nil, err
+		return ResponseFrame{}, nil, err
 	}
 
-	iter := func(ctx context.Context) (responseFrame, bool, error) {
+	iter := func(ctx context.Context) (ResponseFrame, bool, error) {
 		// For streaming, avoid inheriting short-lived parent deadlines; use a generous read timeout instead.
 		readCtx := ctx
 		if ctx == nil || ctx.Done() == nil {
@@ -206,14 +445,14 @@ func (c *Client) callStream(ctx context.Context, path string, body any) (respons
 		data, err := c.readFrameWithRetry(perReadCtx)
 		if err != nil {
 			if isStreamClosedError(err) {
-				return responseFrame{}, false, nil
+				return ResponseFrame{}, false, nil
 			}
-			return responseFrame{}, false, fmt.Errorf("ipc: read response: %w", err)
+			return ResponseFrame{}, false, c.markBroken(fmt.Errorf("ipc: read response: %w", err))
 		}
 
 		nextFrame, err := decodeResponseFrame(data, correlationID)
 		if err != nil {
-			return responseFrame{}, false, err
+			return ResponseFrame{}, false, err
 		}
 		return nextFrame, true, nil
 	}
@@ -221,11 +460,36 @@ func (c *Client) callStream(ctx context.Context, path string, body any) (respons
 	return firstFrame, iter, nil
 }
 
-// consumeHandshakeAck waits for the server handshake acknowledgement.
+// openStream sends the request and reads the first response frame of a stream.
+func (c *Client) openStream(ctx context.Context, path string, body any) (string, ResponseFrame, error) {
+	correlationID, err := c.sendRequest(ctx, path, body)
+	if err != nil {
+		return "", ResponseFrame{}, err
+	}
+
+	firstFrame, err := c.readResponseFrame(ctx, correlationID)
+	if err != nil {
+		c.log.Error(
+			"IPCClient.callStream(ctx, request) :: read_failed",
+			slog.String("error", err.Error()),
+		)
+		return "", ResponseFrame{}, c.markBroken(err)
+	}
+	return correlationID, firstFrame, nil
+}
+
+// consumeHandshakeAck waits for the server handshake acknowledgement, giving up after the
+// handshake timeout so a server that accepts but never answers fails fast.
 func (c *Client) consumeHandshakeAck(ctx context.Context) error {
-	data, err := c.readFrameWithRetry(ctx)
+	ackCtx, cancel := context.WithTimeout(ctx, c.handshakeTimeout)
+	defer cancel()
+
+	data, err := c.readFrameWithRetry(ackCtx)
 	if err != nil {
 		c.log.Error("IPCClient.consumeHandshakeAck(ctx) :: read_failed", slog.String("error", err.Error()))
+		if ctx.Err() == nil && errors.Is(ackCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s: %w", ErrHandshakeTimeout, c.handshakeTimeout, err)
+		}
 		return fmt.Errorf("ipc: read handshake acknowledgement: %w", err)
 	}
 
@@ -240,20 +504,75 @@ func (c *Client) consumeHandshakeAck(ctx context.Context) error {
 	if ack.Protocol != protocolName {
 		return fmt.Errorf("ipc: server protocol mismatch %q", ack.Protocol)
 	}
-	if ack.Version != protocolVersion {
-		return fmt.Errorf("ipc: server protocol version %d unsupported", ack.Version)
+	if ack.Version < protocolVersion || ack.Version > maxProtocolVersion {
+		return fmt.Errorf(
+			"ipc: server protocol version %d unsupported (client supports %d-%d)",
+			ack.Version, protocolVersion, maxProtocolVersion,
+		)
 	}
 
+	c.negotiatedVersion = ack.Version
+	c.serverCaps = normalizeCapabilities(ack.Capabilities)
+	c.compressFrames = ack.Version >= gzipProtocolVersion && slices.Contains(c.serverCaps, CapabilityGzip)
 	c.awaitHandshakeAck = false
-	c.log.Info("IPCClient.consumeHandshakeAck(ctx) :: ack", slog.String("server", ack.Server))
+	c.log.Info(
+		"IPCClient.consumeHandshakeAck(ctx) :: ack",
+		slog.String("server", ack.Server),
+		slog.Int("version", ack.Version),
+		slog.Any("capabilities", c.serverCaps),
+	)
 	return nil
 }
 
+// ServerCapabilities returns the optional features advertised by the server.
+// The acknowledgement is read lazily, so the list is empty until the first request completes
+// and for servers that do not advertise capabilities.
+func (c *Client) ServerCapabilities() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.serverCaps...)
+}
+
+// ProtocolVersion returns the protocol version chosen by the server, or 0 until the
+// handshake acknowledgement has been read by the first request.
+func (c *Client) ProtocolVersion() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.negotiatedVersion
+}
+
+// supportedProtocolVersions lists every protocol version the client can speak, oldest first.
+func supportedProtocolVersions() []int {
+	versions := make([]int, 0, maxProtocolVersion-protocolVersion+1)
+	for version := protocolVersion; version <= maxProtocolVersion; version++ {
+		versions = append(versions, version)
+	}
+	return versions
+}
+
+// normalizeCapabilities trims, lowercases, and de-duplicates advertised capability names.
+func normalizeCapabilities(caps []string) []string {
+	out := make([]string, 0, len(caps))
+	seen := make(map[string]struct{}, len(caps))
+	for _, capability := range caps {
+		capability = strings.ToLower(strings.TrimSpace(capability))
+		if capability == "" {
+			continue
+		}
+		if _, ok := seen[capability]; ok {
+			continue
+		}
+		seen[capability] = struct{}{}
+		out = append(out, capability)
+	}
+	return out
+}
+
 // readFrameWithRetry reads a frame, retrying on temporary network errors.
 func (c *Client) readFrameWithRetry(ctx context.Context) ([]byte, error) {
 	var attempt int
 	for {
-		data, err := readFrame(ctx, c.reader, c.conn)
+		data, err := readFrame(ctx, c.reader, c.conn, c.frameLimit())
 		if err == nil {
 			return data, nil
 		}
@@ -275,6 +594,40 @@ func (c *Client) readFrameWithRetry(ctx context.Context) ([]byte, error) {
 	}
 }
 
+// writeFrameWithRetry encodes a frame and writes it to the connection, retrying temporary
+// write failures with the same schedule as reads. Partial writes resume where they stopped so
+// the backend never sees a duplicated prefix; non-retryable errors fail immediately.
+func (c *Client) writeFrameWithRetry(ctx context.Context, payload any) error {
+	data, err := encodeFrame(payload, c.compressFrames)
+	if err != nil {
+		return err
+	}
+
+	var attempt int
+	for {
+		n, err := c.conn.Write(data)
+		data = data[n:]
+		if err == nil {
+			return nil
+		}
+		if !isRetryableError(err) || attempt >= len(c.retrySchedule) {
+			return err
+		}
+
+		delay := c.retrySchedule[attempt]
+		attempt++
+		c.log.Warn(
+			"IPCClient.writeFrameWithRetry(ctx) :: retry",
+			slog.String("error", err.Error()),
+			slog.Duration("delay", delay),
+			slog.Int("attempt", attempt),
+		)
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
 // normalizeRetrySchedule sanitizes custom retry schedules and falls back to defaults.
 func normalizeRetrySchedule(schedule []time.Duration) []time.Duration {
 	if len(schedule) == 0 {
@@ -294,6 +647,28 @@ func normalizeRetrySchedule(schedule []time.Duration) []time.Duration {
 	return out
 }
 
+// normalizeMaxFrameSize validates a configured frame limit, applying the default and ceiling.
+func normalizeMaxFrameSize(size int) (int, error) {
+	switch {
+	case size == 0:
+		return defaultMaxFrameSize, nil
+	case size < 0:
+		return 0, fmt.Errorf("ipc: max frame size must be positive, got %d", size)
+	case size > maxFrameSizeCeiling:
+		return maxFrameSizeCeiling, nil
+	default:
+		return size, nil
+	}
+}
+
+// frameLimit returns the maximum frame size accepted by this client.
+func (c *Client) frameLimit() int {
+	if c.maxFrameSize <= 0 {
+		return defaultMaxFrameSize
+	}
+	return c.maxFrameSize
+}
+
 // isRetryableError reports whether the error warrants another frame read attempt.
 func isRetryableError(err error) bool {
 	if err == nil {
@@ -332,9 +707,15 @@ func sleepWithContext(ctx context.Context, delay time.Duration) error {
 }
 
 func (c *Client) sendRequest(ctx context.Context, path string, body any) (string, error) {
+	if c.shuttingDown.Load() {
+		return "", ErrClientShutdown
+	}
 	if c.conn == nil {
 		return "", errors.New("ipc: client closed")
 	}
+	if c.broken != nil {
+		return "", fmt.Errorf("ipc: connection unusable after earlier failure: %w", c.broken)
+	}
 	if body == nil {
 		body = map[string]any{}
 	}
@@ -350,48 +731,68 @@ func (c *Client) sendRequest(ctx context.Context, path string, body any) (string
 		Type:          requestType,
 		Path:          path,
 		CorrelationID: correlationID,
+		Deadline:      requestDeadline(ctx),
 		Body:          body,
 	}
-	if err := writeFrame(c.writer, frame); err != nil {
+	if err := c.writeFrameWithRetry(ctx, frame); err != nil {
 		c.log.Error(
 			"IPCClient.call(ctx, request) :: write_failed",
 			slog.String("error", err.Error()),
 		)
-		return "", fmt.Errorf("ipc: write request: %w", err)
+		return "", c.markBroken(fmt.Errorf("ipc: write request: %w", err))
 	}
 
 	if c.awaitHandshakeAck {
 		if err := c.consumeHandshakeAck(ctx); err != nil {
-			return "", err
+			return "", c.markBroken(err)
 		}
 	}
 	return correlationID, nil
 }
 
-func (c *Client) readResponseFrame(ctx context.Context, correlationID string) (responseFrame, error) {
+// markBroken records a transport failure that leaves the connection out of sync with the backend.
+func (c *Client) markBroken(err error) error {
+	c.broken = err
+	return err
+}
+
+func (c *Client) readResponseFrame(ctx context.Context, correlationID string) (ResponseFrame, error) {
 	data, err := c.readFrameWithRetry(ctx)
 	if err != nil {
-		return responseFrame{}, fmt.Errorf("ipc: read response: %w", err)
+		return ResponseFrame{}, fmt.Errorf("ipc: read response: %w", err)
 	}
 	return decodeResponseFrame(data, correlationID)
 }
 
-func decodeResponseFrame(payload []byte, expectedCorrelationID string) (responseFrame, error) {
-	var respFrame responseFrame
+func decodeResponseFrame(payload []byte, expectedCorrelationID string) (ResponseFrame, error) {
+	var respFrame ResponseFrame
 	if err := json.Unmarshal(payload, &respFrame); err != nil {
-		return responseFrame{}, fmt.Errorf("ipc: decode response frame: %w", err)
+		return ResponseFrame{}, fmt.Errorf("ipc: decode response frame: %w", err)
 	}
 
 	if respFrame.Type != responseType {
-		return responseFrame{}, fmt.Errorf("ipc: unexpected frame type %q", respFrame.Type)
+		return ResponseFrame{}, fmt.Errorf("ipc: unexpected frame type %q", respFrame.Type)
 	}
 	if expectedCorrelationID != "" && respFrame.CorrelationID != expectedCorrelationID {
-		return responseFrame{}, fmt.Errorf("ipc: correlation id mismatch %q", respFrame.CorrelationID)
+		return ResponseFrame{}, fmt.Errorf("ipc: correlation id mismatch %q", respFrame.CorrelationID)
 	}
 
 	return respFrame, nil
 }
 
+// requestDeadline formats the context deadline for the request envelope, or returns "" when
+// ctx has none.
+func requestDeadline(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return ""
+	}
+	return deadline.UTC().Format(time.RFC3339Nano)
+}
+
 func isStreamClosedError(err error) bool {
 	if err == nil {
 		return false
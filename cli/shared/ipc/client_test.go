@@ -0,0 +1,298 @@
+package ipc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDoReturnsRawFrameForCustomPath(t *testing.T) {
+	client := newTestFrameClient(t, map[string]any{
+		"type":           responseType,
+		"status":         418,
+		"correlation_id": "test-correlation",
+		"body":           map[string]any{"detail": "custom"},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	frame, err := client.Do(ctx, "/v1/custom", map[string]any{"ping": true})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if frame.Status != 418 || !strings.Contains(string(frame.Body), "custom") {
+		t.Fatalf("unexpected frame: %#v", frame)
+	}
+}
+
+func TestDoRejectsMismatchedCorrelationID(t *testing.T) {
+	client := newTestFrameClient(t, map[string]any{
+		"type":           responseType,
+		"status":         statusOK,
+		"correlation_id": "someone-else",
+		"body":           map[string]any{},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := client.Do(ctx, "/v1/custom", nil); err == nil || !strings.Contains(err.Error(), "correlation id mismatch") {
+		t.Fatalf("expected correlation mismatch error, got %v", err)
+	}
+}
+
+func TestDoStreamStopsWhenCallbackDeclines(t *testing.T) {
+	frame := map[string]any{
+		"type":           responseType,
+		"status":         statusAccepted,
+		"correlation_id": "test-correlation",
+		"body":           map[string]any{},
+	}
+	client := newTestFrameClient(t, frame, frame, frame)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var seen int
+	err := client.DoStream(ctx, "/v1/custom/stream", nil, func(ResponseFrame) (bool, error) {
+		seen++
+		return seen < 2, nil
+	})
+	if err != nil {
+		t.Fatalf("DoStream() error = %v", err)
+	}
+	if seen != 2 {
+		t.Fatalf("expected 2 frames before stopping, got %d", seen)
+	}
+}
+
+func TestDoStreamReportsEarlyClose(t *testing.T) {
+	client := newTestFrameClient(t, map[string]any{
+		"type":           responseType,
+		"status":         statusAccepted,
+		"correlation_id": "test-correlation",
+		"body":           map[string]any{},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := client.DoStream(ctx, "/v1/custom/stream", nil, func(ResponseFrame) (bool, error) {
+		return true, nil
+	})
+	if !errors.Is(err, ErrStreamClosed) {
+		t.Fatalf("expected ErrStreamClosed, got %v", err)
+	}
+}
+
+func TestDoRejectsRelativePath(t *testing.T) {
+	client := newTestFrameClient(t)
+	if _, err := client.Do(context.Background(), "v1/custom", nil); err == nil {
+		t.Fatal("expected relative path to be rejected")
+	}
+}
+
+func newTestFrameClient(t *testing.T, frames ...map[string]any) *Client {
+	t.Helper()
+
+	oldGenerator := correlationIDGenerator
+	correlationIDGenerator = func() string { return "test-correlation" }
+	t.Cleanup(func() { correlationIDGenerator = oldGenerator })
+
+	var payload bytes.Buffer
+	writer := bufio.NewWriter(&payload)
+	for _, frame := range frames {
+		if err := writeFrame(writer, frame); err != nil {
+			t.Fatalf("failed to encode frame: %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("failed to flush encoded frames: %v", err)
+	}
+
+	return &Client{
+		conn:              &stubConn{},
+		reader:            bufio.NewReader(bytes.NewReader(payload.Bytes())),
+		writer:            bufio.NewWriter(io.Discard),
+		log:               slog.New(slog.NewTextHandler(io.Discard, nil)),
+		awaitHandshakeAck: false,
+	}
+}
+
+func TestQuerySurfacesBackendErrorPayload(t *testing.T) {
+	client := newTestFrameClient(t, map[string]any{
+		"type":           responseType,
+		"status":         409,
+		"correlation_id": "test-correlation",
+		"body": map[string]any{
+			"code":        "INDEX_MISSING",
+			"message":     "Content index is missing",
+			"remediation": "Run ragadmin reindex",
+		},
+	})
+
+	_, err := client.Query(context.Background(), QueryRequest{Question: "chmod?", TraceID: "trace-err"})
+	var backendErr *BackendError
+	if !errors.As(err, &backendErr) {
+		t.Fatalf("expected *BackendError, got %T (%v)", err, err)
+	}
+	if backendErr.Status != 409 || backendErr.Code != "INDEX_MISSING" || backendErr.TraceID != "trace-err" {
+		t.Fatalf("unexpected backend error fields: %#v", backendErr)
+	}
+	if backendErr.Remediation != "Run ragadmin reindex" {
+		t.Fatalf("expected remediation to be decoded, got %q", backendErr.Remediation)
+	}
+	if !strings.Contains(err.Error(), "Content index is missing") {
+		t.Fatalf("expected backend message in error text, got %q", err.Error())
+	}
+}
+
+func TestClientReusesConnectionForSequentialCalls(t *testing.T) {
+	response := map[string]any{
+		"type":           responseType,
+		"status":         statusOK,
+		"correlation_id": "test-correlation",
+		"body": map[string]any{
+			"summary":    "Use chmod.",
+			"confidence": 0.8,
+			"trace_id":   "trace-reuse",
+		},
+	}
+	client := newTestFrameClient(t, map[string]any{
+		"type":     handshakeAck,
+		"protocol": protocolName,
+		"version":  protocolVersion,
+		"server":   "stub",
+	}, response, response)
+	client.awaitHandshakeAck = true
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query(context.Background(), QueryRequest{Question: "chmod?", TraceID: "trace-reuse"})
+		if err != nil {
+			t.Fatalf("Query() #%d error = %v", i+1, err)
+		}
+		if resp.Summary != "Use chmod." {
+			t.Fatalf("Query() #%d unexpected summary %q", i+1, resp.Summary)
+		}
+		if client.awaitHandshakeAck {
+			t.Fatalf("expected handshake ack to be consumed after query #%d", i+1)
+		}
+	}
+}
+
+func TestClientFailsFastAfterTransportError(t *testing.T) {
+	client := newTestFrameClient(t)
+
+	_, err := client.Query(context.Background(), QueryRequest{Question: "chmod?"})
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("expected EOF from empty stream, got %v", err)
+	}
+
+	_, err = client.Do(context.Background(), "/v1/custom", nil)
+	if err == nil || !strings.Contains(err.Error(), "unusable after earlier failure") || !errors.Is(err, io.EOF) {
+		t.Fatalf("expected sticky transport error wrapping EOF, got %v", err)
+	}
+}
+
+func TestQueryWithMetaReturnsCorrelationAndStatus(t *testing.T) {
+	client := newTestFrameClient(t, map[string]any{
+		"type":           responseType,
+		"status":         statusOK,
+		"correlation_id": "test-correlation",
+		"body": map[string]any{
+			"summary":    "Use chmod.",
+			"confidence": 0.8,
+			"trace_id":   "backend-trace",
+		},
+	})
+
+	resp, meta, err := client.QueryWithMeta(context.Background(), QueryRequest{Question: "chmod?", TraceID: "cli-trace"})
+	if err != nil {
+		t.Fatalf("QueryWithMeta() error = %v", err)
+	}
+	if resp.Summary != "Use chmod." {
+		t.Fatalf("unexpected summary %q", resp.Summary)
+	}
+	want := RequestMeta{CorrelationID: "test-correlation", TraceID: "backend-trace", Status: statusOK}
+	if meta != want {
+		t.Fatalf("meta = %#v, want %#v", meta, want)
+	}
+}
+
+// flakyWriteConn fails the first write with the configured error after accepting a partial prefix.
+type flakyWriteConn struct {
+	stubConn
+	failWith error
+	failed   bool
+	attempts int
+	written  bytes.Buffer
+}
+
+func (c *flakyWriteConn) Write(p []byte) (int, error) {
+	c.attempts++
+	if !c.failed {
+		c.failed = true
+		n := len(p) / 2
+		c.written.Write(p[:n])
+		return n, c.failWith
+	}
+	return c.written.Write(p)
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestWriteFrameWithRetryResumesAfterTimeout(t *testing.T) {
+	conn := &flakyWriteConn{failWith: timeoutError{}}
+	client := newTestFrameClient(t)
+	client.conn = conn
+	client.retrySchedule = []time.Duration{time.Millisecond}
+
+	frame := map[string]any{"type": requestType, "path": "/v1/query"}
+	if err := client.writeFrameWithRetry(context.Background(), frame); err != nil {
+		t.Fatalf("writeFrameWithRetry() error = %v", err)
+	}
+
+	want, err := encodeFrame(frame, false)
+	if err != nil {
+		t.Fatalf("encodeFrame() error = %v", err)
+	}
+	if !bytes.Equal(conn.written.Bytes(), want) {
+		t.Fatalf("expected exactly one intact frame on the wire, got %q", conn.written.String())
+	}
+}
+
+func TestWriteFrameWithRetryFailsFastOnClosedConn(t *testing.T) {
+	conn := &flakyWriteConn{failWith: net.ErrClosed}
+	client := newTestFrameClient(t)
+	client.conn = conn
+	client.retrySchedule = []time.Duration{time.Millisecond}
+
+	err := client.writeFrameWithRetry(context.Background(), map[string]any{"type": requestType})
+	if !errors.Is(err, net.ErrClosed) {
+		t.Fatalf("expected net.ErrClosed, got %v", err)
+	}
+	if conn.attempts != 1 {
+		t.Fatalf("expected a single write attempt, got %d", conn.attempts)
+	}
+}
+
+func TestQueryRejectsOversizedContextBudget(t *testing.T) {
+	client := newTestFrameClient(t)
+
+	_, err := client.Query(context.Background(), QueryRequest{Question: "chmod?", MaxContextTokens: MaxContextTokensLimit * 2})
+	if !errors.Is(err, ErrInvalidQueryRequest) {
+		t.Fatalf("expected ErrInvalidQueryRequest before any transport call, got %v", err)
+	}
+}
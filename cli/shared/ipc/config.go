@@ -2,14 +2,23 @@
 package ipc
 
 import (
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 )
 
 // Transport constants used by the shared IPC client and helpers.
 const (
-	protocolName    = "rag-cli-ipc"
+	protocolName = "rag-cli-ipc"
+	// protocolVersion is the oldest supported version; it is also sent in the legacy
+	// single-version handshake field so servers that predate negotiation keep working.
 	protocolVersion = 1
+	// maxProtocolVersion is the newest version the client advertises. Version 2 introduces
+	// gzip-compressed frames; a server that settles on version 1 only ever sees plaintext.
+	maxProtocolVersion = 2
+	// gzipProtocolVersion is the first version in which gzip frames may be sent.
+	gzipProtocolVersion = 2
 
 	requestType   = "request"
 	responseType  = "response"
@@ -19,11 +28,24 @@ const (
 
 	defaultClientID         = "ipc-client"
 	defaultDialTimout       = 2 * time.Second
+	defaultHandshakeTimeout = 2 * time.Second
 	defaultMaxContextTokens = 4096
 
-	maxFrameSize = 16 << 20 // 16 MiB guardrail for transport frames.
+	defaultMaxFrameSize  = 16 << 20  // 16 MiB guardrail for transport frames.
+	maxFrameSizeCeiling  = 256 << 20 // Absolute cap for Config.MaxFrameSize.
+	compressionThreshold = 8 << 10   // Frames above 8 KiB are gzip-compressed once v2 and gzip are negotiated.
 )
 
+// Capabilities advertised during the handshake. Optional features are gated on the
+// server listing the matching capability; gzip additionally requires protocol version 2.
+const (
+	CapabilityQueryStream = "query_stream"
+	CapabilityGzip        = "gzip"
+)
+
+// clientCapabilities lists the optional features this client understands.
+var clientCapabilities = []string{CapabilityQueryStream, CapabilityGzip}
+
 // defaultRetrySchedule defines the progressive delays between frame read retries.
 var defaultRetrySchedule = []time.Duration{
 	250 * time.Millisecond,
@@ -31,11 +53,49 @@ var defaultRetrySchedule = []time.Duration{
 	1 * time.Second,
 }
 
+// ParseRetrySchedule parses a comma-separated list of durations (e.g. "250ms,500ms,1s") into a
+// retry schedule. An empty value yields nil so the client falls back to the default schedule;
+// malformed or non-positive entries are rejected.
+func ParseRetrySchedule(value string) ([]time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	schedule := make([]time.Duration, 0, len(parts))
+	for _, part := range parts {
+		entry := strings.TrimSpace(part)
+		delay, err := time.ParseDuration(entry)
+		if err != nil {
+			return nil, fmt.Errorf("ipc: invalid retry delay %q: %w", entry, err)
+		}
+		if delay <= 0 {
+			return nil, fmt.Errorf("ipc: retry delay %q must be positive", entry)
+		}
+		schedule = append(schedule, delay)
+	}
+	return schedule, nil
+}
+
 // Config describes how to construct a new IPC client.
 type Config struct {
-	SocketPath    string
-	ClientID      string
-	DialTimeout   time.Duration
-	Logger        *slog.Logger
-	RetrySchedule []time.Duration
+	SocketPath  string
+	ClientID    string
+	DialTimeout time.Duration
+	// HandshakeTimeout bounds the wait for the server's handshake acknowledgement, which is read
+	// with the first request. Zero selects the 2s default.
+	HandshakeTimeout time.Duration
+	Logger           *slog.Logger
+	RetrySchedule    []time.Duration
+	// MaxFrameSize bounds the size of a single decoded frame in bytes. Zero selects the
+	// 16 MiB default; larger values are capped at 256 MiB.
+	MaxFrameSize int
+	// EagerHandshake reads and validates the handshake acknowledgement while connecting, so a
+	// mismatched or silent server fails NewClient before any request is sent. By default the
+	// acknowledgement is read lazily with the first request.
+	EagerHandshake bool
+	// AutoReconnect re-dials the socket and retries the in-flight request once when the
+	// connection turns out to be closed (for example after a backend restart). It also
+	// re-dials before the next request once a failure such as a timeout left the
+	// connection out of sync.
+	AutoReconnect bool
 }
@@ -0,0 +1,28 @@
+package ipc
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseRetrySchedule(t *testing.T) {
+	schedule, err := ParseRetrySchedule(" 250ms, 500ms ,1s")
+	if err != nil {
+		t.Fatalf("ParseRetrySchedule() error = %v", err)
+	}
+	want := []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second}
+	if !slices.Equal(schedule, want) {
+		t.Fatalf("ParseRetrySchedule() = %v, want %v", schedule, want)
+	}
+
+	if schedule, err := ParseRetrySchedule("  "); err != nil || schedule != nil {
+		t.Fatalf("ParseRetrySchedule(blank) = %v, %v; want nil, nil", schedule, err)
+	}
+
+	for _, invalid := range []string{"250ms,,1s", "fast", "0s", "-1s", "250"} {
+		if _, err := ParseRetrySchedule(invalid); err == nil {
+			t.Fatalf("expected ParseRetrySchedule(%q) to fail", invalid)
+		}
+	}
+}
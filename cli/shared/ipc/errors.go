@@ -0,0 +1,63 @@
+package ipc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BackendError describes a non-success response returned by the backend, including
+// the decoded error payload so callers can surface the backend's reason to users.
+type BackendError struct {
+	Operation   string
+	Status      int
+	Code        string
+	Message     string
+	Remediation string
+	TraceID     string
+}
+
+// Error formats the status together with any backend-provided message and code.
+func (e *BackendError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ipc: %s unexpected status %d", e.Operation, e.Status)
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	return b.String()
+}
+
+// backendErrorPayload mirrors the ErrorResponse schema emitted by the transport layer.
+type backendErrorPayload struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation"`
+	TraceID     string `json:"trace_id"`
+}
+
+// expectStatus returns a *BackendError when the frame status differs from want.
+// The request trace ID is used when the error body does not carry its own.
+func expectStatus(operation string, frame ResponseFrame, want int, traceID string) error {
+	if frame.Status == want {
+		return nil
+	}
+
+	backendErr := &BackendError{
+		Operation: operation,
+		Status:    frame.Status,
+		TraceID:   traceID,
+	}
+	var payload backendErrorPayload
+	if len(frame.Body) > 0 && json.Unmarshal(frame.Body, &payload) == nil {
+		backendErr.Code = strings.TrimSpace(payload.Code)
+		backendErr.Message = strings.TrimSpace(payload.Message)
+		backendErr.Remediation = strings.TrimSpace(payload.Remediation)
+		if trimmed := strings.TrimSpace(payload.TraceID); trimmed != "" {
+			backendErr.TraceID = trimmed
+		}
+	}
+	return backendErr
+}
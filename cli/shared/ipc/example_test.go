@@ -0,0 +1,41 @@
+package ipc_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+// A single client can serve several sequential calls, so scripted workflows pay the
+// dial and handshake cost once instead of once per request.
+func ExampleClient_sequentialCalls() {
+	client, err := ipc.NewClient(ipc.Config{
+		SocketPath: "/run/user/1000/ragcli/backend.sock",
+		ClientID:   "scripted-admin",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	catalog, err := client.ListSources(ctx, ipc.SourceListRequest{TraceID: "example-list"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, source := range catalog.Sources {
+		resp, err := client.Query(ctx, ipc.QueryRequest{
+			Question:    fmt.Sprintf("What does the %s source cover?", source.Alias),
+			SourceTypes: []string{source.Type},
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(source.Alias, resp.Summary)
+	}
+}
@@ -3,10 +3,13 @@ package ipc
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -16,18 +19,22 @@ import (
 
 // handshakeFrame encodes the client handshake payload.
 type handshakeFrame struct {
-	Type     string `json:"type"`
-	Protocol string `json:"protocol"`
-	Version  int    `json:"version"`
-	Client   string `json:"client"`
+	Type         string   `json:"type"`
+	Protocol     string   `json:"protocol"`
+	Version      int      `json:"version"`
+	Client       string   `json:"client"`
+	Versions     []int    `json:"versions,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
 }
 
 // handshakeAckFrame encodes the server acknowledgement payload.
+// Servers that predate capability negotiation omit the capabilities list.
 type handshakeAckFrame struct {
-	Type     string `json:"type"`
-	Protocol string `json:"protocol"`
-	Version  int    `json:"version"`
-	Server   string `json:"server"`
+	Type         string   `json:"type"`
+	Protocol     string   `json:"protocol"`
+	Version      int      `json:"version"`
+	Server       string   `json:"server"`
+	Capabilities []string `json:"capabilities,omitempty"`
 }
 
 // requestFrame represents a newline-delimited JSON request envelope.
@@ -35,58 +42,155 @@ type requestFrame struct {
 	Type          string `json:"type"`
 	Path          string `json:"path"`
 	CorrelationID string `json:"correlation_id"`
-	Body          any    `json:"body"`
+	// Deadline is the RFC3339 instant after which the client stops waiting, so a cooperating
+	// backend can abandon the work. It is omitted when the request has no deadline.
+	Deadline string `json:"deadline,omitempty"`
+	Body     any    `json:"body"`
 }
 
-// responseFrame represents a newline-delimited JSON response envelope.
-type responseFrame struct {
+// ResponseFrame represents a newline-delimited JSON response envelope returned by the backend.
+type ResponseFrame struct {
 	Type          string          `json:"type"`
 	Status        int             `json:"status"`
 	CorrelationID string          `json:"correlation_id"`
 	Body          json.RawMessage `json:"body"`
 }
 
-// writeFrame marshals and emits a length-prefixed JSON frame.
+// gzipFramePrefix marks a length line whose payload is gzip-compressed JSON.
+// Plaintext length lines always start with a digit, so the two encodings cannot collide.
+const gzipFramePrefix = 'z'
+
+// writeFrame marshals and emits a plaintext length-prefixed JSON frame.
 func writeFrame(writer *bufio.Writer, payload any) error {
-	bytes, err := json.Marshal(payload)
+	data, err := encodeFrame(payload, false)
 	if err != nil {
 		return err
 	}
-
-	if _, err := fmt.Fprintf(writer, "%d\n", len(bytes)); err != nil {
+	if _, err := writer.Write(data); err != nil {
 		return err
 	}
-	if _, err := writer.Write(bytes); err != nil {
-		return err
+	return writer.Flush()
+}
+
+// encodeFrame renders the wire form of a frame. When compress is set (protocol version 2 and
+// the gzip capability were negotiated) and the payload exceeds compressionThreshold, the frame is gzip-compressed and its
+// length line carries gzipFramePrefix.
+func encodeFrame(payload any, compress bool) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
 	}
-	if err := writer.WriteByte('\n'); err != nil {
-		return err
+
+	prefix := ""
+	if compress && len(data) > compressionThreshold {
+		compressed, err := gzipPayload(data)
+		if err != nil {
+			return nil, err
+		}
+		data = compressed
+		prefix = string(gzipFramePrefix)
 	}
-	return writer.Flush()
+
+	var buf bytes.Buffer
+	buf.Grow(len(data) + 16)
+	fmt.Fprintf(&buf, "%s%d\n", prefix, len(data))
+	buf.Write(data)
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func gzipPayload(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(payload); err != nil {
+		return nil, fmt.Errorf("compress frame: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress frame: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// gunzipPayload inflates a compressed frame, enforcing maxSize on the decoded size.
+func gunzipPayload(payload []byte, maxSize int) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("decompress frame: %w", err)
+	}
+	defer zr.Close()
+
+	decoded, err := io.ReadAll(io.LimitReader(zr, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompress frame: %w", err)
+	}
+	if len(decoded) > maxSize {
+		return nil, fmt.Errorf("decompress frame: exceeds max frame size")
+	}
+	return decoded, nil
 }
 
-// readFrame reads and validates a length-prefixed JSON frame.
-func readFrame(ctx context.Context, reader *bufio.Reader, conn net.Conn) ([]byte, error) {
+// readFrame reads and validates a length-prefixed JSON frame no larger than maxSize bytes.
+// The read honours the context deadline and returns ctx.Err() promptly when ctx is cancelled.
+func readFrame(ctx context.Context, reader *bufio.Reader, conn net.Conn, maxSize int) ([]byte, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	var cancel context.CancelFunc
-	if deadline, ok := ctx.Deadline(); ok {
+	release, err := bindReadDeadline(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	data, err := readFramePayload(reader, maxSize)
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return nil, ctx.Err()
+	}
+	return data, err
+}
+
+// bindReadDeadline applies the context deadline to conn and expires the read deadline as
+// soon as ctx is done, since cancellation alone cannot interrupt a blocked read. The
+// returned function stops the watcher and clears any deadline it left behind.
+func bindReadDeadline(ctx context.Context, conn net.Conn) (func(), error) {
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
 		if err := conn.SetReadDeadline(deadline); err != nil {
 			return nil, err
 		}
-		cancel = func() { _ = conn.SetReadDeadline(time.Time{}) }
-	} else {
-		cancel = func() {}
 	}
-	defer cancel()
+	if ctx.Done() == nil {
+		return func() {}, nil
+	}
+
+	expired := make(chan struct{})
+	stopWatch := context.AfterFunc(ctx, func() {
+		_ = conn.SetReadDeadline(time.Now())
+		close(expired)
+	})
+	return func() {
+		fired := !stopWatch()
+		if fired {
+			<-expired
+		}
+		if hasDeadline || fired {
+			_ = conn.SetReadDeadline(time.Time{})
+		}
+	}, nil
+}
 
+// readFramePayload decodes one frame from reader without any deadline handling.
+func readFramePayload(reader *bufio.Reader, maxSize int) ([]byte, error) {
 	lengthLine, err := reader.ReadString('\n')
 	if err != nil {
 		return nil, err
 	}
 
+	compressed := strings.HasPrefix(lengthLine, string(gzipFramePrefix))
+	if compressed {
+		lengthLine = lengthLine[1:]
+	}
+
 	var payloadLength int
 	if _, err := fmt.Sscanf(lengthLine, "%d\n", &payloadLength); err != nil {
 		return nil, fmt.Errorf("invalid length prefix %q: %w", strings.TrimSpace(lengthLine), err)
@@ -94,7 +198,7 @@ func readFrame(ctx context.Context, reader *bufio.Reader, conn net.Conn) ([]byte
 	if payloadLength < 0 {
 		return nil, fmt.Errorf("invalid length prefix %d: negative length", payloadLength)
 	}
-	if payloadLength > maxFrameSize {
+	if payloadLength > maxSize {
 		return nil, fmt.Errorf("invalid length prefix %d: exceeds max frame size", payloadLength)
 	}
 
@@ -111,6 +215,9 @@ func readFrame(ctx context.Context, reader *bufio.Reader, conn net.Conn) ([]byte
 		return nil, fmt.Errorf("expected newline terminator, got %q", term)
 	}
 
+	if compressed {
+		return gunzipPayload(payload, maxSize)
+	}
 	return payload, nil
 }
 
@@ -131,3 +238,26 @@ func newCorrelationID() string {
 func NewTraceID() string {
 	return newCorrelationID()
 }
+
+// maxTraceIDLength bounds externally supplied trace identifiers.
+const maxTraceIDLength = 128
+
+// ValidateTraceID checks that an externally supplied trace identifier is safe to propagate:
+// at most 128 characters of letters, digits, '.', '_', ':' or '-', starting with a letter or digit.
+func ValidateTraceID(traceID string) error {
+	if traceID == "" {
+		return fmt.Errorf("ipc: trace id must not be empty")
+	}
+	if len(traceID) > maxTraceIDLength {
+		return fmt.Errorf("ipc: trace id exceeds %d characters", maxTraceIDLength)
+	}
+	for idx, r := range traceID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case idx > 0 && (r == '.' || r == '_' || r == ':' || r == '-'):
+		default:
+			return fmt.Errorf("ipc: trace id %q contains unsupported character %q", traceID, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package ipc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeFrameKeepsSmallFramesPlaintext(t *testing.T) {
+	payload := map[string]any{"summary": "short answer"}
+
+	encoded := encodeTestFrame(t, payload, true)
+	if strings.HasPrefix(encoded, string(gzipFramePrefix)) {
+		t.Fatalf("expected small frame to stay uncompressed, got %q", encoded)
+	}
+
+	decoded := decodeTestFrame(t, encoded)
+	if decoded["summary"] != "short answer" {
+		t.Fatalf("unexpected round-trip payload: %v", decoded)
+	}
+}
+
+func TestEncodeFrameCompressesLargeFrames(t *testing.T) {
+	summary := strings.Repeat("kiwix article text ", compressionThreshold/8)
+	payload := map[string]any{"summary": summary}
+
+	encoded := encodeTestFrame(t, payload, true)
+	if !strings.HasPrefix(encoded, string(gzipFramePrefix)) {
+		t.Fatalf("expected large frame to carry the gzip prefix")
+	}
+	if len(encoded) >= len(summary) {
+		t.Fatalf("expected compressed frame to be smaller than payload (%d >= %d)", len(encoded), len(summary))
+	}
+
+	decoded := decodeTestFrame(t, encoded)
+	if decoded["summary"] != summary {
+		t.Fatal("expected compressed frame to round-trip unchanged")
+	}
+}
+
+func TestEncodeFrameSkipsCompressionWhenNotNegotiated(t *testing.T) {
+	payload := map[string]any{"summary": strings.Repeat("x", compressionThreshold*2)}
+
+	encoded := encodeTestFrame(t, payload, false)
+	if strings.HasPrefix(encoded, string(gzipFramePrefix)) {
+		t.Fatal("expected plaintext frame when gzip was not negotiated")
+	}
+}
+
+func encodeTestFrame(t *testing.T, payload any, compress bool) string {
+	t.Helper()
+
+	data, err := encodeFrame(payload, compress)
+	if err != nil {
+		t.Fatalf("encodeFrame() error = %v", err)
+	}
+	return string(data)
+}
+
+func decodeTestFrame(t *testing.T, encoded string) map[string]any {
+	t.Helper()
+
+	data, err := readFrame(context.Background(), bufio.NewReader(strings.NewReader(encoded)), &stubConn{}, defaultMaxFrameSize)
+	if err != nil {
+		t.Fatalf("readFrame() error = %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decode frame payload: %v", err)
+	}
+	return decoded
+}
+
+func TestClientEnforcesConfiguredMaxFrameSize(t *testing.T) {
+	const limit = 64
+	frame := func(size int) string {
+		return fmt.Sprintf("%d\n%s\n", size, strings.Repeat(" ", size))
+	}
+
+	for _, tc := range []struct {
+		name    string
+		size    int
+		wantErr bool
+	}{
+		{name: "just under", size: limit - 1},
+		{name: "at limit", size: limit},
+		{name: "just over", size: limit + 1, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestFrameClient(t)
+			client.maxFrameSize = limit
+			client.reader = bufio.NewReader(strings.NewReader(frame(tc.size)))
+
+			_, err := client.readFrameWithRetry(context.Background())
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "exceeds max frame size") {
+					t.Fatalf("expected max frame size error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readFrameWithRetry() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNormalizeMaxFrameSize(t *testing.T) {
+	if size, err := normalizeMaxFrameSize(0); err != nil || size != defaultMaxFrameSize {
+		t.Fatalf("normalizeMaxFrameSize(0) = %d, %v", size, err)
+	}
+	if size, err := normalizeMaxFrameSize(1 << 30); err != nil || size != maxFrameSizeCeiling {
+		t.Fatalf("normalizeMaxFrameSize(1 GiB) = %d, %v; want ceiling", size, err)
+	}
+	if _, err := normalizeMaxFrameSize(-1); err == nil {
+		t.Fatal("expected negative max frame size to be rejected")
+	}
+}
+
+func TestValidateTraceID(t *testing.T) {
+	for _, valid := range []string{"abc", "ext-trace-123", "req_1.2:3", strings.Repeat("a", maxTraceIDLength)} {
+		if err := ValidateTraceID(valid); err != nil {
+			t.Fatalf("ValidateTraceID(%q) error = %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "-leading", "has space", "semi;colon", strings.Repeat("a", maxTraceIDLength+1)} {
+		if err := ValidateTraceID(invalid); err == nil {
+			t.Fatalf("expected ValidateTraceID(%q) to fail", invalid)
+		}
+	}
+}
+
+func TestReadFrameReturnsPromptlyWhenCancelledMidRead(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	client := newTestFrameClient(t)
+	client.conn = clientConn
+	client.reader = bufio.NewReader(clientConn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	started := time.Now()
+	_, err := client.readFrameWithRetry(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("read took %s to observe cancellation", elapsed)
+	}
+
+	// The expired deadline must not leak into the next read on the same connection.
+	go func() {
+		writer := bufio.NewWriter(serverConn)
+		_ = writeFrame(writer, map[string]any{"type": responseType})
+		_ = writer.Flush()
+	}()
+	if _, err := client.readFrameWithRetry(context.Background()); err != nil {
+		t.Fatalf("read after cancellation failed: %v", err)
+	}
+}
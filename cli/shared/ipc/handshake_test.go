@@ -0,0 +1,144 @@
+package ipc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConsumeHandshakeAckStoresServerCapabilities(t *testing.T) {
+	client := newTestFrameClient(t, map[string]any{
+		"type":         handshakeAck,
+		"protocol":     protocolName,
+		"version":      protocolVersion,
+		"server":       "stub",
+		"capabilities": []string{"query_stream", " Compression ", "query_stream"},
+	})
+	client.awaitHandshakeAck = true
+
+	if err := client.consumeHandshakeAck(context.Background()); err != nil {
+		t.Fatalf("consumeHandshakeAck() error = %v", err)
+	}
+	if got, want := client.ServerCapabilities(), []string{"query_stream", "compression"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ServerCapabilities() = %v, want %v", got, want)
+	}
+}
+
+func TestConsumeHandshakeAckWithoutCapabilities(t *testing.T) {
+	client := newTestFrameClient(t, map[string]any{
+		"type":     handshakeAck,
+		"protocol": protocolName,
+		"version":  protocolVersion,
+		"server":   "legacy",
+	})
+	client.awaitHandshakeAck = true
+
+	if err := client.consumeHandshakeAck(context.Background()); err != nil {
+		t.Fatalf("consumeHandshakeAck() error = %v", err)
+	}
+	if caps := client.ServerCapabilities(); len(caps) != 0 {
+		t.Fatalf("expected no capabilities from legacy server, got %v", caps)
+	}
+}
+
+func TestSendHandshakeAdvertisesVersionsAndCapabilities(t *testing.T) {
+	var buf bytes.Buffer
+	client := &Client{
+		writer:   bufio.NewWriter(&buf),
+		log:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		clientID: "test-client",
+	}
+	if err := client.sendHandshake(); err != nil {
+		t.Fatalf("sendHandshake() error = %v", err)
+	}
+
+	_, payload, _ := strings.Cut(buf.String(), "\n")
+	var frame handshakeFrame
+	if err := json.Unmarshal([]byte(strings.TrimSpace(payload)), &frame); err != nil {
+		t.Fatalf("decode handshake: %v", err)
+	}
+	if !reflect.DeepEqual(frame.Capabilities, clientCapabilities) {
+		t.Fatalf("handshake capabilities = %v, want %v", frame.Capabilities, clientCapabilities)
+	}
+	if frame.Version != protocolVersion || !reflect.DeepEqual(frame.Versions, []int{1, 2}) {
+		t.Fatalf("handshake versions = %d / %v, want %d / [1 2]", frame.Version, frame.Versions, protocolVersion)
+	}
+}
+
+func TestConsumeHandshakeAckGatesGzipOnVersion(t *testing.T) {
+	cases := []struct {
+		name         string
+		version      int
+		capabilities []string
+		wantCompress bool
+	}{
+		{name: "v2 with gzip", version: 2, capabilities: []string{CapabilityGzip}, wantCompress: true},
+		{name: "downgrade to v1 keeps plaintext", version: 1, capabilities: []string{CapabilityGzip}},
+		{name: "v2 without gzip", version: 2},
+	}
+	for _, tc := range cases {
+		client := newTestFrameClient(t, map[string]any{
+			"type":         handshakeAck,
+			"protocol":     protocolName,
+			"version":      tc.version,
+			"server":       "stub",
+			"capabilities": tc.capabilities,
+		})
+		client.awaitHandshakeAck = true
+
+		if err := client.consumeHandshakeAck(context.Background()); err != nil {
+			t.Fatalf("%s: consumeHandshakeAck() error = %v", tc.name, err)
+		}
+		if client.ProtocolVersion() != tc.version {
+			t.Fatalf("%s: ProtocolVersion() = %d, want %d", tc.name, client.ProtocolVersion(), tc.version)
+		}
+		if client.compressFrames != tc.wantCompress {
+			t.Fatalf("%s: compressFrames = %v, want %v", tc.name, client.compressFrames, tc.wantCompress)
+		}
+	}
+}
+
+func TestConsumeHandshakeAckNegotiatesVersionInRange(t *testing.T) {
+	for _, version := range supportedProtocolVersions() {
+		client := newTestFrameClient(t, map[string]any{
+			"type":     handshakeAck,
+			"protocol": protocolName,
+			"version":  version,
+			"server":   "stub",
+		})
+		client.awaitHandshakeAck = true
+
+		if err := client.consumeHandshakeAck(context.Background()); err != nil {
+			t.Fatalf("consumeHandshakeAck() version %d error = %v", version, err)
+		}
+		if got := client.ProtocolVersion(); got != version {
+			t.Fatalf("ProtocolVersion() = %d, want %d", got, version)
+		}
+	}
+}
+
+func TestConsumeHandshakeAckRejectsOutOfRangeVersion(t *testing.T) {
+	for _, version := range []int{0, maxProtocolVersion + 1} {
+		client := newTestFrameClient(t, map[string]any{
+			"type":     handshakeAck,
+			"protocol": protocolName,
+			"version":  version,
+			"server":   "stub",
+		})
+		client.awaitHandshakeAck = true
+
+		err := client.consumeHandshakeAck(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "unsupported") {
+			t.Fatalf("expected version %d to be rejected, got %v", version, err)
+		}
+		if client.ProtocolVersion() != 0 {
+			t.Fatalf("expected no negotiated version after rejection, got %d", client.ProtocolVersion())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package ipc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+)
+
+// GuardedDialer wraps net.Dialer so outbound TCP/UDP connections can only reach loopback hosts.
+// It is independent of the HTTP guard; callers may install either or both.
+type GuardedDialer struct {
+	net.Dialer
+
+	log *slog.Logger
+}
+
+// NewGuardedDialer returns a dialer that rejects non-loopback TCP and UDP dials with ErrExternalNetworkBlocked.
+// Unix domain sockets are passed through unchanged so the backend socket stays reachable.
+func NewGuardedDialer() *GuardedDialer {
+	return &GuardedDialer{log: slog.Default()}
+}
+
+// Dial connects to the address on the named network when the destination is local.
+func (d *GuardedDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialContext connects to the address on the named network using the provided context when the
+// destination is local.
+func (d *GuardedDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if err := d.check(network, address); err != nil {
+		return nil, err
+	}
+	return d.Dialer.DialContext(ctx, network, address)
+}
+
+// check reports ErrExternalNetworkBlocked when the dial would leave the local machine.
+func (d *GuardedDialer) check(network, address string) error {
+	switch strings.ToLower(network) {
+	case "unix", "unixgram", "unixpacket":
+		return nil
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return fmt.Errorf("%w: unsupported network %q", ErrExternalNetworkBlocked, network)
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("ipc: guarded dial %s %q: %w", network, address, err)
+	}
+	if !isRemoteHost(host, hostAllowlist{}) {
+		return nil
+	}
+	if d.log != nil {
+		d.log.Warn(
+			"OfflineGuard blocked outbound dial",
+			slog.String("network", network),
+			slog.String("address", address),
+		)
+	}
+	return ErrExternalNetworkBlocked
+}
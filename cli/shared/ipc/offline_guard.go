@@ -4,62 +4,123 @@ package ipc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"net/netip"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // ErrExternalNetworkBlocked is returned when the offline guard prevents an outbound HTTP call.
 var ErrExternalNetworkBlocked = errors.New("ipc: external network access blocked")
 
-// offline guard state is guarded by a global mutex to support nested installs.
+// offline guard state is guarded by a global mutex to support nested installs. Each active install
+// keeps its own allowlist entry; the guarded transport enforces their union.
 var (
 	offlineGuardMu                sync.Mutex
-	offlineGuardInstallCount      int
+	offlineGuardInstalls          []*hostAllowlist
+	offlineGuardTransport         *offlineTransport
 	offlineGuardOriginalTransport http.RoundTripper
 )
 
+// offline guard counters record every request the guarded transport has decided on since process start.
+var (
+	offlineGuardBlocked atomic.Uint64
+	offlineGuardAllowed atomic.Uint64
+)
+
+// OfflineGuardStats reports how many HTTP requests the offline guard has blocked and allowed since the
+// process started. The counters are cumulative across installs and safe to read concurrently.
+func OfflineGuardStats() (blocked, allowed uint64) {
+	return offlineGuardBlocked.Load(), offlineGuardAllowed.Load()
+}
+
 // offlineTransport wraps the base transport to enforce loopback-only requests.
 type offlineTransport struct {
 	base http.RoundTripper
 	log  *slog.Logger
+	// allow is the union of the allowlists of every active install, swapped as installs come and go.
+	allow atomic.Pointer[hostAllowlist]
+}
+
+// hostAllowlist lists non-loopback destinations the offline guard lets through.
+type hostAllowlist struct {
+	hosts    map[string]struct{}
+	networks []*net.IPNet
 }
 
 // InstallOfflineHTTPGuard wraps the default HTTP transport to block outbound requests to non-loopback hosts.
 // The returned restore function must be invoked to revert to the original transport once offline enforcement is no longer required.
-// InstallOfflineHTTPGuard swaps the default HTTP transport with an offline-enforcing wrapper.
+// It is the loopback-only shortcut for InstallOfflineHTTPGuardWithAllowlist.
 func InstallOfflineHTTPGuard() func() {
+	return installOfflineGuard(hostAllowlist{})
+}
+
+// InstallOfflineHTTPGuardWithAllowlist behaves like InstallOfflineHTTPGuard but also permits requests to
+// the given hosts. Entries are exact host names or IPs (matched case-insensitively) or CIDR ranges such as
+// 10.0.0.0/24; everything else outside loopback stays blocked. When guards are nested, the union of the
+// active allowlists is enforced, and restoring an install withdraws only the entries it added.
+func InstallOfflineHTTPGuardWithAllowlist(hosts []string) (func(), error) {
+	allow, err := parseHostAllowlist(hosts)
+	if err != nil {
+		return nil, err
+	}
+	return installOfflineGuard(allow), nil
+}
+
+func installOfflineGuard(allow hostAllowlist) func() {
 	offlineGuardMu.Lock()
 	defer offlineGuardMu.Unlock()
 
-	if offlineGuardInstallCount == 0 {
+	if len(offlineGuardInstalls) == 0 {
 		offlineGuardOriginalTransport = http.DefaultTransport
 		logger := slog.Default()
 		if logger == nil {
 			logger = slog.New(slogdiscardHandler{})
 		}
-		http.DefaultTransport = &offlineTransport{
+		offlineGuardTransport = &offlineTransport{
 			base: offlineGuardOriginalTransport,
 			log:  logger.With(slog.String("component", "ipc.offline_guard")),
 		}
+		http.DefaultTransport = offlineGuardTransport
 	}
-	offlineGuardInstallCount++
+	install := &allow
+	offlineGuardInstalls = append(offlineGuardInstalls, install)
+	offlineGuardTransport.allow.Store(mergeHostAllowlists(offlineGuardInstalls))
 
 	return func() {
 		offlineGuardMu.Lock()
 		defer offlineGuardMu.Unlock()
 
-		if offlineGuardInstallCount == 0 {
+		idx := slices.Index(offlineGuardInstalls, install)
+		if idx < 0 {
+			return
+		}
+		offlineGuardInstalls = slices.Delete(offlineGuardInstalls, idx, idx+1)
+		if len(offlineGuardInstalls) > 0 {
+			offlineGuardTransport.allow.Store(mergeHostAllowlists(offlineGuardInstalls))
 			return
 		}
-		offlineGuardInstallCount--
-		if offlineGuardInstallCount == 0 && offlineGuardOriginalTransport != nil {
-			http.DefaultTransport = offlineGuardOriginalTransport
-			offlineGuardOriginalTransport = nil
+		http.DefaultTransport = offlineGuardOriginalTransport
+		offlineGuardOriginalTransport = nil
+		offlineGuardTransport = nil
+	}
+}
+
+// mergeHostAllowlists returns the union of the given allowlists.
+func mergeHostAllowlists(lists []*hostAllowlist) *hostAllowlist {
+	merged := &hostAllowlist{hosts: make(map[string]struct{})}
+	for _, list := range lists {
+		for host := range list.hosts {
+			merged.hosts[host] = struct{}{}
 		}
+		merged.networks = append(merged.networks, list.networks...)
 	}
+	return merged
 }
 
 // RoundTrip enforces loopback-only HTTP requests for the wrapped transport.
@@ -68,8 +129,13 @@ func (t *offlineTransport) RoundTrip(req *http.Request) (*http.Response, error)
 		return t.base.RoundTrip(req)
 	}
 
+	allow := hostAllowlist{}
+	if current := t.allow.Load(); current != nil {
+		allow = *current
+	}
 	host := req.URL.Hostname()
-	if isRemoteHost(host) {
+	if isRemoteHost(host, allow) {
+		offlineGuardBlocked.Add(1)
 		if t.log != nil {
 			t.log.Warn(
 				"OfflineGuard blocked outbound HTTP request",
@@ -80,26 +146,76 @@ func (t *offlineTransport) RoundTrip(req *http.Request) (*http.Response, error)
 		return nil, ErrExternalNetworkBlocked
 	}
 
+	offlineGuardAllowed.Add(1)
 	return t.base.RoundTrip(req)
 }
 
-// isRemoteHost reports whether the host lies outside the loopback range.
-func isRemoteHost(host string) bool {
+// isRemoteHost reports whether the host lies outside the local machine and is not allowlisted.
+// IP literals are classified explicitly: loopback (127.0.0.0/8, ::1, IPv4-mapped loopback) and the
+// unspecified address (0.0.0.0, ::), which dials the local host, are local. Link-local addresses
+// (169.254.0.0/16, fe80::/10), with or without an IPv6 zone such as %eth0, reach other machines on
+// the link and are remote.
+func isRemoteHost(host string, allow hostAllowlist) bool {
 	if host == "" {
 		return false
 	}
 
-	lowered := strings.ToLower(host)
+	lowered := strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"))
 	if lowered == "localhost" {
 		return false
 	}
+	if _, ok := allow.hosts[lowered]; ok {
+		return false
+	}
 
-	ip := net.ParseIP(host)
-	if ip == nil {
+	addr, err := netip.ParseAddr(lowered)
+	if err != nil {
 		return true
 	}
+	addr = addr.WithZone("").Unmap()
 
-	return !ip.IsLoopback()
+	if addr.IsLoopback() || addr.IsUnspecified() {
+		return false
+	}
+	return !allow.containsAddr(addr)
+}
+
+// containsAddr reports whether the address matches an allowlisted IP or range.
+func (a hostAllowlist) containsAddr(addr netip.Addr) bool {
+	if _, ok := a.hosts[addr.String()]; ok {
+		return true
+	}
+	ip := net.IP(addr.AsSlice())
+	for _, network := range a.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHostAllowlist splits entries into exact hosts and CIDR ranges, rejecting malformed ranges.
+func parseHostAllowlist(entries []string) (hostAllowlist, error) {
+	allow := hostAllowlist{hosts: make(map[string]struct{}, len(entries))}
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			_, network, err := net.ParseCIDR(entry)
+			if err != nil {
+				return hostAllowlist{}, fmt.Errorf("ipc: invalid offline allowlist range %q: %w", entry, err)
+			}
+			allow.networks = append(allow.networks, network)
+			continue
+		}
+		if ip := net.ParseIP(entry); ip != nil {
+			entry = ip.String()
+		}
+		allow.hosts[entry] = struct{}{}
+	}
+	return allow, nil
 }
 
 // slogdiscardHandler is a no-op handler used when slog lacks a configured logger.
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math"
 	"strings"
 )
 
@@ -13,12 +15,26 @@ var ErrInvalidQueryRequest = errors.New("ipc: invalid query request")
 // ErrInvalidQueryResponse indicates that a decoder received malformed payload data.
 var ErrInvalidQueryResponse = errors.New("ipc: invalid query response payload")
 
+// MaxContextTokensLimit is the largest context budget a query may request; zero selects the default.
+const MaxContextTokensLimit = 32768
+
 // QueryRequest mirrors the backend contract for issuing query operations.
 type QueryRequest struct {
-	Question         string `json:"question"`
-	ConversationID   string `json:"conversation_id,omitempty"`
-	MaxContextTokens int    `json:"max_context_tokens"`
-	TraceID          string `json:"trace_id,omitempty"`
+	Question         string      `json:"question"`
+	ConversationID   string      `json:"conversation_id,omitempty"`
+	MaxContextTokens int         `json:"max_context_tokens"`
+	TraceID          string      `json:"trace_id,omitempty"`
+	SourceTypes      []string    `json:"source_types,omitempty"`
+	Language         string      `json:"language,omitempty"`
+	History          []QueryTurn `json:"history,omitempty"`
+	// IndexVersion pins the query to a specific catalog version so past answers can be reproduced.
+	IndexVersion string `json:"index_version,omitempty"`
+}
+
+// QueryTurn is an earlier question/answer exchange replayed to stateless backends.
+type QueryTurn struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer,omitempty"`
 }
 
 // QueryReference captures a single reference entry returned by the backend.
@@ -30,9 +46,10 @@ type QueryReference struct {
 
 // QueryCitation captures inline citation metadata provided by the backend.
 type QueryCitation struct {
-	Alias       string `json:"alias"`
-	DocumentRef string `json:"document_ref"`
-	Excerpt     string `json:"excerpt,omitempty"`
+	Alias       string   `json:"alias"`
+	DocumentRef string   `json:"document_ref"`
+	Excerpt     string   `json:"excerpt,omitempty"`
+	Score       *float64 `json:"score,omitempty"`
 }
 
 // QueryResponse represents the structured answer returned by the backend query endpoint.
@@ -54,6 +71,8 @@ type QueryResponse struct {
 	ConfidenceThreshold  *float64         `json:"confidence_threshold,omitempty"`
 	StaleIndexDetected   bool             `json:"stale_index_detected,omitempty"`
 	BackendCorrelationID string           `json:"backend_correlation_id,omitempty"`
+	Warnings             []string         `json:"warnings,omitempty"`
+	Final                bool             `json:"final,omitempty"`
 }
 
 // QueryRequestInput captures user-provided fields used to build JSON transport requests.
@@ -80,9 +99,9 @@ func BuildQueryRequest(input QueryRequestInput) (RequestEnvelope, error) {
 	conversationID := strings.TrimSpace(input.ConversationID)
 	traceID := strings.TrimSpace(input.TraceID)
 
-	maxTokens := input.MaxContextTokens
-	if maxTokens <= 0 {
-		maxTokens = defaultMaxContextTokens
+	maxTokens, err := resolveMaxContextTokens(input.MaxContextTokens)
+	if err != nil {
+		return RequestEnvelope{}, err
 	}
 
 	request := QueryRequest{
@@ -101,7 +120,14 @@ func BuildQueryRequest(input QueryRequestInput) (RequestEnvelope, error) {
 }
 
 // DecodeQueryResponse converts a raw JSON payload into a structured QueryResponse.
+// Adjustments to out-of-range values are logged through slog.Default.
 func DecodeQueryResponse(payload []byte) (QueryResponse, error) {
+	return decodeQueryResponse(payload, slog.Default())
+}
+
+// decodeQueryResponse is DecodeQueryResponse with an explicit logger, so the client reports
+// clamped values through its own configured logger.
+func decodeQueryResponse(payload []byte, logger *slog.Logger) (QueryResponse, error) {
 	var resp QueryResponse
 	if err := json.Unmarshal(payload, &resp); err != nil {
 		return QueryResponse{}, fmt.Errorf("%w: %v", ErrInvalidQueryResponse, err)
@@ -112,9 +138,103 @@ func DecodeQueryResponse(payload []byte) (QueryResponse, error) {
 	}
 
 	ensureQueryResponseDefaults(&resp)
+	normalizeQueryConfidence(&resp, logger)
+	resp.Warnings = normalizeQueryWarnings(resp.Warnings)
 	return resp, nil
 }
 
+// normalizeQueryWarnings trims backend warnings and drops blank entries. It returns nil when
+// nothing is left so the field stays omitted on re-encoding.
+func normalizeQueryWarnings(warnings []string) []string {
+	var cleaned []string
+	for _, warning := range warnings {
+		if warning = strings.TrimSpace(warning); warning != "" {
+			cleaned = append(cleaned, warning)
+		}
+	}
+	return cleaned
+}
+
+// normalizeQueryConfidence clamps the backend confidence into [0, 1] so presenters never render values
+// such as 170%. A misbehaving backend is logged at debug level rather than failing the query.
+func normalizeQueryConfidence(resp *QueryResponse, logger *slog.Logger) {
+	clamped, changed := clampConfidence(resp.Confidence)
+	if !changed {
+		return
+	}
+	logger.Debug(
+		"ipc: clamped out-of-range query confidence",
+		slog.Float64("reported", resp.Confidence),
+		slog.Float64("clamped", clamped),
+		slog.String("trace_id", resp.TraceID),
+	)
+	resp.Confidence = clamped
+}
+
+// clampConfidence limits value to [0, 1], mapping NaN to 0, and reports whether it was adjusted.
+func clampConfidence(value float64) (float64, bool) {
+	switch {
+	case math.IsNaN(value):
+		return 0, true
+	case value < 0:
+		return 0, true
+	case value > 1:
+		return 1, true
+	default:
+		return value, false
+	}
+}
+
+// normalizeQueryRequest trims identifiers, validates the question and context budget, and applies the default budget.
+func normalizeQueryRequest(req *QueryRequest) error {
+	req.Question = strings.TrimSpace(req.Question)
+	if req.Question == "" {
+		return errors.New("ipc: question must be provided")
+	}
+	req.ConversationID = strings.TrimSpace(req.ConversationID)
+	req.TraceID = strings.TrimSpace(req.TraceID)
+	req.Language = strings.TrimSpace(req.Language)
+	req.IndexVersion = strings.TrimSpace(req.IndexVersion)
+	if len(req.SourceTypes) > 0 {
+		types := make([]string, 0, len(req.SourceTypes))
+		for _, sourceType := range req.SourceTypes {
+			if trimmed := strings.ToLower(strings.TrimSpace(sourceType)); trimmed != "" {
+				types = append(types, trimmed)
+			}
+		}
+		req.SourceTypes = types
+	}
+	if len(req.History) > 0 {
+		turns := make([]QueryTurn, 0, len(req.History))
+		for _, turn := range req.History {
+			turn.Question = strings.TrimSpace(turn.Question)
+			turn.Answer = strings.TrimSpace(turn.Answer)
+			if turn.Question != "" {
+				turns = append(turns, turn)
+			}
+		}
+		req.History = turns
+	}
+	maxTokens, err := resolveMaxContextTokens(req.MaxContextTokens)
+	if err != nil {
+		return err
+	}
+	req.MaxContextTokens = maxTokens
+	return nil
+}
+
+// resolveMaxContextTokens applies the default budget for zero and rejects negative or oversized values.
+func resolveMaxContextTokens(value int) (int, error) {
+	switch {
+	case value == 0:
+		return defaultMaxContextTokens, nil
+	case value < 0 || value > MaxContextTokensLimit:
+		return 0, fmt.Errorf("%w: max context tokens must be between 1 and %d (or 0 for the default), got %d", ErrInvalidQueryRequest, MaxContextTokensLimit, value)
+	default:
+		return value, nil
+	}
+}
+
 // ensureQueryResponseDefaults backfills nil slices to keep marshaling predictable.
 func ensureQueryResponseDefaults(resp *QueryResponse) {
 	if resp.Steps == nil {
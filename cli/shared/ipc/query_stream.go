@@ -0,0 +1,102 @@
+package ipc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+var errQueryStreamIncomplete = errors.New("ipc: query stream ended before the final answer")
+
+// QueryStream sends a /v1/query request and consumes partial answers as they arrive.
+// The backend emits several response frames sharing one correlation ID; the stream ends
+// with the frame flagged `final` (or `no_answer`). The callback runs for every frame,
+// including the terminal one, and the validated final response is returned.
+// Servers that do not advertise the query_stream capability answer in a single frame, so
+// that frame is treated as terminal and validated like a Query response.
+func (c *Client) QueryStream(ctx context.Context, req QueryRequest, onChunk func(QueryResponse) error) (QueryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return QueryResponse{}, errors.New("ipc: client closed")
+	}
+	if err := normalizeQueryRequest(&req); err != nil {
+		return QueryResponse{}, err
+	}
+
+	firstFrame, iter, err := c.callStream(ctx, queryPath, req)
+	if err != nil {
+		return QueryResponse{}, err
+	}
+
+	// The handshake acknowledgement has been consumed by now, so the capabilities are known.
+	streaming := slices.Contains(c.serverCaps, CapabilityQueryStream)
+
+	frame := firstFrame
+	for {
+		if err := expectStatus("query", frame, statusOK, req.TraceID); err != nil {
+			return QueryResponse{}, err
+		}
+
+		decode := decodeQueryChunk
+		if !streaming {
+			decode = decodeQueryResponse
+		}
+		chunk, err := decode(frame.Body, c.log)
+		if err != nil {
+			return QueryResponse{}, fmt.Errorf("ipc: decode query response: %w", err)
+		}
+		if err := invokeQueryCallback(onChunk, chunk); err != nil {
+			return chunk, err
+		}
+		if !streaming || isFinalQueryChunk(chunk) {
+			c.log.Info(
+				"IPCClient.QueryStream(ctx, request) :: ok",
+				slog.String("correlation_id", frame.CorrelationID),
+				slog.String("trace_id", chunk.TraceID),
+			)
+			return chunk, nil
+		}
+
+		next, ok, err := iter(ctx)
+		if err != nil {
+			return chunk, err
+		}
+		if !ok {
+			return chunk, errQueryStreamIncomplete
+		}
+		frame = next
+	}
+}
+
+// decodeQueryChunk decodes a streamed frame, enforcing the full response contract only on the terminal chunk.
+func decodeQueryChunk(payload []byte, logger *slog.Logger) (QueryResponse, error) {
+	var resp QueryResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return QueryResponse{}, fmt.Errorf("%w: %v", ErrInvalidQueryResponse, err)
+	}
+	if isFinalQueryChunk(resp) {
+		return decodeQueryResponse(payload, logger)
+	}
+	ensureQueryResponseDefaults(&resp)
+	normalizeQueryConfidence(&resp, logger)
+	return resp, nil
+}
+
+func invokeQueryCallback(cb func(QueryResponse) error, resp QueryResponse) error {
+	if cb == nil {
+		return nil
+	}
+	if err := cb(resp); err != nil {
+		return fmt.Errorf("ipc: query callback: %w", err)
+	}
+	return nil
+}
+
+func isFinalQueryChunk(resp QueryResponse) bool {
+	return resp.Final || resp.NoAnswer
+}
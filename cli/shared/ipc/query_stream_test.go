@@ -0,0 +1,126 @@
+package ipc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestQueryStreamInvokesCallbackUntilFinalFrame(t *testing.T) {
+	chunks := []map[string]any{
+		{"summary": "Use chmod"},
+		{"summary": "Use chmod to adjust"},
+		{
+			"summary":    "Use chmod to adjust permissions.",
+			"steps":      []string{"Run chmod 640 file"},
+			"confidence": 0.8,
+			"trace_id":   "trace-stream",
+			"final":      true,
+		},
+	}
+	client := newTestQueryStreamClient(t, chunks, CapabilityQueryStream)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var summaries []string
+	resp, err := client.QueryStream(ctx, QueryRequest{Question: "chmod?"}, func(chunk QueryResponse) error {
+		summaries = append(summaries, chunk.Summary)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("QueryStream() error = %v", err)
+	}
+	if len(summaries) != len(chunks) {
+		t.Fatalf("expected %d callbacks, got %d (%v)", len(chunks), len(summaries), summaries)
+	}
+	if resp.Summary != "Use chmod to adjust permissions." || len(resp.Steps) != 1 {
+		t.Fatalf("unexpected final response: %#v", resp)
+	}
+}
+
+func TestQueryStreamReportsIncompleteStream(t *testing.T) {
+	client := newTestQueryStreamClient(t, []map[string]any{
+		{"summary": "Partial answer"},
+	}, CapabilityQueryStream)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	resp, err := client.QueryStream(ctx, QueryRequest{Question: "chmod?"}, nil)
+	if !errors.Is(err, errQueryStreamIncomplete) {
+		t.Fatalf("expected incomplete stream error, got %v", err)
+	}
+	if resp.Summary != "Partial answer" {
+		t.Fatalf("expected last partial chunk to be returned, got %#v", resp)
+	}
+}
+
+func TestQueryStreamTreatsSingleFrameAsFinalWithoutCapability(t *testing.T) {
+	client := newTestQueryStreamClient(t, []map[string]any{
+		{
+			"summary":    "Use chmod to adjust permissions.",
+			"steps":      []string{"Run chmod 640 file"},
+			"confidence": 0.8,
+			"trace_id":   "trace-single",
+		},
+		{"summary": "never read"},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	calls := 0
+	resp, err := client.QueryStream(ctx, QueryRequest{Question: "chmod?"}, func(QueryResponse) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("QueryStream() error = %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single callback, got %d", calls)
+	}
+	if resp.Summary != "Use chmod to adjust permissions." || resp.TraceID != "trace-single" {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func newTestQueryStreamClient(t *testing.T, bodies []map[string]any, serverCaps ...string) *Client {
+	t.Helper()
+
+	oldGenerator := correlationIDGenerator
+	correlationIDGenerator = func() string { return "test-correlation" }
+	t.Cleanup(func() { correlationIDGenerator = oldGenerator })
+
+	var payload bytes.Buffer
+	writer := bufio.NewWriter(&payload)
+	for _, body := range bodies {
+		frame := map[string]any{
+			"type":           responseType,
+			"status":         statusOK,
+			"correlation_id": "test-correlation",
+			"body":           body,
+		}
+		if err := writeFrame(writer, frame); err != nil {
+			t.Fatalf("failed to encode frame: %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("failed to flush encoded frames: %v", err)
+	}
+
+	return &Client{
+		conn:              &stubConn{},
+		reader:            bufio.NewReader(bytes.NewReader(payload.Bytes())),
+		writer:            bufio.NewWriter(io.Discard),
+		log:               slog.New(slog.NewTextHandler(io.Discard, nil)),
+		awaitHandshakeAck: false,
+		serverCaps:        serverCaps,
+	}
+}
@@ -0,0 +1,64 @@
+package ipc
+
+import (
+	"bytes"
+	"log/slog"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClampConfidence(t *testing.T) {
+	cases := []struct {
+		name        string
+		value       float64
+		want        float64
+		wantChanged bool
+	}{
+		{name: "nan", value: math.NaN(), want: 0, wantChanged: true},
+		{name: "positive infinity", value: math.Inf(1), want: 1, wantChanged: true},
+		{name: "over range", value: 1.7, want: 1, wantChanged: true},
+		{name: "under range", value: -0.2, want: 0, wantChanged: true},
+		{name: "bounds", value: 1, want: 1},
+		{name: "in range", value: 0.3, want: 0.3},
+	}
+	for _, tc := range cases {
+		got, changed := clampConfidence(tc.value)
+		if got != tc.want || changed != tc.wantChanged {
+			t.Fatalf("%s: clampConfidence(%v) = (%v, %v), want (%v, %v)", tc.name, tc.value, got, changed, tc.want, tc.wantChanged)
+		}
+	}
+}
+
+func TestNormalizeQueryConfidenceReplacesNaN(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	resp := QueryResponse{Summary: "Use chmod.", Confidence: math.NaN()}
+	normalizeQueryConfidence(&resp, logger)
+	if resp.Confidence != 0 {
+		t.Fatalf("expected NaN confidence to clamp to 0, got %v", resp.Confidence)
+	}
+	if !strings.Contains(logs.String(), "clamped out-of-range query confidence") {
+		t.Fatalf("expected the clamp to be logged through the given logger, got %q", logs.String())
+	}
+}
+
+func TestDecodeQueryResponseTrimsWarnings(t *testing.T) {
+	resp, err := DecodeQueryResponse([]byte(`{"summary":"Use chmod.","warnings":["  kiwix source last indexed 30 days ago ",""," "]}`))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []string{"kiwix source last indexed 30 days ago"}
+	if !reflect.DeepEqual(resp.Warnings, want) {
+		t.Fatalf("expected warnings %q, got %q", want, resp.Warnings)
+	}
+
+	resp, err = DecodeQueryResponse([]byte(`{"summary":"Use chmod.","warnings":[" "]}`))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Warnings != nil {
+		t.Fatalf("expected blank warnings to be dropped, got %q", resp.Warnings)
+	}
+}
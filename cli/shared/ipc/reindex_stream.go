@@ -4,15 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"path"
 	"strings"
+	"time"
 )
 
-var errReindexStreamIncomplete = errors.New("ipc: reindex stream ended before completion")
+var (
+	errReindexStreamIncomplete = errors.New("ipc: reindex stream ended before completion")
+	errReindexStreamAbandoned  = errors.New("ipc: reindex progress stream left unread")
+)
 
 // StartReindexStream streams ingestion job snapshots as described in
 // tmp/specs/001-rag-cli/20-11-2025-ragadmin-reindex-streaming-design.md.
-// The method mirrors StartReindex but invokes the callback for every streamed
-// job update before returning the final snapshot.
+// Unlike StartReindex, the method follows the job to completion, invoking the
+// callback for every streamed job update before returning the final snapshot.
+// Cancelling ctx stops the stream between frames; a terminal snapshot already
+// received still wins.
 func (c *Client) StartReindexStream(ctx context.Context, req ReindexRequest, onUpdate func(IngestionJob) error) (IngestionJob, error) {
 	req.TraceID = ensureTraceID(req.TraceID)
 	trigger := strings.TrimSpace(req.Trigger)
@@ -28,8 +36,8 @@ func (c *Client) StartReindexStream(ctx context.Context, req ReindexRequest, onU
 	if err != nil {
 		return IngestionJob{}, err
 	}
-	if firstFrame.Status != statusAccepted {
-		return IngestionJob{}, fmt.Errorf("ipc: start reindex unexpected status %d", firstFrame.Status)
+	if err := expectStatus("start reindex", firstFrame, statusAccepted, req.TraceID); err != nil {
+		return IngestionJob{}, err
 	}
 
 	job, err := decodeIngestionJob(firstFrame.Body)
@@ -44,6 +52,9 @@ func (c *Client) StartReindexStream(ctx context.Context, req ReindexRequest, onU
 		if isTerminalJobStatus(job.Status) {
 			return job, nil
 		}
+		if err := ctx.Err(); err != nil {
+			return job, err
+		}
 
 		nextFrame, ok, err := iter(ctx)
 		if err != nil {
@@ -63,6 +74,81 @@ func (c *Client) StartReindexStream(ctx context.Context, req ReindexRequest, onU
 	}
 }
 
+// ProgressSink consumes reindex job snapshots as they stream in. Handle sees every
+// snapshot; Complete is called once with the last snapshot and the time the job took.
+type ProgressSink interface {
+	Handle(job IngestionJob) error
+	Complete(job IngestionJob, elapsed time.Duration) error
+}
+
+// StreamReindex runs StartReindexStream and reports the job to sink. Complete runs even
+// when the stream fails so sinks can present a partial result; a stream error takes
+// precedence over one returned by Complete.
+func (c *Client) StreamReindex(ctx context.Context, req ReindexRequest, sink ProgressSink) (IngestionJob, error) {
+	started := time.Now()
+	job, err := c.StartReindexStream(ctx, req, sink.Handle)
+	if job.SourceAlias == "" {
+		job.SourceAlias = strings.TrimSpace(req.SourceAlias)
+	}
+	if completeErr := sink.Complete(job, time.Since(started)); completeErr != nil && err == nil {
+		err = fmt.Errorf("ipc: reindex sink: %w", completeErr)
+	}
+	return job, err
+}
+
+const (
+	// latestReindexJob addresses the most recently started job when no ID is supplied.
+	latestReindexJob = "latest"
+	// reindexCancelSuffix is appended to a job path to request cancellation.
+	reindexCancelSuffix = "cancel"
+)
+
+// GetReindexStatus fetches the current snapshot of a reindex job.
+// An empty jobID returns the most recently started job, which lets callers
+// monitor a reindex triggered by another process.
+func (c *Client) GetReindexStatus(ctx context.Context, jobID string, req ReindexStatusRequest) (IngestionJob, error) {
+	req.TraceID = ensureTraceID(req.TraceID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frame, err := c.call(ctx, buildReindexJobPath(jobID), req)
+	if err != nil {
+		return IngestionJob{}, err
+	}
+	if err := expectStatus("get reindex status", frame, statusOK, req.TraceID); err != nil {
+		return IngestionJob{}, err
+	}
+	return decodeIngestionJob(frame.Body)
+}
+
+// CancelReindex aborts a reindex job and returns its updated snapshot.
+// An empty jobID targets the most recently started job. Streaming callers
+// observe the cancellation as a terminal `cancelled` status.
+func (c *Client) CancelReindex(ctx context.Context, jobID string, req ReindexCancelRequest) (IngestionJob, error) {
+	req.TraceID = ensureTraceID(req.TraceID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frame, err := c.call(ctx, path.Join(buildReindexJobPath(jobID), reindexCancelSuffix), req)
+	if err != nil {
+		return IngestionJob{}, err
+	}
+	if err := expectStatus("cancel reindex", frame, statusAccepted, req.TraceID); err != nil {
+		return IngestionJob{}, err
+	}
+	return decodeIngestionJob(frame.Body)
+}
+
+func buildReindexJobPath(jobID string) string {
+	jobID = strings.TrimSpace(jobID)
+	if jobID == "" {
+		jobID = latestReindexJob
+	}
+	return path.Join(indexReindexPath, url.PathEscape(jobID))
+}
+
 func invokeReindexCallback(cb func(IngestionJob) error, job IngestionJob) error {
 	if cb == nil {
 		return nil
@@ -101,6 +101,34 @@ func TestStartReindexStreamForwardsCallbackErrors(t *testing.T) {
 	}
 }
 
+func TestStartReindexStreamStopsBetweenFramesWhenCancelled(t *testing.T) {
+	jobs := []IngestionJob{
+		{JobID: "job-789", Status: "running", Stage: "discovering"},
+		{JobID: "job-789", Status: "running", Stage: "chunking"},
+		{JobID: "job-789", Status: "succeeded", Stage: "completed"},
+	}
+	client := newTestReindexClient(t, jobs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var processed int
+	job, err := client.StartReindexStream(ctx, ReindexRequest{Trigger: "manual"}, func(IngestionJob) error {
+		processed++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if processed != 1 {
+		t.Fatalf("expected the stream to stop after the first frame, got %d callbacks", processed)
+	}
+	if job.Stage != "discovering" {
+		t.Fatalf("expected the last received snapshot, got stage %s", job.Stage)
+	}
+}
+
 func newTestReindexClient(t *testing.T, jobs []IngestionJob) *Client {
 	t.Helper()
 
@@ -155,3 +183,53 @@ type fakeAddr string
 
 func (a fakeAddr) Network() string { return string(a) }
 func (a fakeAddr) String() string  { return string(a) }
+
+// recordingSink is a ProgressSink that keeps every snapshot it receives.
+type recordingSink struct {
+	handled   []IngestionJob
+	completed []IngestionJob
+	elapsed   time.Duration
+}
+
+func (s *recordingSink) Handle(job IngestionJob) error {
+	s.handled = append(s.handled, job)
+	return nil
+}
+
+func (s *recordingSink) Complete(job IngestionJob, elapsed time.Duration) error {
+	s.completed = append(s.completed, job)
+	s.elapsed = elapsed
+	return nil
+}
+
+func TestStreamReindexReportsToCustomSink(t *testing.T) {
+	jobs := []IngestionJob{
+		{JobID: "job-sink", Status: "running", Stage: "discovering", PercentComplete: floatPtr(20)},
+		{JobID: "job-sink", Status: "succeeded", Stage: "completed", PercentComplete: floatPtr(100)},
+	}
+	client := newTestReindexClient(t, jobs)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sink := &recordingSink{}
+	finalJob, err := client.StreamReindex(ctx, ReindexRequest{Trigger: "manual", SourceAlias: "man-pages"}, sink)
+	if err != nil {
+		t.Fatalf("StreamReindex() error = %v", err)
+	}
+	if len(sink.handled) != len(jobs) {
+		t.Fatalf("expected %d handled snapshots, got %d", len(jobs), len(sink.handled))
+	}
+	if len(sink.completed) != 1 {
+		t.Fatalf("expected Complete to run once, got %d", len(sink.completed))
+	}
+	if got := sink.completed[0]; got.Status != "succeeded" || got.SourceAlias != "man-pages" {
+		t.Fatalf("unexpected completed snapshot: %+v", got)
+	}
+	if finalJob.SourceAlias != "man-pages" {
+		t.Fatalf("expected returned job to carry the requested alias, got %q", finalJob.SourceAlias)
+	}
+	if sink.elapsed <= 0 {
+		t.Fatalf("expected a positive elapsed duration, got %s", sink.elapsed)
+	}
+}
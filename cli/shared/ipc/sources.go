@@ -14,11 +14,44 @@ const (
 	sourcesPath      = "/v1/sources"
 	indexReindexPath = "/v1/index/reindex"
 
+	// sourceShowSuffix distinguishes reads from updates and removals, which share the alias path
+	// because the backend routes on the path alone.
+	sourceShowSuffix = "show"
+
 	statusOK       = 200
 	statusCreated  = 201
 	statusAccepted = 202
 )
 
+// ErrRemoteSourceLocation is returned when a source location points at a remote host.
+var ErrRemoteSourceLocation = errors.New("ipc: remote source locations are not allowed")
+
+// remoteLocationSchemes lists the URL schemes that would make the backend fetch content over the network.
+var remoteLocationSchemes = map[string]struct{}{"http": {}, "https": {}, "ftp": {}}
+
+// ValidateSourceLocation rejects locations that would fetch content from a remote host, which the
+// offline-only design forbids. Local paths, file:// URLs, and man:/info: references pass, as do
+// http(s) and ftp URLs addressed to the local machine. A location with a remote scheme that
+// does not parse as a URL is rejected, since its host cannot be checked.
+func ValidateSourceLocation(location string) error {
+	location = strings.TrimSpace(location)
+	parsed, err := url.Parse(location)
+	if err != nil {
+		scheme, _, found := strings.Cut(location, ":")
+		if _, remote := remoteLocationSchemes[strings.ToLower(scheme)]; found && remote {
+			return fmt.Errorf("%w: %s URL does not parse (%v); download the content locally first and register the local path", ErrRemoteSourceLocation, strings.ToLower(scheme), errors.Unwrap(err))
+		}
+		return nil
+	}
+	if _, ok := remoteLocationSchemes[strings.ToLower(parsed.Scheme)]; !ok {
+		return nil
+	}
+	if host := parsed.Hostname(); isRemoteHost(host, hostAllowlist{}) {
+		return fmt.Errorf("%w: %s is served by %s; download the content locally first and register the local path", ErrRemoteSourceLocation, parsed.Redacted(), host)
+	}
+	return nil
+}
+
 // SourceRecord mirrors catalog entries returned by the backend.
 type SourceRecord struct {
 	Alias       string `json:"alias"`
@@ -61,6 +94,11 @@ type SourceListRequest struct {
 	TraceID string `json:"trace_id"`
 }
 
+// SourceGetRequest fetches a single catalog entry by alias.
+type SourceGetRequest struct {
+	TraceID string `json:"trace_id"`
+}
+
 // SourceCreateRequest registers a new knowledge source.
 type SourceCreateRequest struct {
 	TraceID  string `json:"trace_id"`
@@ -87,11 +125,22 @@ type SourceRemoveRequest struct {
 	Reason  string `json:"reason"`
 }
 
+// ReindexStatusRequest polls the state of an existing reindex job.
+type ReindexStatusRequest struct {
+	TraceID string `json:"trace_id"`
+}
+
+// ReindexCancelRequest asks the backend to abort a running reindex job.
+type ReindexCancelRequest struct {
+	TraceID string `json:"trace_id"`
+}
+
 // ReindexRequest triggers an index rebuild operation.
 type ReindexRequest struct {
-	TraceID string `json:"trace_id"`
-	Trigger string `json:"trigger"`
-	Force   bool   `json:"force,omitempty"`
+	TraceID     string `json:"trace_id"`
+	Trigger     string `json:"trigger"`
+	Force       bool   `json:"force,omitempty"`
+	SourceAlias string `json:"source_alias,omitempty"`
 }
 
 // SourceListResponse captures catalog listing payloads.
@@ -120,12 +169,33 @@ func (c *Client) ListSources(ctx context.Context, req SourceListRequest) (Source
 	if err != nil {
 		return SourceListResponse{}, err
 	}
-	if frame.Status != statusOK {
-		return SourceListResponse{}, fmt.Errorf("ipc: list sources unexpected status %d", frame.Status)
+	if err := expectStatus("list sources", frame, statusOK, req.TraceID); err != nil {
+		return SourceListResponse{}, err
 	}
 	return decodeSourceListResponse(frame.Body)
 }
 
+// GetSource fetches the full catalog record for a single source.
+func (c *Client) GetSource(ctx context.Context, alias string, req SourceGetRequest) (SourceRecord, error) {
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return SourceRecord{}, errors.New("ipc: alias must be provided")
+	}
+	req.TraceID = ensureTraceID(req.TraceID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frame, err := c.call(ctx, path.Join(buildSourceAliasPath(alias), sourceShowSuffix), req)
+	if err != nil {
+		return SourceRecord{}, err
+	}
+	if err := expectStatus("get source", frame, statusOK, req.TraceID); err != nil {
+		return SourceRecord{}, err
+	}
+	return decodeSourceRecord(frame.Body)
+}
+
 // CreateSource registers a new knowledge source.
 func (c *Client) CreateSource(ctx context.Context, req SourceCreateRequest) (SourceMutationResponse, error) {
 	req.TraceID = ensureTraceID(req.TraceID)
@@ -137,6 +207,9 @@ func (c *Client) CreateSource(ctx context.Context, req SourceCreateRequest) (Sou
 	if req.Location == "" {
 		return SourceMutationResponse{}, errors.New("ipc: source location is required")
 	}
+	if err := ValidateSourceLocation(req.Location); err != nil {
+		return SourceMutationResponse{}, err
+	}
 	req.Language = strings.TrimSpace(req.Language)
 
 	c.mu.Lock()
@@ -146,8 +219,8 @@ func (c *Client) CreateSource(ctx context.Context, req SourceCreateRequest) (Sou
 	if err != nil {
 		return SourceMutationResponse{}, err
 	}
-	if frame.Status != statusCreated {
-		return SourceMutationResponse{}, fmt.Errorf("ipc: create source unexpected status %d", frame.Status)
+	if err := expectStatus("create source", frame, statusCreated, req.TraceID); err != nil {
+		return SourceMutationResponse{}, err
 	}
 	return decodeSourceMutationResponse(frame.Body)
 }
@@ -159,6 +232,12 @@ func (c *Client) UpdateSource(ctx context.Context, alias string, req SourceUpdat
 		return SourceMutationResponse{}, errors.New("ipc: alias must be provided")
 	}
 	req.TraceID = ensureTraceID(req.TraceID)
+	req.Location = strings.TrimSpace(req.Location)
+	if req.Location != "" {
+		if err := ValidateSourceLocation(req.Location); err != nil {
+			return SourceMutationResponse{}, err
+		}
+	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -167,8 +246,8 @@ func (c *Client) UpdateSource(ctx context.Context, alias string, req SourceUpdat
 	if err != nil {
 		return SourceMutationResponse{}, err
 	}
-	if frame.Status != statusOK {
-		return SourceMutationResponse{}, fmt.Errorf("ipc: update source unexpected status %d", frame.Status)
+	if err := expectStatus("update source", frame, statusOK, req.TraceID); err != nil {
+		return SourceMutationResponse{}, err
 	}
 	return decodeSourceMutationResponse(frame.Body)
 }
@@ -192,15 +271,40 @@ func (c *Client) RemoveSource(ctx context.Context, alias string, req SourceRemov
 	if err != nil {
 		return SourceMutationResponse{}, err
 	}
-	if frame.Status != statusAccepted {
-		return SourceMutationResponse{}, fmt.Errorf("ipc: remove source unexpected status %d", frame.Status)
+	if err := expectStatus("remove source", frame, statusAccepted, req.TraceID); err != nil {
+		return SourceMutationResponse{}, err
 	}
 	return decodeSourceMutationResponse(frame.Body)
 }
 
-// StartReindex triggers an index rebuild and returns the job metadata.
+// StartReindex triggers an index rebuild and returns the accepted job snapshot without
+// waiting for it to finish. The progress frames that follow are left unread, so the
+// connection is marked broken unless the job was already terminal; use StartReindexStream
+// to follow the job on the same client.
 func (c *Client) StartReindex(ctx context.Context, req ReindexRequest) (IngestionJob, error) {
-	return c.StartReindexStream(ctx, req, nil)
+	req.TraceID = ensureTraceID(req.TraceID)
+	if strings.TrimSpace(req.Trigger) == "" {
+		req.Trigger = "manual"
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frame, _, err := c.callStream(ctx, indexReindexPath, req)
+	if err != nil {
+		return IngestionJob{}, err
+	}
+	if err := expectStatus("start reindex", frame, statusAccepted, req.TraceID); err != nil {
+		return IngestionJob{}, err
+	}
+	job, err := decodeIngestionJob(frame.Body)
+	if err != nil {
+		return IngestionJob{}, err
+	}
+	if !isTerminalJobStatus(job.Status) {
+		c.markBroken(errReindexStreamAbandoned)
+	}
+	return job, nil
 }
 
 func decodeSourceListResponse(payload []byte) (SourceListResponse, error) {
@@ -214,6 +318,24 @@ func decodeSourceListResponse(payload []byte) (SourceListResponse, error) {
 	return resp, nil
 }
 
+func decodeSourceRecord(payload []byte) (SourceRecord, error) {
+	var resp struct {
+		Source *SourceRecord `json:"source"`
+	}
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return SourceRecord{}, fmt.Errorf("ipc: decode source record: %w", err)
+	}
+	if resp.Source != nil {
+		return *resp.Source, nil
+	}
+
+	var record SourceRecord
+	if err := json.Unmarshal(payload, &record); err != nil {
+		return SourceRecord{}, fmt.Errorf("ipc: decode source record: %w", err)
+	}
+	return record, nil
+}
+
 func decodeSourceMutationResponse(payload []byte) (SourceMutationResponse, error) {
 	var resp SourceMutationResponse
 	if err := json.Unmarshal(payload, &resp); err != nil {
@@ -0,0 +1,70 @@
+// Package termutil detects whether CLI streams are attached to a terminal and how large it is.
+package termutil
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// unwrapper is implemented by writers that wrap another stream, such as output trackers.
+type unwrapper interface {
+	Unwrap() io.Writer
+}
+
+// fileOf returns the *os.File behind the stream, following Unwrap chains.
+func fileOf(stream any) (*os.File, bool) {
+	for {
+		switch s := stream.(type) {
+		case *os.File:
+			return s, s != nil
+		case unwrapper:
+			stream = s.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// IsTerminal reports whether the stream is attached to a character device such as a TTY.
+// Writers that wrap another stream are followed through their Unwrap method.
+func IsTerminal(stream any) bool {
+	file, ok := fileOf(stream)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Width returns the number of columns of the terminal behind the stream, or 0 when unknown.
+func Width(stream any) int {
+	_, cols := Size(stream)
+	return cols
+}
+
+// Height returns the number of rows of the terminal behind the stream, or 0 when unknown.
+func Height(stream any) int {
+	rows, _ := Size(stream)
+	return rows
+}
+
+// Size queries the window size of the terminal behind the stream; both values are 0 when unknown.
+func Size(stream any) (rows, cols int) {
+	file, ok := fileOf(stream)
+	if !ok {
+		return 0, 0
+	}
+	var size struct {
+		Rows, Cols, XPixels, YPixels uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0, 0
+	}
+	return int(size.Rows), int(size.Cols)
+}
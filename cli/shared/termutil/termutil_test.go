@@ -0,0 +1,69 @@
+package termutil
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Fatal("IsTerminal(buffer) = true, want false")
+	}
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	defer file.Close()
+	if IsTerminal(file) {
+		t.Fatal("IsTerminal(regular file) = true, want false")
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("open %s: %v", os.DevNull, err)
+	}
+	defer devNull.Close()
+	if !IsTerminal(devNull) {
+		t.Fatalf("IsTerminal(%s) = false, want true for a character device", os.DevNull)
+	}
+}
+
+func TestSizeUnknown(t *testing.T) {
+	if rows, cols := Size(&bytes.Buffer{}); rows != 0 || cols != 0 {
+		t.Fatalf("Size(buffer) = %d, %d, want 0, 0", rows, cols)
+	}
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	defer file.Close()
+	if width := Width(file); width != 0 {
+		t.Fatalf("Width(regular file) = %d, want 0", width)
+	}
+}
+
+type wrappedWriter struct {
+	w io.Writer
+}
+
+func (w wrappedWriter) Write(p []byte) (int, error) { return w.w.Write(p) }
+
+func (w wrappedWriter) Unwrap() io.Writer { return w.w }
+
+func TestIsTerminalFollowsUnwrap(t *testing.T) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("open %s: %v", os.DevNull, err)
+	}
+	defer devNull.Close()
+	if !IsTerminal(wrappedWriter{w: wrappedWriter{w: devNull}}) {
+		t.Fatal("IsTerminal(wrapped character device) = false, want true")
+	}
+	if IsTerminal(wrappedWriter{w: &bytes.Buffer{}}) {
+		t.Fatal("IsTerminal(wrapped buffer) = true, want false")
+	}
+}
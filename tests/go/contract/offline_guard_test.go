@@ -1,10 +1,13 @@
 package contract_test
 
 import (
+	"context"
 	"errors"
 	"io"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/linux-rag-t2/cli/shared/ipc"
@@ -70,3 +73,226 @@ func TestOfflineGuardAllowsLoopbackForRagadminHealth(t *testing.T) {
 		t.Fatalf("expected exactly one transport call for loopback request, got %d", calls)
 	}
 }
+
+func TestOfflineGuardAllowlistPermitsListedHosts(t *testing.T) {
+	originalTransport := http.DefaultTransport
+	var calls int
+	http.DefaultTransport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	restore, err := ipc.InstallOfflineHTTPGuardWithAllowlist([]string{"192.168.1.20", "10.20.0.0/16", "Ollama.Lan"})
+	if err != nil {
+		t.Fatalf("InstallOfflineHTTPGuardWithAllowlist() error = %v", err)
+	}
+	t.Cleanup(restore)
+
+	for _, url := range []string{
+		"http://192.168.1.20:11434/api/tags",
+		"http://10.20.3.4:11434/api/tags",
+		"http://ollama.lan:11434/api/tags",
+		"http://localhost:8080/v1/schema",
+	} {
+		resp, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("expected allowlisted request to %s to pass, got %v", url, err)
+		}
+		resp.Body.Close()
+	}
+	if calls != 4 {
+		t.Fatalf("expected 4 transport calls, got %d", calls)
+	}
+
+	for _, url := range []string{"http://192.168.1.21:11434/", "http://10.21.0.1/", "https://example.com/"} {
+		if _, err := http.Get(url); !errors.Is(err, ipc.ErrExternalNetworkBlocked) {
+			t.Fatalf("expected request to %s to be blocked, got %v", url, err)
+		}
+	}
+	if calls != 4 {
+		t.Fatalf("expected blocked hosts to never reach the transport, got %d calls", calls)
+	}
+}
+
+func TestOfflineGuardHonoursNestedAllowlists(t *testing.T) {
+	originalTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	restoreOuter := ipc.InstallOfflineHTTPGuard()
+	t.Cleanup(restoreOuter)
+
+	restoreInner, err := ipc.InstallOfflineHTTPGuardWithAllowlist([]string{"ollama.lan"})
+	if err != nil {
+		t.Fatalf("InstallOfflineHTTPGuardWithAllowlist() error = %v", err)
+	}
+	resp, err := http.Get("http://ollama.lan:11434/api/tags")
+	if err != nil {
+		t.Fatalf("expected nested allowlist to permit ollama.lan, got %v", err)
+	}
+	resp.Body.Close()
+
+	restoreInner()
+	if _, err := http.Get("http://ollama.lan:11434/api/tags"); !errors.Is(err, ipc.ErrExternalNetworkBlocked) {
+		t.Fatalf("expected ollama.lan to be blocked once the nested guard is restored, got %v", err)
+	}
+
+	restoreOuter()
+	resp, err = http.Get("http://ollama.lan:11434/api/tags")
+	if err != nil {
+		t.Fatalf("expected requests to pass once every guard is restored, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestOfflineGuardAllowlistRejectsInvalidRange(t *testing.T) {
+	if _, err := ipc.InstallOfflineHTTPGuardWithAllowlist([]string{"10.0.0.0/33"}); err == nil {
+		t.Fatal("expected invalid CIDR to be rejected")
+	}
+}
+
+func TestOfflineGuardClassifiesIPv6Hosts(t *testing.T) {
+	originalTransport := http.DefaultTransport
+	var calls int
+	http.DefaultTransport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	restore := ipc.InstallOfflineHTTPGuard()
+	t.Cleanup(restore)
+
+	for _, url := range []string{"http://[::1]:11434/api/tags", "http://[::ffff:127.0.0.1]:8080/", "http://[::]:8080/"} {
+		resp, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("expected local IPv6 request to %s to pass, got %v", url, err)
+		}
+		resp.Body.Close()
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 transport calls, got %d", calls)
+	}
+
+	for _, url := range []string{"http://[fe80::1%25eth0]/", "http://[fe80::1]/", "http://[2001:db8::1]:11434/"} {
+		if _, err := http.Get(url); !errors.Is(err, ipc.ErrExternalNetworkBlocked) {
+			t.Fatalf("expected request to %s to be blocked, got %v", url, err)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("expected blocked IPv6 hosts to never reach the transport, got %d calls", calls)
+	}
+}
+
+func TestOfflineGuardAllowlistMatchesZonedIPv6(t *testing.T) {
+	originalTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	restore, err := ipc.InstallOfflineHTTPGuardWithAllowlist([]string{"fe80::/10"})
+	if err != nil {
+		t.Fatalf("InstallOfflineHTTPGuardWithAllowlist() error = %v", err)
+	}
+	t.Cleanup(restore)
+
+	resp, err := http.Get("http://[fe80::1%25eth0]:11434/api/tags")
+	if err != nil {
+		t.Fatalf("expected allowlisted link-local request to pass, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestGuardedDialerAllowsLoopback(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}()
+
+	conn, err := ipc.NewGuardedDialer().DialContext(context.Background(), "tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("expected loopback dial to succeed, got %v", err)
+	}
+	_ = conn.Close()
+}
+
+func TestGuardedDialerBlocksExternalAddresses(t *testing.T) {
+	dialer := ipc.NewGuardedDialer()
+	for _, target := range []struct{ network, address string }{
+		{"tcp", "203.0.113.10:443"},
+		{"udp", "8.8.8.8:53"},
+		{"tcp6", "[2001:db8::1]:80"},
+		{"tcp", "example.com:80"},
+	} {
+		conn, err := dialer.Dial(target.network, target.address)
+		if !errors.Is(err, ipc.ErrExternalNetworkBlocked) {
+			if conn != nil {
+				_ = conn.Close()
+			}
+			t.Fatalf("expected %s dial to %s to be blocked, got %v", target.network, target.address, err)
+		}
+	}
+}
+
+func TestOfflineGuardStatsCountConcurrentRequests(t *testing.T) {
+	originalTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	restore := ipc.InstallOfflineHTTPGuard()
+	t.Cleanup(restore)
+
+	blockedBefore, allowedBefore := ipc.OfflineGuardStats()
+
+	const rounds = 25
+	var wg sync.WaitGroup
+	for i := 0; i < rounds; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if resp, err := http.Get("http://127.0.0.1:11434/api/tags"); err == nil {
+				resp.Body.Close()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = http.Get("https://example.com/")
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = http.Get("http://203.0.113.7/")
+		}()
+	}
+	wg.Wait()
+
+	blocked, allowed := ipc.OfflineGuardStats()
+	if got := blocked - blockedBefore; got != 2*rounds {
+		t.Fatalf("blocked delta = %d, want %d", got, 2*rounds)
+	}
+	if got := allowed - allowedBefore; got != rounds {
+		t.Fatalf("allowed delta = %d, want %d", got, rounds)
+	}
+}
@@ -0,0 +1,48 @@
+package contract_test
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRagadminDialTimeoutToleratesSlowAccept(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "dial-timeout-slow-accept",
+		args: []string{
+			"--socket",
+			"",
+			"--dial-timeout",
+			"5s",
+			"sources",
+			"list",
+		},
+		acceptDelay:  500 * time.Millisecond,
+		responseBody: map[string]any{"sources": []any{}},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if strings.Contains(output, "dial unix socket") {
+				t.Fatalf("did not expect a dial failure within the configured timeout:\n%s", output)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
+
+func TestRagadminDialTimeoutRejectsNonPositiveValues(t *testing.T) {
+	t.Parallel()
+
+	cmd := exec.Command("go", "run", "./cli/ragadmin", "--dial-timeout", "0s", "sources", "list")
+	cmd.Dir = findRepoRoot(t)
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected ragadmin to reject a zero dial timeout:\n%s", output)
+	}
+	if !strings.Contains(string(output), "--dial-timeout must be positive") {
+		t.Fatalf("expected dial timeout validation error:\n%s", output)
+	}
+}
@@ -0,0 +1,59 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRagadminJSONOutputEmitsErrorEnvelope(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "sources-show-json-error-envelope",
+		args: []string{
+			"--socket",
+			"",
+			"--output",
+			"json",
+			"sources",
+			"show",
+			"missing",
+		},
+		responseStatus: 404,
+		responseBody: map[string]any{
+			"code":     "SOURCE_NOT_FOUND",
+			"message":  "Source missing is not catalogued",
+			"trace_id": "trace-envelope",
+		},
+		expectError: true,
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			start := strings.Index(output, "{")
+			if start < 0 {
+				t.Fatalf("expected a JSON error envelope in output:\n%s", output)
+			}
+			var envelope struct {
+				Error struct {
+					Message string `json:"message"`
+					TraceID string `json:"trace_id"`
+					Code    string `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&envelope); err != nil {
+				t.Fatalf("decode error envelope: %v\n%s", err, output)
+			}
+			if envelope.Error.Code != "SOURCE_NOT_FOUND" {
+				t.Fatalf("expected backend error code, got %q", envelope.Error.Code)
+			}
+			if envelope.Error.TraceID != "trace-envelope" {
+				t.Fatalf("expected backend trace id, got %q", envelope.Error.TraceID)
+			}
+			if !strings.Contains(envelope.Error.Message, "Source missing is not catalogued") {
+				t.Fatalf("expected backend message in envelope, got %q", envelope.Error.Message)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
@@ -100,7 +100,7 @@ func TestRagadminHealthDisplaysComponentStatuses(t *testing.T) {
 		},
 		outputAssert: func(t *testing.T, output string) {
 			t.Helper()
-			for _, token := range []string{"Disk Capacity", "WARN", "9% free", "Ollama", "Weaviate"} {
+			for _, token := range []string{"Disk Capacity", "WARN", "9% free", "Ollama", "Weaviate", "→ Fix: Delete temporary files"} {
 				if !strings.Contains(output, token) {
 					t.Fatalf("expected health output to include %q:\n%s", token, output)
 				}
@@ -110,3 +110,39 @@ func TestRagadminHealthDisplaysComponentStatuses(t *testing.T) {
 
 	runRagadminScenario(t, scenario)
 }
+
+func TestRagadminHealthExitsNonZeroOnFail(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "admin-health-fail",
+		args: []string{
+			"--socket",
+			"",
+			"health",
+		},
+		responseBody: map[string]any{
+			"overall_status": "fail",
+			"results": []any{
+				map[string]any{
+					"component": "weaviate",
+					"status":    "fail",
+					"message":   "Connection refused",
+				},
+			},
+		},
+		expectError: true,
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Overall Status: FAIL") {
+				t.Fatalf("expected summary to print before failing:\n%s", output)
+			}
+			// `go run` reports the child's exit code on stderr.
+			if !strings.Contains(output, "exit status 3") {
+				t.Fatalf("expected health fail exit code 3:\n%s", output)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
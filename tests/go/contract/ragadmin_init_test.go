@@ -0,0 +1,100 @@
+package contract_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRagadminInitDryRunLeavesFilesystemUntouched(t *testing.T) {
+	t.Parallel()
+
+	dataHome := filepath.Join(t.TempDir(), "data")
+	kiwixDir := filepath.Join(dataHome, "kiwix")
+
+	scenario := ragadminScenario{
+		name: "admin-init-dry-run",
+		args: []string{
+			"--socket",
+			"",
+			"init",
+			"--dry-run",
+		},
+		env: map[string]string{
+			"RAGCLI_DATA_HOME": dataHome,
+		},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			body, _ := frame["body"].(map[string]any)
+			if dryRun, _ := body["dry_run"].(bool); !dryRun {
+				t.Fatalf("expected dry_run: true in init request body, got %v", body)
+			}
+		},
+		responseBody: map[string]any{
+			"catalog_version":     5,
+			"created_directories": []any{"/home/example/.local/share/ragcli"},
+			"seeded_sources": []any{
+				map[string]any{"alias": "man-pages", "type": "man", "location": "/usr/share/man", "status": "pending_validation"},
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			for _, token := range []string{"(dry run)", "Would create:", kiwixDir + " (would create)", "man-pages"} {
+				if !strings.Contains(output, token) {
+					t.Fatalf("expected dry-run output to mention %q:\n%s", token, output)
+				}
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+
+	if _, err := os.Stat(kiwixDir); !os.IsNotExist(err) {
+		t.Fatalf("expected dry run to leave %s uncreated, stat err = %v", kiwixDir, err)
+	}
+}
+
+func TestRagadminInitDryRunReportsFallbackKiwixDir(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	blocker := filepath.Join(root, "blocker")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker: %v", err)
+	}
+	xdgDataHome := filepath.Join(root, "xdg")
+	kiwixDir := filepath.Join(xdgDataHome, "ragcli", "kiwix")
+
+	scenario := ragadminScenario{
+		name: "admin-init-dry-run-fallback",
+		args: []string{
+			"--socket",
+			"",
+			"init",
+			"--dry-run",
+		},
+		env: map[string]string{
+			"RAGCLI_DATA_HOME": blocker,
+			"XDG_DATA_HOME":    xdgDataHome,
+		},
+		requestAssert: func(t *testing.T, frame map[string]any) { t.Helper() },
+		responseBody: map[string]any{
+			"catalog_version":     5,
+			"created_directories": []any{},
+			"seeded_sources":      []any{},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, kiwixDir+" (would create)") {
+				t.Fatalf("expected dry-run output to report the fallback %s:\n%s", kiwixDir, output)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+
+	if _, err := os.Stat(xdgDataHome); !os.IsNotExist(err) {
+		t.Fatalf("expected dry run to leave %s uncreated, stat err = %v", xdgDataHome, err)
+	}
+}
@@ -0,0 +1,161 @@
+package contract_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRagadminReindexStatusForJob(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "reindex-status-job",
+		args: []string{"--socket", "", "--trace-id", "ext-status-1", "reindex", "status", "--job", "job-42"},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			if path, _ := frame["path"].(string); path != "/v1/index/reindex/job-42" {
+				t.Fatalf("expected status request to target job path, got %q", path)
+			}
+			if body, _ := frame["body"].(map[string]any); body["trace_id"] != "ext-status-1" {
+				t.Fatalf("expected --trace-id to be forwarded, body=%v", body)
+			}
+		},
+		responseBody: map[string]any{
+			"job": map[string]any{
+				"job_id":              "job-42",
+				"status":              "running",
+				"stage":               "embedding",
+				"percent_complete":    62.4,
+				"documents_processed": 512,
+				"requested_at":        "2025-11-20T00:00:00Z",
+				"started_at":          "2025-11-20T00:00:02Z",
+				"trigger":             "manual",
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			for _, want := range []string{"Reindex running (job job-42)", "Stage: embedding (62%)", "Documents: 512", "Started: 2025-11-20T00:00:02Z"} {
+				if !strings.Contains(output, want) {
+					t.Fatalf("expected %q in status output:\n%s", want, output)
+				}
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
+
+func TestRagadminReindexStatusDefaultsToLatestJob(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "reindex-status-latest",
+		args: []string{"--socket", "", "--output", "json", "reindex", "status"},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			if path, _ := frame["path"].(string); path != "/v1/index/reindex/latest" {
+				t.Fatalf("expected status request to target latest job, got %q", path)
+			}
+		},
+		responseBody: map[string]any{
+			"job": map[string]any{
+				"job_id":       "job-43",
+				"status":       "succeeded",
+				"stage":        "completed",
+				"completed_at": "2025-11-20T00:10:00Z",
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			var payload struct {
+				Job map[string]any `json:"job"`
+			}
+			if err := json.Unmarshal([]byte(output), &payload); err != nil {
+				t.Fatalf("expected JSON status payload, got %v:\n%s", err, output)
+			}
+			if payload.Job["job_id"] != "job-43" || payload.Job["status"] != "succeeded" {
+				t.Fatalf("unexpected job payload: %v", payload.Job)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
+
+func TestRagadminReindexCancel(t *testing.T) {
+	t.Parallel()
+
+	dataHome := t.TempDir()
+	scenario := ragadminScenario{
+		name: "reindex-cancel",
+		args: []string{"--socket", "", "--trace-id", "ext-cancel-1", "reindex", "cancel", "--job", "job-42"},
+		env:  map[string]string{"XDG_DATA_HOME": dataHome},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			if path, _ := frame["path"].(string); path != "/v1/index/reindex/job-42/cancel" {
+				t.Fatalf("expected cancel request to target job cancel path, got %q", path)
+			}
+			if body, _ := frame["body"].(map[string]any); body["trace_id"] != "ext-cancel-1" {
+				t.Fatalf("expected --trace-id to be forwarded, body=%v", body)
+			}
+		},
+		responseStatus: 202,
+		responseBody: map[string]any{
+			"job": map[string]any{
+				"job_id": "job-42",
+				"status": "cancelled",
+				"stage":  "embedding",
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Reindex cancelled (job job-42)") {
+				t.Fatalf("expected cancellation confirmation in output:\n%s", output)
+			}
+
+			data, err := os.ReadFile(filepath.Join(dataHome, "ragcli", "audit.log"))
+			if err != nil {
+				t.Fatalf("expected audit log to be written: %v", err)
+			}
+			var entry map[string]any
+			if err := json.Unmarshal(bytes.TrimSpace(data), &entry); err != nil {
+				t.Fatalf("decode audit entry: %v\n%s", err, data)
+			}
+			if entry["action"] != "index_reindex_cancel" || entry["trace_id"] != "ext-cancel-1" {
+				t.Fatalf("expected the cancel audit entry to carry the request trace ID, got %v", entry)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
+
+func TestRagadminReindexCancelRejectsUncancelledJob(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name:           "reindex-cancel-already-finished",
+		args:           []string{"--socket", "", "reindex", "cancel"},
+		responseStatus: 202,
+		responseBody: map[string]any{
+			"job": map[string]any{
+				"job_id": "job-43",
+				"status": "succeeded",
+				"stage":  "completed",
+			},
+		},
+		expectError: true,
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "was not cancelled (status succeeded)") {
+				t.Fatalf("expected failure explaining job status:\n%s", output)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
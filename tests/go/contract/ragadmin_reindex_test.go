@@ -4,7 +4,10 @@ package contract_test
 // tmp/specs/001-rag-cli/20-11-2025-ragadmin-reindex-streaming-design.md.
 
 import (
+	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -288,3 +291,99 @@ func TestRagadminReindexForceFlag(t *testing.T) {
 
 	runRagadminScenario(t, scenario)
 }
+
+func TestRagadminReindexScopesToSourceAlias(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "reindex-single-source",
+		args: []string{
+			"--socket",
+			"",
+			"reindex",
+			"--source",
+			"man-pages",
+		},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			body, _ := frame["body"].(map[string]any)
+			if alias, _ := body["source_alias"].(string); alias != "man-pages" {
+				t.Fatalf("expected source_alias man-pages in request body, got %v", body["source_alias"])
+			}
+		},
+		responseStream: []ragadminStreamFrame{
+			{
+				status: 202,
+				body: map[string]any{
+					"job": map[string]any{
+						"job_id":           "job-scoped",
+						"status":           "succeeded",
+						"stage":            "completed",
+						"percent_complete": 100,
+					},
+				},
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Reindex succeeded for man-pages (job job-scoped)") {
+				t.Fatalf("expected scoped summary line in output:\n%s", output)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
+
+func TestRagadminReindexWaitFalseQueuesWithoutStreaming(t *testing.T) {
+	t.Parallel()
+
+	dataHome := t.TempDir()
+	scenario := ragadminScenario{
+		name: "reindex-wait-false",
+		args: []string{
+			"--socket",
+			"",
+			"reindex",
+			"--wait=false",
+		},
+		env: map[string]string{"XDG_DATA_HOME": dataHome},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			if frame["path"] != "/v1/index/reindex" {
+				t.Fatalf("expected reindex path, got %v", frame["path"])
+			}
+		},
+		responseStatus: 202,
+		responseBody: map[string]any{
+			"job": map[string]any{
+				"job_id": "job-queued",
+				"status": "queued",
+				"stage":  "pending",
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Reindex queued (job job-queued)") {
+				t.Fatalf("expected queued job summary, got:\n%s", output)
+			}
+			if !strings.Contains(output, "ragadmin reindex status --job job-queued") {
+				t.Fatalf("expected follow-up hint, got:\n%s", output)
+			}
+
+			data, err := os.ReadFile(filepath.Join(dataHome, "ragcli", "audit.log"))
+			if err != nil {
+				t.Fatalf("expected audit log to be written: %v", err)
+			}
+			var entry map[string]any
+			if err := json.Unmarshal(bytes.TrimSpace(data), &entry); err != nil {
+				t.Fatalf("decode audit entry: %v\n%s", err, data)
+			}
+			if entry["action"] != "index_reindex" || entry["status"] != "queued" {
+				t.Fatalf("unexpected audit entry: %v", entry)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
@@ -0,0 +1,140 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var backupCatalog = map[string]any{
+	"updated_at": "2024-11-04T09:00:00Z",
+	"sources": []any{
+		map[string]any{
+			"alias":        "man-pages",
+			"type":         "man",
+			"location":     "/usr/share/man",
+			"language":     "en",
+			"size_bytes":   1024,
+			"last_updated": "2024-11-03T08:00:00Z",
+			"status":       "active",
+		},
+		map[string]any{
+			"alias":        "linuxwiki",
+			"type":         "kiwix",
+			"location":     "/data/linuxwiki_en.zim",
+			"language":     "en",
+			"size_bytes":   4096,
+			"last_updated": "2024-11-04T09:00:00Z",
+			"status":       "active",
+			"checksum":     "sha256:abc123",
+			"notes":        "Weekly snapshot",
+		},
+	},
+}
+
+func TestRagadminSourcesExportImportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	catalogPath := filepath.Join(t.TempDir(), "catalog.json")
+
+	runRagadminScenario(t, ragadminScenario{
+		name: "sources-export",
+		args: []string{"--socket", "", "sources", "export", "--file", catalogPath},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			if path, _ := frame["path"].(string); path != "/v1/sources" {
+				t.Fatalf("expected export to list sources, got %q", path)
+			}
+		},
+		responseBody: backupCatalog,
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Exported 2 sources") {
+				t.Fatalf("expected export summary in output:\n%s", output)
+			}
+		},
+	})
+
+	data, err := os.ReadFile(catalogPath)
+	if err != nil {
+		t.Fatalf("expected catalog file to be written: %v", err)
+	}
+	var exported map[string]any
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("expected exported catalog to be JSON: %v", err)
+	}
+	if sources, _ := exported["sources"].([]any); len(sources) != 2 {
+		t.Fatalf("expected 2 exported sources, got %v", exported["sources"])
+	}
+
+	runRagadminScenario(t, ragadminScenario{
+		name: "sources-import",
+		args: []string{"--socket", "", "sources", "import", "--file", catalogPath},
+		responseBody: map[string]any{
+			"updated_at": "2024-11-05T09:00:00Z",
+			"sources": []any{
+				map[string]any{"alias": "man-pages", "type": "man", "location": "/usr/share/man", "status": "active"},
+			},
+		},
+		followUps: []ragadminExchange{
+			{
+				requestAssert: func(t *testing.T, frame map[string]any) {
+					t.Helper()
+					body, _ := frame["body"].(map[string]any)
+					if body["alias"] != "linuxwiki" || body["type"] != "kiwix" || body["location"] != "/data/linuxwiki_en.zim" {
+						t.Fatalf("expected create request for linuxwiki, got %v", body)
+					}
+					if body["checksum"] != "sha256:abc123" || body["notes"] != "Weekly snapshot" {
+						t.Fatalf("expected checksum and notes to be replayed, got %v", body)
+					}
+				},
+				status: 201,
+				body: map[string]any{
+					"source": map[string]any{"alias": "linuxwiki", "type": "kiwix", "status": "pending_validation"},
+				},
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			for _, want := range []string{"Skipping man-pages", "Created linuxwiki", "Imported 1 sources, skipped 1"} {
+				if !strings.Contains(output, want) {
+					t.Fatalf("expected %q in import output:\n%s", want, output)
+				}
+			}
+		},
+	})
+}
+
+func TestRagadminSourcesImportDryRunCreatesNothing(t *testing.T) {
+	t.Parallel()
+
+	catalogPath := filepath.Join(t.TempDir(), "catalog.json")
+	data, err := json.Marshal(backupCatalog)
+	if err != nil {
+		t.Fatalf("failed to encode catalog: %v", err)
+	}
+	if err := os.WriteFile(catalogPath, data, 0o600); err != nil {
+		t.Fatalf("failed to write catalog: %v", err)
+	}
+
+	runRagadminScenario(t, ragadminScenario{
+		name: "sources-import-dry-run",
+		args: []string{"--socket", "", "sources", "import", "--file", catalogPath, "--dry-run"},
+		responseBody: map[string]any{
+			"updated_at": "2024-11-05T09:00:00Z",
+			"sources": []any{
+				map[string]any{"alias": "linuxwiki", "type": "kiwix", "location": "/data/linuxwiki_en.zim", "status": "active"},
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			for _, want := range []string{"Would create man-pages (man at /usr/share/man)", "Skipping linuxwiki", "Dry run: would import 1 sources, skipped 1"} {
+				if !strings.Contains(output, want) {
+					t.Fatalf("expected %q in dry-run output:\n%s", want, output)
+				}
+			}
+		},
+	})
+}
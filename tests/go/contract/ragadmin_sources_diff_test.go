@@ -0,0 +1,113 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRagadminSourcesDiffAgainstLiveCatalog(t *testing.T) {
+	t.Parallel()
+
+	snapshot := map[string]any{
+		"updated_at": "2024-11-01T09:00:00Z",
+		"sources": []any{
+			map[string]any{
+				"alias":        "linuxwiki",
+				"type":         "kiwix",
+				"location":     "/data/linuxwiki_en.zim",
+				"language":     "en",
+				"size_bytes":   2048,
+				"last_updated": "2024-10-28T09:00:00Z",
+				"status":       "active",
+				"checksum":     "sha256:old999",
+				"notes":        "Weekly snapshot",
+			},
+			map[string]any{
+				"alias":        "archwiki",
+				"type":         "kiwix",
+				"location":     "/data/archwiki.zim",
+				"language":     "en",
+				"size_bytes":   512,
+				"last_updated": "2024-10-01T09:00:00Z",
+				"status":       "active",
+			},
+		},
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("encode snapshot: %v", err)
+	}
+	fromPath := filepath.Join(t.TempDir(), "before.json")
+	if err := os.WriteFile(fromPath, data, 0o600); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+
+	scenario := ragadminScenario{
+		name: "sources-diff-live",
+		args: []string{
+			"--socket",
+			"",
+			"--output",
+			"json",
+			"sources",
+			"diff",
+			"--from",
+			fromPath,
+			"--to",
+			"-",
+		},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			if path, _ := frame["path"].(string); path != "/v1/sources" {
+				t.Fatalf("expected live diff to list sources, got %q", path)
+			}
+		},
+		responseBody: backupCatalog,
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			start := strings.Index(output, "{")
+			if start < 0 {
+				t.Fatalf("expected JSON diff output:\n%s", output)
+			}
+			var diff struct {
+				Added   []map[string]any `json:"added"`
+				Removed []map[string]any `json:"removed"`
+				Changed []struct {
+					Alias  string `json:"alias"`
+					Fields []struct {
+						Field string `json:"field"`
+						From  string `json:"from"`
+						To    string `json:"to"`
+					} `json:"fields"`
+				} `json:"changed"`
+			}
+			if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&diff); err != nil {
+				t.Fatalf("decode diff: %v\n%s", err, output)
+			}
+			if len(diff.Added) != 1 || diff.Added[0]["alias"] != "man-pages" {
+				t.Fatalf("expected man-pages to be added, got %v", diff.Added)
+			}
+			if len(diff.Removed) != 1 || diff.Removed[0]["alias"] != "archwiki" {
+				t.Fatalf("expected archwiki to be removed, got %v", diff.Removed)
+			}
+			if len(diff.Changed) != 1 || diff.Changed[0].Alias != "linuxwiki" {
+				t.Fatalf("expected linuxwiki to change, got %+v", diff.Changed)
+			}
+			fields := map[string][2]string{}
+			for _, delta := range diff.Changed[0].Fields {
+				fields[delta.Field] = [2]string{delta.From, delta.To}
+			}
+			if fields["checksum"] != [2]string{"sha256:old999", "sha256:abc123"} || fields["size_bytes"] != [2]string{"2048", "4096"} {
+				t.Fatalf("unexpected field deltas: %v", fields)
+			}
+			if _, ok := fields["last_updated"]; !ok {
+				t.Fatalf("expected a last_updated delta, got %v", fields)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
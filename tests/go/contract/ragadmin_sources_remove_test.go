@@ -18,6 +18,7 @@ func TestRagadminSourcesRemoveQuarantinesSource(t *testing.T) {
 			"linuxwiki",
 			"--reason",
 			"Duplicate content detected",
+			"--yes",
 		},
 		requestAssert: func(t *testing.T, frame map[string]any) {
 			t.Helper()
@@ -20,7 +20,9 @@ type ragadminScenario struct {
 	responseStatus int
 	responseBody   map[string]any
 	responseStream []ragadminStreamFrame
+	followUps      []ragadminExchange
 	env            map[string]string
+	acceptDelay    time.Duration
 	expectError    bool
 	outputAssert   func(t *testing.T, output string)
 }
@@ -112,6 +114,9 @@ func runRagadminStub(t *testing.T, socketPath string, scenario ragadminScenario,
 
 	close(ready)
 
+	if scenario.acceptDelay > 0 {
+		time.Sleep(scenario.acceptDelay)
+	}
 	conn, err := listener.Accept()
 	if err != nil {
 		return fmt.Errorf("failed to accept connection: %w", err)
@@ -186,9 +191,43 @@ func runRagadminStub(t *testing.T, socketPath string, scenario ragadminScenario,
 		return fmt.Errorf("failed to write response frame: %w", err)
 	}
 
+	for idx, exchange := range scenario.followUps {
+		data, err := readFrame(context.Background(), reader, conn)
+		if err != nil {
+			return fmt.Errorf("failed to read follow-up request %d: %w", idx+1, err)
+		}
+		var frame map[string]any
+		if err := json.Unmarshal(data, &frame); err != nil {
+			return fmt.Errorf("failed to decode follow-up request %d: %w", idx+1, err)
+		}
+		if exchange.requestAssert != nil {
+			exchange.requestAssert(t, frame)
+		}
+		status := exchange.status
+		if status == 0 {
+			status = 200
+		}
+		correlationID, _ := frame["correlation_id"].(string)
+		if err := writeFrame(writer, map[string]any{
+			"type":           "response",
+			"status":         status,
+			"correlation_id": correlationID,
+			"body":           exchange.body,
+		}); err != nil {
+			return fmt.Errorf("failed to write follow-up response %d: %w", idx+1, err)
+		}
+	}
+
 	return nil
 }
 
+// ragadminExchange scripts an additional request/response pair served on the same connection.
+type ragadminExchange struct {
+	requestAssert func(t *testing.T, frame map[string]any)
+	status        int
+	body          map[string]any
+}
+
 type ragadminStreamFrame struct {
 	status int
 	body   map[string]any
@@ -0,0 +1,122 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRagadminSourcesShowPrintsAllFields(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "sources-show-table",
+		args: []string{
+			"--socket",
+			"",
+			"--trace-id",
+			"ext-show-1",
+			"sources",
+			"show",
+			"linuxwiki",
+		},
+		requestAssert: func(t *testing.T, frame map[string]any) {
+			t.Helper()
+			if path, _ := frame["path"].(string); path != "/v1/sources/linuxwiki/show" {
+				t.Fatalf("expected show request to target the alias show path, got %q", path)
+			}
+			if body, _ := frame["body"].(map[string]any); body["trace_id"] != "ext-show-1" {
+				t.Fatalf("expected --trace-id to be forwarded, body=%v", body)
+			}
+		},
+		responseBody: map[string]any{
+			"source": map[string]any{
+				"alias":        "linuxwiki",
+				"type":         "kiwix",
+				"location":     "/data/linuxwiki_en.zim",
+				"language":     "en",
+				"size_bytes":   5242880,
+				"last_updated": "2024-11-04T09:00:00Z",
+				"status":       "active",
+				"checksum":     "sha256:abc123",
+				"notes":        "Weekly snapshot",
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			for _, want := range []string{"linuxwiki", "kiwix", "active", "5.0MiB", "2024-11-04T09:00:00Z", "sha256:abc123", "Weekly snapshot"} {
+				if !strings.Contains(output, want) {
+					t.Fatalf("expected %q in show output:\n%s", want, output)
+				}
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
+
+func TestRagadminSourcesShowJSONIncludesFullRecord(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "sources-show-json",
+		args: []string{
+			"--socket",
+			"",
+			"--output",
+			"json",
+			"sources",
+			"show",
+			"linuxwiki",
+		},
+		responseBody: map[string]any{
+			"source": map[string]any{
+				"alias":    "linuxwiki",
+				"type":     "kiwix",
+				"status":   "active",
+				"checksum": "sha256:abc123",
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			var record map[string]any
+			if err := json.Unmarshal([]byte(output), &record); err != nil {
+				t.Fatalf("expected JSON record, got %v:\n%s", err, output)
+			}
+			if record["checksum"] != "sha256:abc123" || record["alias"] != "linuxwiki" {
+				t.Fatalf("unexpected JSON record: %v", record)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
+
+func TestRagadminSourcesShowSurfacesNotFound(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragadminScenario{
+		name: "sources-show-not-found",
+		args: []string{
+			"--socket",
+			"",
+			"sources",
+			"show",
+			"missing",
+		},
+		responseStatus: 404,
+		responseBody: map[string]any{
+			"code":    "SOURCE_NOT_FOUND",
+			"message": "Source missing is not catalogued",
+		},
+		expectError: true,
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Source missing is not catalogued") {
+				t.Fatalf("expected backend not-found message in output:\n%s", output)
+			}
+		},
+	}
+
+	runRagadminScenario(t, scenario)
+}
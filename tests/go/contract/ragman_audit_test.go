@@ -0,0 +1,107 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRagmanQueryAppendsAuditEntryWhenEnabled(t *testing.T) {
+	t.Parallel()
+
+	dataHome := t.TempDir()
+	question := "How do I mount an ISO image?"
+	scenario := ragmanScenario{
+		name:          "query-audit",
+		args:          []string{"query", "--socket", "", "--plain", question},
+		dataHome:      dataHome,
+		ragmanConfig:  "  audit_queries: true\n",
+		requestAssert: func(t *testing.T, body map[string]any) { t.Helper() },
+		responseBody: map[string]any{
+			"summary":    "Use mount -o loop to mount the ISO.",
+			"confidence": 0.82,
+			"trace_id":   "trace-audit",
+		},
+		outputAssert: func(t *testing.T, output string) { t.Helper() },
+	}
+	runRagmanScenario(t, scenario)
+
+	data, err := os.ReadFile(filepath.Join(dataHome, "ragcli", "audit.log"))
+	if err != nil {
+		t.Fatalf("expected audit log to be written: %v", err)
+	}
+	if strings.Contains(string(data), question) {
+		t.Fatalf("audit log must not contain the raw question:\n%s", data)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("decode audit entry: %v\n%s", err, data)
+	}
+	if entry["actor"] != "ragman" || entry["action"] != "query" || entry["status"] != "success" || entry["trace_id"] != "trace-audit" {
+		t.Fatalf("unexpected audit entry: %v", entry)
+	}
+	if target, _ := entry["target"].(string); !strings.HasPrefix(target, "sha256:") {
+		t.Fatalf("expected hashed question target, got %v", entry["target"])
+	}
+}
+
+func TestRagmanQueryAuditAppliesSharedRedaction(t *testing.T) {
+	t.Parallel()
+
+	dataHome := t.TempDir()
+	scenario := ragmanScenario{
+		name:     "query-audit-redaction",
+		args:     []string{"query", "--socket", "", "--plain", "How do I rotate logs?"},
+		dataHome: dataHome,
+		// The shared section follows the ragman keys; the harness appends the ragadmin section after it.
+		ragmanConfig:  "  audit_queries: true\nshared:\n  audit_redact_keys: [trace_id]\n  audit_redact_patterns: ['^sha256:']\n",
+		requestAssert: func(t *testing.T, body map[string]any) { t.Helper() },
+		responseBody: map[string]any{
+			"summary":    "Use logrotate.",
+			"confidence": 0.8,
+			"trace_id":   "trace-redacted",
+		},
+		outputAssert: func(t *testing.T, output string) { t.Helper() },
+	}
+	runRagmanScenario(t, scenario)
+
+	data, err := os.ReadFile(filepath.Join(dataHome, "ragcli", "audit.log"))
+	if err != nil {
+		t.Fatalf("expected audit log to be written: %v", err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("decode audit entry: %v\n%s", err, data)
+	}
+	if entry["trace_id"] != "***" {
+		t.Fatalf("expected trace_id to be masked, got %v", entry["trace_id"])
+	}
+	if target, _ := entry["target"].(string); !strings.HasPrefix(target, "***") || strings.Contains(target, "sha256:") {
+		t.Fatalf("expected the target prefix to be masked, got %v", entry["target"])
+	}
+}
+
+func TestRagmanQuerySkipsAuditByDefault(t *testing.T) {
+	t.Parallel()
+
+	dataHome := t.TempDir()
+	scenario := ragmanScenario{
+		name:          "query-audit-default-off",
+		args:          []string{"query", "--socket", "", "--plain", "How do I list open ports?"},
+		dataHome:      dataHome,
+		requestAssert: func(t *testing.T, body map[string]any) { t.Helper() },
+		responseBody: map[string]any{
+			"summary":    "Use ss -tulpn.",
+			"confidence": 0.77,
+		},
+		outputAssert: func(t *testing.T, output string) { t.Helper() },
+	}
+	runRagmanScenario(t, scenario)
+
+	if _, err := os.Stat(filepath.Join(dataHome, "ragcli", "audit.log")); !os.IsNotExist(err) {
+		t.Fatalf("expected no audit log without audit_queries, stat err = %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package contract_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRagmanQueryBatchCapturesPerQuestionErrors(t *testing.T) {
+	t.Parallel()
+
+	batchPath := filepath.Join(t.TempDir(), "questions.txt")
+	content := "How do I list disks?\n\nHow do I mount a disk?\nHow do I format a disk?\n"
+	if err := os.WriteFile(batchPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	questionAssert := func(want string) func(t *testing.T, body map[string]any) {
+		return func(t *testing.T, body map[string]any) {
+			t.Helper()
+			if question, _ := body["question"].(string); question != want {
+				t.Fatalf("expected question %q, got %v", want, body["question"])
+			}
+		}
+	}
+
+	scenario := ragmanScenario{
+		name:          "query-batch",
+		args:          []string{"query", "--socket", "", "--batch", batchPath},
+		requestAssert: questionAssert("How do I list disks?"),
+		responseBody: map[string]any{
+			"summary":    "Use lsblk.",
+			"confidence": 0.9,
+			"trace_id":   "trace-batch-1",
+		},
+		followUps: []ragmanExchange{
+			{
+				requestAssert: questionAssert("How do I mount a disk?"),
+				status:        500,
+				body: map[string]any{
+					"code":    "INTERNAL",
+					"message": "retrieval failed",
+				},
+			},
+			{
+				requestAssert: questionAssert("How do I format a disk?"),
+				body: map[string]any{
+					"summary":    "Use mkfs.",
+					"confidence": 0.8,
+					"trace_id":   "trace-batch-3",
+				},
+			},
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			start := strings.Index(output, "[")
+			if start < 0 {
+				t.Fatalf("expected a JSON array in batch output:\n%s", output)
+			}
+			var results []struct {
+				Question string         `json:"question"`
+				TraceID  string         `json:"trace_id"`
+				Answer   map[string]any `json:"answer"`
+				Error    string         `json:"error"`
+			}
+			if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&results); err != nil {
+				t.Fatalf("decode batch output: %v\n%s", err, output)
+			}
+			if len(results) != 3 {
+				t.Fatalf("expected 3 batch results, got %d:\n%s", len(results), output)
+			}
+			if results[0].TraceID != "trace-batch-1" || results[0].Answer["summary"] != "Use lsblk." {
+				t.Fatalf("unexpected first result: %+v", results[0])
+			}
+			if results[1].Answer != nil || !strings.Contains(results[1].Error, "retrieval failed") {
+				t.Fatalf("expected the second question to record its error, got %+v", results[1])
+			}
+			if results[2].TraceID != "trace-batch-3" || results[2].Answer["summary"] != "Use mkfs." {
+				t.Fatalf("expected the batch to continue after an error, got %+v", results[2])
+			}
+		},
+	}
+	runRagmanScenario(t, scenario)
+}
+
+func TestRagmanQueryBatchRedialsAfterTimeout(t *testing.T) {
+	t.Parallel()
+
+	batchPath := filepath.Join(t.TempDir(), "questions.txt")
+	content := "How do I list disks?\nHow do I mount a disk?\nHow do I format a disk?\n"
+	if err := os.WriteFile(batchPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write batch file: %v", err)
+	}
+
+	scenario := ragmanScenario{
+		name: "query-batch-timeout",
+		args: []string{"query", "--socket", "", "--batch", batchPath, "--timeout-seconds", "1"},
+		stub: func(t *testing.T, socketPath string, ready chan<- struct{}) error {
+			return runBatchReconnectStub(socketPath, ready, "How do I mount a disk?", 2)
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			start := strings.Index(output, "[")
+			if start < 0 {
+				t.Fatalf("expected a JSON array in batch output:\n%s", output)
+			}
+			var results []struct {
+				Answer map[string]any `json:"answer"`
+				Error  string         `json:"error"`
+			}
+			if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&results); err != nil {
+				t.Fatalf("decode batch output: %v\n%s", err, output)
+			}
+			if len(results) != 3 {
+				t.Fatalf("expected 3 batch results, got %d:\n%s", len(results), output)
+			}
+			if results[0].Answer["summary"] != "answer: How do I list disks?" {
+				t.Fatalf("unexpected first result: %+v", results[0])
+			}
+			if results[1].Answer != nil || results[1].Error == "" {
+				t.Fatalf("expected the timed-out question to record its error, got %+v", results[1])
+			}
+			if results[2].Error != "" || results[2].Answer["summary"] != "answer: How do I format a disk?" {
+				t.Fatalf("expected the batch to recover after the timeout, got %+v", results[2])
+			}
+		},
+	}
+	runRagmanScenario(t, scenario)
+}
+
+// runBatchReconnectStub serves the given number of sequential connections, answering every
+// question except slow, which is left unanswered so the client times out and redials.
+func runBatchReconnectStub(socketPath string, ready chan<- struct{}, slow string, connections int) error {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to bind unix socket: %w", err)
+	}
+	defer listener.Close()
+	close(ready)
+
+	if err := listener.(*net.UnixListener).SetDeadline(time.Now().Add(20 * time.Second)); err != nil {
+		return fmt.Errorf("failed to set listener deadline: %w", err)
+	}
+
+	for idx := 1; idx <= connections; idx++ {
+		conn, err := listener.Accept()
+		if err != nil {
+			return fmt.Errorf("failed to accept connection #%d: %w", idx, err)
+		}
+		err = serveBatchConnection(conn, slow)
+		conn.Close()
+		if err != nil {
+			return fmt.Errorf("connection #%d: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+func serveBatchConnection(conn net.Conn, slow string) error {
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	if _, err := readFrame(context.Background(), reader, conn); err != nil {
+		return fmt.Errorf("failed to read handshake: %w", err)
+	}
+	if err := writeFrame(writer, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "ragman-batch-stub",
+	}); err != nil {
+		return fmt.Errorf("failed to write handshake ack: %w", err)
+	}
+
+	for {
+		data, err := readFrame(context.Background(), reader, conn)
+		if err != nil {
+			// The client closed the connection, either after a timeout or at the end of the batch.
+			return nil
+		}
+		var frame map[string]any
+		if err := json.Unmarshal(data, &frame); err != nil {
+			return fmt.Errorf("failed to decode request frame: %w", err)
+		}
+		body, _ := frame["body"].(map[string]any)
+		question, _ := body["question"].(string)
+		if question == slow {
+			continue
+		}
+		correlationID, _ := frame["correlation_id"].(string)
+		if err := writeFrame(writer, map[string]any{
+			"type":           "response",
+			"status":         200,
+			"correlation_id": correlationID,
+			"body": map[string]any{
+				"summary":    "answer: " + question,
+				"confidence": 0.9,
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestRagmanConfigShowReportsEffectiveSettings(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeRagmanConfig(t, t.TempDir())
+	cmd := exec.Command("go", "run", "./cli/ragman", "--socket", "/run/test/backend.sock", "config", "show", "--json")
+	cmd.Dir = findRepoRoot(t)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("RAGCLI_CONFIG=%s", configPath),
+		"RAGMAN_PRESENTER=plain",
+		"RAGMAN_CONFIDENCE_THRESHOLD=0.6",
+	)
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("ragman config show failed: %v\noutput:\n%s", err, string(output))
+	}
+
+	var effective map[string]any
+	if err := json.Unmarshal(output, &effective); err != nil {
+		t.Fatalf("decode config show output: %v\n%s", err, string(output))
+	}
+	if got := effective["config_path"]; got != configPath {
+		t.Fatalf("config_path = %v, want %s", got, configPath)
+	}
+	if got := effective["socket_path"]; got != "/run/test/backend.sock" {
+		t.Fatalf("socket_path = %v, want the --socket value", got)
+	}
+	if got := effective["presenter"]; got != "plain" {
+		t.Fatalf("presenter = %v, want the environment override", got)
+	}
+	if got := effective["confidence_threshold"]; got != 0.6 {
+		t.Fatalf("confidence_threshold = %v, want 0.6", got)
+	}
+}
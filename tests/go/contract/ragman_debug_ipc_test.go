@@ -0,0 +1,30 @@
+package contract_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRagmanQueryDebugIPCSurfacesClientLogs(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragmanScenario{
+		name:          "query-debug-ipc",
+		args:          []string{"query", "--socket", "", "--plain", "--debug-ipc", "How do I list open ports?"},
+		requestAssert: func(t *testing.T, body map[string]any) { t.Helper() },
+		responseBody: map[string]any{
+			"summary":    "Use ss -tulpn.",
+			"confidence": 0.77,
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "IPCClient.NewClient(config) :: ready") {
+				t.Fatalf("expected IPC client logs with --debug-ipc:\n%s", output)
+			}
+			if !strings.Contains(output, "Use ss -tulpn.") {
+				t.Fatalf("expected the answer to still be rendered:\n%s", output)
+			}
+		},
+	}
+	runRagmanScenario(t, scenario)
+}
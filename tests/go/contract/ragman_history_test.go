@@ -0,0 +1,149 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRagmanHistoryRecordsAnsweredQuestions(t *testing.T) {
+	t.Parallel()
+
+	dataHome := t.TempDir()
+	for _, scenario := range []ragmanScenario{
+		{
+			name:     "history-recorded",
+			args:     []string{"query", "--socket", "", "--plain", "How do I list block devices?"},
+			dataHome: dataHome,
+			responseBody: map[string]any{
+				"summary":    "Use lsblk to list block devices.",
+				"confidence": 0.74,
+				"trace_id":   "trace-history",
+			},
+		},
+		{
+			name:     "history-opt-out",
+			args:     []string{"query", "--socket", "", "--plain", "--no-history", "How do I list USB devices?"},
+			dataHome: dataHome,
+			responseBody: map[string]any{
+				"summary":    "Use lsusb to list USB devices.",
+				"confidence": 0.71,
+				"trace_id":   "trace-private",
+			},
+		},
+	} {
+		scenario.requestAssert = func(t *testing.T, body map[string]any) { t.Helper() }
+		scenario.outputAssert = func(t *testing.T, output string) { t.Helper() }
+		runRagmanScenario(t, scenario)
+	}
+
+	configDir := t.TempDir()
+	cmd := exec.Command("go", "run", "./cli/ragman", "history", "--json", "--limit", "5")
+	cmd.Dir = findRepoRoot(t)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("XDG_DATA_HOME=%s", dataHome),
+		fmt.Sprintf("RAGCLI_CONFIG=%s", writeRagmanConfig(t, configDir)),
+	)
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("ragman history failed: %v\noutput:\n%s", err, string(output))
+	}
+
+	var entries []map[string]any
+	if err := json.Unmarshal(output, &entries); err != nil {
+		t.Fatalf("decode history output: %v\n%s", err, string(output))
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected exactly one recorded question, got %d:\n%s", len(entries), string(output))
+	}
+	if question, _ := entries[0]["question"].(string); question != "How do I list block devices?" {
+		t.Fatalf("unexpected recorded question %q", question)
+	}
+	if traceID, _ := entries[0]["trace_id"].(string); traceID != "trace-history" {
+		t.Fatalf("unexpected recorded trace id %q", traceID)
+	}
+	if strings.Contains(string(output), "trace-private") {
+		t.Fatalf("expected --no-history query to be omitted:\n%s", string(output))
+	}
+	if _, err := os.Stat(filepath.Join(dataHome, "ragcli", "ragman", "history.log")); err != nil {
+		t.Fatalf("expected history log under data home: %v", err)
+	}
+}
+
+func TestRagmanQueryReplaysHistoryTurns(t *testing.T) {
+	t.Parallel()
+
+	dataHome := t.TempDir()
+	logDir := filepath.Join(dataHome, "ragcli", "ragman")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		t.Fatalf("create history dir: %v", err)
+	}
+	log := strings.Join([]string{
+		`{"timestamp":"2025-01-01T10:00:00Z","question":"How do I list disks?","answer":"Use lsblk.","trace_id":"t1","confidence":0.8}`,
+		`{"timestamp":"2025-01-01T10:01:00Z","question":"How do I mount a disk?","answer":"Use mount.","trace_id":"t2","confidence":0.8}`,
+		`{"timestamp":"2025-01-01T10:02:00Z","question":"How do I format a disk?","trace_id":"t3","confidence":0.8}`,
+	}, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(logDir, "history.log"), []byte(log), 0o600); err != nil {
+		t.Fatalf("write history log: %v", err)
+	}
+
+	runRagmanScenario(t, ragmanScenario{
+		name:     "with-history",
+		args:     []string{"query", "--socket", "", "--plain", "--with-history", "2", "And how do I unmount it?"},
+		dataHome: dataHome,
+		requestAssert: func(t *testing.T, body map[string]any) {
+			t.Helper()
+			turns, _ := body["history"].([]any)
+			if len(turns) != 2 {
+				t.Fatalf("expected the two newest history turns, got %v", body["history"])
+			}
+			first, _ := turns[0].(map[string]any)
+			if first["question"] != "How do I mount a disk?" || first["answer"] != "Use mount." {
+				t.Fatalf("unexpected first turn %v", first)
+			}
+			second, _ := turns[1].(map[string]any)
+			if second["question"] != "How do I format a disk?" {
+				t.Fatalf("unexpected second turn %v", second)
+			}
+			if _, ok := second["answer"]; ok {
+				t.Fatalf("expected unanswered turn to omit answer, got %v", second)
+			}
+		},
+		responseBody: map[string]any{
+			"summary":    "Use umount.",
+			"confidence": 0.77,
+			"trace_id":   "trace-replay",
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Use umount.") {
+				t.Fatalf("expected answer in output:\n%s", output)
+			}
+		},
+	})
+}
+
+func TestRagmanQueryOmitsHistoryByDefault(t *testing.T) {
+	t.Parallel()
+
+	runRagmanScenario(t, ragmanScenario{
+		name: "without-history",
+		args: []string{"query", "--socket", "", "--plain", "How do I list disks?"},
+		requestAssert: func(t *testing.T, body map[string]any) {
+			t.Helper()
+			if _, ok := body["history"]; ok {
+				t.Fatalf("expected no history key without --with-history, got %v", body["history"])
+			}
+		},
+		responseBody: map[string]any{
+			"summary":    "Use lsblk.",
+			"confidence": 0.77,
+			"trace_id":   "trace-no-replay",
+		},
+		outputAssert: func(t *testing.T, output string) { t.Helper() },
+	})
+}
@@ -0,0 +1,34 @@
+package contract_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRagmanQueryPinsIndexVersion(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragmanScenario{
+		name: "query-index-version-pin",
+		args: []string{"query", "--socket", "", "--plain", "--width", "0", "--index-version", "catalog-41", "How do I list disks?"},
+		requestAssert: func(t *testing.T, body map[string]any) {
+			t.Helper()
+			if version, _ := body["index_version"].(string); version != "catalog-41" {
+				t.Fatalf("expected request to pin index_version catalog-41, got %v", body["index_version"])
+			}
+		},
+		responseBody: map[string]any{
+			"summary":       "Use lsblk.",
+			"confidence":    0.9,
+			"trace_id":      "trace-index-pin",
+			"index_version": "catalog-42",
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Requested index version catalog-41 was not honored; the backend answered from catalog-42") {
+				t.Fatalf("expected a warning that the pin was not honored:\n%s", output)
+			}
+		},
+	}
+	runRagmanScenario(t, scenario)
+}
@@ -18,9 +18,23 @@ import (
 type ragmanScenario struct {
 	name          string
 	args          []string
+	stdin         string
+	dataHome      string
+	ragmanConfig  string
 	requestAssert func(t *testing.T, body map[string]any)
 	responseBody  map[string]any
+	followUps     []ragmanExchange
+	expectError   bool
 	outputAssert  func(t *testing.T, output string)
+	// stub, when set, replaces the default single-connection backend stub.
+	stub func(t *testing.T, socketPath string, ready chan<- struct{}) error
+}
+
+// ragmanExchange is an additional request/response pair served on the same connection.
+type ragmanExchange struct {
+	requestAssert func(t *testing.T, body map[string]any)
+	status        int
+	body          map[string]any
 }
 
 func TestRagmanQueryMarkdownOutput(t *testing.T) {
@@ -249,21 +263,7 @@ func TestRagmanQueryNoAnswerFallback(t *testing.T) {
 				t.Fatalf("expected Windows question, got %v", question)
 			}
 		},
-		responseBody: map[string]any{
-			"summary":                "Answer is below the confidence threshold. Please rephrase your query or refresh sources via ragadmin.",
-			"steps":                  []any{},
-			"references":             []any{},
-			"citations":              []any{},
-			"confidence":             0.14,
-			"trace_id":               "trace-no-answer",
-			"no_answer":              true,
-			"confidence_threshold":   0.35,
-			"latency_ms":             210,
-			"retrieval_latency_ms":   90,
-			"llm_latency_ms":         120,
-			"index_version":          "catalog/v1",
-			"backend_correlation_id": "no-answer-correlation",
-		},
+		responseBody: noAnswerResponseBody(),
 		outputAssert: func(t *testing.T, output string) {
 			t.Helper()
 			if !strings.Contains(output, "rephrase your query") {
@@ -275,6 +275,162 @@ func TestRagmanQueryNoAnswerFallback(t *testing.T) {
 	runRagmanScenario(t, scenario)
 }
 
+// noAnswerResponseBody is the backend payload for a question the catalog cannot answer.
+func noAnswerResponseBody() map[string]any {
+	return map[string]any{
+		"summary":                "Answer is below the confidence threshold. Please rephrase your query or refresh sources via ragadmin.",
+		"steps":                  []any{},
+		"references":             []any{},
+		"citations":              []any{},
+		"confidence":             0.14,
+		"trace_id":               "trace-no-answer",
+		"no_answer":              true,
+		"confidence_threshold":   0.35,
+		"latency_ms":             210,
+		"retrieval_latency_ms":   90,
+		"llm_latency_ms":         120,
+		"index_version":          "catalog/v1",
+		"backend_correlation_id": "no-answer-correlation",
+	}
+}
+
+func TestRagmanQueryFailOnNoAnswerExitsNonZero(t *testing.T) {
+	t.Parallel()
+
+	runRagmanScenario(t, ragmanScenario{
+		name: "fail-on-no-answer",
+		args: []string{
+			"query",
+			"--socket",
+			"", // placeholder replaced at runtime
+			"--fail-on-no-answer",
+			"How do I boot Windows with ragman?",
+		},
+		requestAssert: func(t *testing.T, body map[string]any) { t.Helper() },
+		responseBody:  noAnswerResponseBody(),
+		expectError:   true,
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "rephrase your query") {
+				t.Fatalf("expected the fallback to be rendered before failing:\n%s", output)
+			}
+			if !strings.Contains(output, "exit status 2") {
+				t.Fatalf("expected exit status 2, got:\n%s", output)
+			}
+		},
+	})
+}
+
+func TestRagmanQueryReadsQuestionFromStdin(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragmanScenario{
+		name: "stdin-question",
+		args: []string{
+			"query",
+			"--socket",
+			"", // placeholder replaced at runtime
+			"--plain",
+			"-",
+		},
+		stdin: "  How do I mount\n\nan ext4 partition?  \n",
+		requestAssert: func(t *testing.T, body map[string]any) {
+			t.Helper()
+			if question, _ := body["question"].(string); question != "How do I mount an ext4 partition?" {
+				t.Fatalf("expected joined stdin question, got %q", question)
+			}
+		},
+		responseBody: map[string]any{
+			"summary":    "Use mount -t ext4 with the device and target directory.",
+			"confidence": 0.8,
+			"trace_id":   "trace-stdin",
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "mount -t ext4") {
+				t.Fatalf("expected answer in output:\n%s", output)
+			}
+		},
+	}
+
+	runRagmanScenario(t, scenario)
+}
+
+func TestRagmanQueryPagerPrintsDirectlyWithoutTerminal(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragmanScenario{
+		name: "pager-non-tty",
+		args: []string{
+			"query",
+			"--socket",
+			"", // placeholder replaced at runtime
+			"--pager",
+			"How do I list open ports?",
+		},
+		requestAssert: func(t *testing.T, body map[string]any) {
+			t.Helper()
+		},
+		responseBody: map[string]any{
+			"summary":    "Use ss -tulpn to list listening sockets.",
+			"steps":      []any{"Run ss -tulpn.", "Inspect the Local Address column."},
+			"confidence": 0.77,
+			"trace_id":   "trace-pager",
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "ss -tulpn") || !strings.Contains(output, "Trace ID: trace-pager") {
+				t.Fatalf("expected answer printed directly when stdout is not a terminal:\n%s", output)
+			}
+		},
+	}
+
+	runRagmanScenario(t, scenario)
+}
+
+func TestRagmanQuerySendsSourceFilters(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragmanScenario{
+		name: "source-filters",
+		args: []string{
+			"query",
+			"--socket",
+			"", // placeholder replaced at runtime
+			"--source-type",
+			"man",
+			"--source-type",
+			"Info",
+			"--lang",
+			"en",
+			"How do I change file permissions?",
+		},
+		requestAssert: func(t *testing.T, body map[string]any) {
+			t.Helper()
+			types, _ := body["source_types"].([]any)
+			if len(types) != 2 || types[0] != "man" || types[1] != "info" {
+				t.Fatalf("expected source_types [man info], got %v", body["source_types"])
+			}
+			if lang, _ := body["language"].(string); lang != "en" {
+				t.Fatalf("expected language `en`, got %v", body["language"])
+			}
+		},
+		responseBody: map[string]any{
+			"summary":    "Use chmod to update file permissions.",
+			"confidence": 0.8,
+			"trace_id":   "trace-filters",
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "chmod") {
+				t.Fatalf("expected answer in output:\n%s", output)
+			}
+		},
+	}
+
+	runRagmanScenario(t, scenario)
+}
+
 func runRagmanScenario(t *testing.T, scenario ragmanScenario) {
 	t.Helper()
 
@@ -286,7 +442,9 @@ func runRagmanScenario(t *testing.T, scenario ragmanScenario) {
 		t.Fatalf("failed to create config dir: %v", err)
 	}
 	configPath := filepath.Join(configDir, "ragcli", "config.yaml")
-	configContent := "ragman:\n  confidence_threshold: 0.35\n  presenter_default: markdown\nragadmin:\n  output_default: table\n"
+	configContent := "ragman:\n  confidence_threshold: 0.35\n  presenter_default: markdown\n" +
+		scenario.ragmanConfig +
+		"ragadmin:\n  output_default: table\n"
 	if err := os.WriteFile(configPath, []byte(configContent), 0o600); err != nil {
 		t.Fatalf("failed to write config: %v", err)
 	}
@@ -302,6 +460,10 @@ func runRagmanScenario(t *testing.T, scenario ragmanScenario) {
 	goStubReady := make(chan struct{})
 	goStubResult := make(chan error, 1)
 	go func() {
+		if scenario.stub != nil {
+			goStubResult <- scenario.stub(t, socketPath, goStubReady)
+			return
+		}
 		goStubResult <- runRagmanStub(t, socketPath, scenario, goStubReady)
 	}()
 	select {
@@ -313,14 +475,26 @@ func runRagmanScenario(t *testing.T, scenario ragmanScenario) {
 	cmdArgs := append([]string{"run", "./cli/ragman"}, args...)
 	cmd := exec.Command("go", cmdArgs...)
 	cmd.Dir = findRepoRoot(t)
+	dataHome := scenario.dataHome
+	if dataHome == "" {
+		dataHome = filepath.Join(socketDir, "data")
+	}
 	cmd.Env = append(os.Environ(),
 		fmt.Sprintf("XDG_RUNTIME_DIR=%s", socketDir),
 		fmt.Sprintf("XDG_CONFIG_HOME=%s", configDir),
+		fmt.Sprintf("XDG_DATA_HOME=%s", dataHome),
 		fmt.Sprintf("RAGCLI_CONFIG=%s", configPath),
 	)
+	if scenario.stdin != "" {
+		cmd.Stdin = strings.NewReader(scenario.stdin)
+	}
 
 	output, err := cmd.CombinedOutput()
-	if err != nil {
+	if scenario.expectError {
+		if err == nil {
+			t.Fatalf("expected ragman CLI to fail for scenario %q\noutput:\n%s", scenario.name, string(output))
+		}
+	} else if err != nil {
 		t.Fatalf("expected ragman CLI to succeed for scenario %q: %v\noutput:\n%s", scenario.name, err, string(output))
 	}
 
@@ -395,6 +569,34 @@ func runRagmanStub(t *testing.T, socketPath string, scenario ragmanScenario, rea
 		return fmt.Errorf("failed to write response frame: %w", err)
 	}
 
+	for idx, exchange := range scenario.followUps {
+		data, err := readFrame(context.Background(), reader, conn)
+		if err != nil {
+			return fmt.Errorf("failed to read follow-up request %d: %w", idx+1, err)
+		}
+		var frame map[string]any
+		if err := json.Unmarshal(data, &frame); err != nil {
+			return fmt.Errorf("failed to decode follow-up request %d: %w", idx+1, err)
+		}
+		if exchange.requestAssert != nil {
+			body, _ := frame["body"].(map[string]any)
+			exchange.requestAssert(t, body)
+		}
+		correlationID, _ := frame["correlation_id"].(string)
+		status := exchange.status
+		if status == 0 {
+			status = 200
+		}
+		if err := writeFrame(writer, map[string]any{
+			"type":           "response",
+			"status":         status,
+			"correlation_id": correlationID,
+			"body":           exchange.body,
+		}); err != nil {
+			return fmt.Errorf("failed to write follow-up response %d: %w", idx+1, err)
+		}
+	}
+
 	return nil
 }
 
@@ -480,3 +682,83 @@ func readFrame(ctx context.Context, reader *bufio.Reader, conn net.Conn) ([]byte
 
 	return payload, nil
 }
+
+func TestRagmanQueryWritesOutputFile(t *testing.T) {
+	t.Parallel()
+
+	outputPath := filepath.Join(t.TempDir(), "answers", "chmod.json")
+	runRagmanScenario(t, ragmanScenario{
+		name: "output-file",
+		args: []string{
+			"query",
+			"--socket",
+			"", // placeholder replaced at runtime
+			"--output-file",
+			outputPath,
+			"--output-format-file",
+			"json",
+			"How do I change file permissions?",
+		},
+		requestAssert: func(t *testing.T, body map[string]any) { t.Helper() },
+		responseBody: map[string]any{
+			"summary":    "Use chmod to adjust permissions.",
+			"confidence": 0.82,
+			"trace_id":   "trace-output-file",
+		},
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Summary") {
+				t.Fatalf("expected markdown answer on stdout:\n%s", output)
+			}
+		},
+	})
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read output file: %v", err)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("expected JSON output file: %v\n%s", err, string(data))
+	}
+	if payload["summary"] != "Use chmod to adjust permissions." {
+		t.Fatalf("unexpected output file payload: %v", payload)
+	}
+}
+
+func TestRagmanQueryOutputFileFailureKeepsStdout(t *testing.T) {
+	t.Parallel()
+
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker file: %v", err)
+	}
+	runRagmanScenario(t, ragmanScenario{
+		name: "output-file-failure",
+		args: []string{
+			"query",
+			"--socket",
+			"", // placeholder replaced at runtime
+			"--plain",
+			"--output-file",
+			filepath.Join(blocker, "answer.txt"),
+			"How do I change file permissions?",
+		},
+		requestAssert: func(t *testing.T, body map[string]any) { t.Helper() },
+		responseBody: map[string]any{
+			"summary":    "Use chmod to adjust permissions.",
+			"confidence": 0.82,
+			"trace_id":   "trace-output-file-failure",
+		},
+		expectError: true,
+		outputAssert: func(t *testing.T, output string) {
+			t.Helper()
+			if !strings.Contains(output, "Use chmod to adjust permissions.") {
+				t.Fatalf("expected the answer on stdout despite the file error:\n%s", output)
+			}
+			if !strings.Contains(output, "create output directory") {
+				t.Fatalf("expected the file error to be reported:\n%s", output)
+			}
+		},
+	})
+}
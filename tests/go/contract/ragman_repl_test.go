@@ -0,0 +1,269 @@
+package contract_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRagmanReplReusesConversationAcrossQuestions(t *testing.T) {
+	t.Parallel()
+
+	socketDir := t.TempDir()
+	socketPath := filepath.Join(socketDir, "backend.sock")
+	configPath := writeRagmanConfig(t, socketDir)
+
+	ready := make(chan struct{})
+	result := make(chan replStubResult, 1)
+	go func() {
+		result <- runRagmanReplStub(socketPath, ready)
+	}()
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	input := strings.Join([]string{
+		"How do I list files?",
+		"And hidden ones?",
+		"/plain",
+		"/new",
+		"How do I change permissions?",
+		"/quit",
+	}, "\n") + "\n"
+
+	cmd := exec.Command("go", "run", "./cli/ragman", "repl", "--socket", socketPath)
+	cmd.Dir = findRepoRoot(t)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("XDG_RUNTIME_DIR=%s", socketDir),
+		fmt.Sprintf("RAGCLI_CONFIG=%s", configPath),
+	)
+	cmd.Stdin = strings.NewReader(input)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("expected ragman repl to succeed: %v\noutput:\n%s", err, string(output))
+	}
+
+	stub := <-result
+	if stub.err != nil {
+		t.Fatalf("stub server failed: %v", stub.err)
+	}
+	if len(stub.conversations) != 3 {
+		t.Fatalf("expected 3 backend queries, got %d (%v)", len(stub.conversations), stub.conversations)
+	}
+	if stub.conversations[0] == "" {
+		t.Fatal("expected an auto-generated conversation id on the first question")
+	}
+	if stub.conversations[0] != stub.conversations[1] {
+		t.Fatalf("expected follow-up to reuse conversation id, got %v", stub.conversations)
+	}
+	if stub.conversations[2] == stub.conversations[1] {
+		t.Fatalf("expected /new to start a fresh conversation, got %v", stub.conversations)
+	}
+
+	text := string(output)
+	if !strings.Contains(text, "ragman [markdown]>") || !strings.Contains(text, "ragman [plain]>") {
+		t.Fatalf("expected prompt to reflect presenter switches:\n%s", text)
+	}
+	if !strings.Contains(text, "Summary") || !strings.Contains(text, "SUMMARY:") {
+		t.Fatalf("expected markdown and plain renderings in output:\n%s", text)
+	}
+}
+
+func TestRagmanReplRedialsAfterTimedOutQuestion(t *testing.T) {
+	t.Parallel()
+
+	socketDir := t.TempDir()
+	socketPath := filepath.Join(socketDir, "backend.sock")
+	configPath := writeRagmanConfig(t, socketDir)
+
+	ready := make(chan struct{})
+	result := make(chan replStubResult, 1)
+	go func() {
+		result <- runStallingReplStub(socketPath, ready)
+	}()
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	input := strings.Join([]string{
+		"Will this time out?",
+		"How do I list hidden files?",
+		"/quit",
+	}, "\n") + "\n"
+
+	cmd := exec.Command("go", "run", "./cli/ragman", "repl", "--socket", socketPath, "--timeout-seconds", "1")
+	cmd.Dir = findRepoRoot(t)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("XDG_RUNTIME_DIR=%s", socketDir),
+		fmt.Sprintf("RAGCLI_CONFIG=%s", configPath),
+	)
+	cmd.Stdin = strings.NewReader(input)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("expected ragman repl to survive the timeout: %v\noutput:\n%s", err, string(output))
+	}
+
+	stub := <-result
+	if stub.err != nil {
+		t.Fatalf("stub server failed: %v", stub.err)
+	}
+	if len(stub.conversations) != 1 {
+		t.Fatalf("expected the second question on a redialed connection, got %d answered queries", len(stub.conversations))
+	}
+
+	text := string(output)
+	if !strings.Contains(text, "ragman: query backend:") {
+		t.Fatalf("expected the timed-out question to be reported:\n%s", text)
+	}
+	if !strings.Contains(text, "Use ls -la to include hidden files.") {
+		t.Fatalf("expected the second question to be answered:\n%s", text)
+	}
+}
+
+type replStubResult struct {
+	conversations []string
+	err           error
+}
+
+func runRagmanReplStub(socketPath string, ready chan<- struct{}) replStubResult {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return replStubResult{err: fmt.Errorf("failed to bind unix socket: %w", err)}
+	}
+	defer listener.Close()
+
+	if unixListener, ok := listener.(*net.UnixListener); ok {
+		_ = unixListener.SetDeadline(time.Now().Add(60 * time.Second))
+	}
+	close(ready)
+
+	return serveReplConnection(listener)
+}
+
+// serveReplConnection accepts one connection and answers every question on it until the client hangs up.
+func serveReplConnection(listener net.Listener) replStubResult {
+	conn, err := listener.Accept()
+	if err != nil {
+		return replStubResult{err: fmt.Errorf("failed to accept connection: %w", err)}
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	if _, err := readFrame(context.Background(), reader, conn); err != nil {
+		return replStubResult{err: fmt.Errorf("failed to read handshake: %w", err)}
+	}
+	if err := writeFrame(writer, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "ragman-repl-stub",
+	}); err != nil {
+		return replStubResult{err: fmt.Errorf("failed to write handshake ack: %w", err)}
+	}
+
+	var conversations []string
+	for {
+		data, err := readFrame(context.Background(), reader, conn)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return replStubResult{conversations: conversations}
+			}
+			return replStubResult{conversations: conversations, err: fmt.Errorf("failed to read request: %w", err)}
+		}
+
+		var frame map[string]any
+		if err := json.Unmarshal(data, &frame); err != nil {
+			return replStubResult{err: fmt.Errorf("failed to decode request frame: %w", err)}
+		}
+		body, _ := frame["body"].(map[string]any)
+		conversation, _ := body["conversation_id"].(string)
+		conversations = append(conversations, conversation)
+
+		if err := writeFrame(writer, map[string]any{
+			"type":           "response",
+			"status":         200,
+			"correlation_id": frame["correlation_id"],
+			"body": map[string]any{
+				"summary":    "Use ls -la to include hidden files.",
+				"confidence": 0.8,
+				"trace_id":   fmt.Sprintf("trace-repl-%d", len(conversations)),
+			},
+		}); err != nil {
+			return replStubResult{err: fmt.Errorf("failed to write response frame: %w", err)}
+		}
+	}
+}
+
+// runStallingReplStub never answers the first connection's request, then answers questions on
+// the connection the REPL redials. Only questions answered on the second connection are recorded.
+func runStallingReplStub(socketPath string, ready chan<- struct{}) replStubResult {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return replStubResult{err: fmt.Errorf("failed to bind unix socket: %w", err)}
+	}
+	defer listener.Close()
+
+	if unixListener, ok := listener.(*net.UnixListener); ok {
+		_ = unixListener.SetDeadline(time.Now().Add(60 * time.Second))
+	}
+	close(ready)
+
+	stalled, err := listener.Accept()
+	if err != nil {
+		return replStubResult{err: fmt.Errorf("failed to accept first connection: %w", err)}
+	}
+	defer stalled.Close()
+
+	stalledReader := bufio.NewReader(stalled)
+	if _, err := readFrame(context.Background(), stalledReader, stalled); err != nil {
+		return replStubResult{err: fmt.Errorf("failed to read handshake: %w", err)}
+	}
+	if err := writeFrame(bufio.NewWriter(stalled), map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "ragman-repl-stub",
+	}); err != nil {
+		return replStubResult{err: fmt.Errorf("failed to write handshake ack: %w", err)}
+	}
+	if _, err := readFrame(context.Background(), stalledReader, stalled); err != nil {
+		return replStubResult{err: fmt.Errorf("failed to read stalled request: %w", err)}
+	}
+
+	return serveReplConnection(listener)
+}
+
+func writeRagmanConfig(t *testing.T, dir string) string {
+	t.Helper()
+
+	configDir := filepath.Join(dir, "config", "ragcli")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	configPath := filepath.Join(configDir, "config.yaml")
+	configContent := "ragman:\n  confidence_threshold: 0.35\n  presenter_default: markdown\n"
+	if err := os.WriteFile(configPath, []byte(configContent), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return configPath
+}
@@ -0,0 +1,25 @@
+package contract_test
+
+import "testing"
+
+func TestRagmanQueryReusesExternalTraceID(t *testing.T) {
+	t.Parallel()
+
+	scenario := ragmanScenario{
+		name: "query-external-trace-id",
+		args: []string{"--trace-id", "ext-trace-123", "query", "--socket", "", "--plain", "How do I list open ports?"},
+		requestAssert: func(t *testing.T, body map[string]any) {
+			t.Helper()
+			if traceID, _ := body["trace_id"].(string); traceID != "ext-trace-123" {
+				t.Fatalf("expected request to carry external trace id, got %v", body["trace_id"])
+			}
+		},
+		responseBody: map[string]any{
+			"summary":    "Use ss -tulpn.",
+			"confidence": 0.77,
+			"trace_id":   "ext-trace-123",
+		},
+		outputAssert: func(t *testing.T, output string) { t.Helper() },
+	}
+	runRagmanScenario(t, scenario)
+}
@@ -77,6 +77,133 @@ func TestClientHandshakeAndQueryFraming(t *testing.T) {
 	}
 }
 
+func TestClientReusesConnectionForSequentialQueries(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+	questions := []string{"How do I change file permissions?", "How do I change file ownership?"}
+
+	ready := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runReuseStubServer(socketPath, len(questions), ready)
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	client, err := ipc.NewClient(ipc.Config{
+		SocketPath: socketPath,
+		ClientID:   "contract-tests",
+	})
+	if err != nil {
+		t.Fatalf("failed to create IPC client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	for idx, question := range questions {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		resp, err := client.Query(ctx, ipc.QueryRequest{
+			Question: question,
+			TraceID:  fmt.Sprintf("reuse-trace-%d", idx+1),
+		})
+		cancel()
+		if err != nil {
+			t.Fatalf("query #%d failed on reused connection: %v", idx+1, err)
+		}
+		if want := fmt.Sprintf("answer %d: %s", idx+1, question); resp.Summary != want {
+			t.Fatalf("query #%d unexpected summary %q, want %q", idx+1, resp.Summary, want)
+		}
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("stub server error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stub server did not finish expectations")
+	}
+}
+
+// runReuseStubServer accepts exactly one connection, acknowledges one handshake,
+// and answers the given number of query requests on that connection.
+func runReuseStubServer(socketPath string, requests int, ready chan<- struct{}) error {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to bind unix socket: %w", err)
+	}
+	defer listener.Close()
+
+	close(ready)
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("failed to accept connection: %w", err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	handshake, err := readJSONFrame(reader)
+	if err != nil {
+		return err
+	}
+	if handshakeType, _ := handshake["type"].(string); handshakeType != "handshake" {
+		return fmt.Errorf("expected handshake frame, got %v", handshake)
+	}
+	if err := writeJSONFrame(writer, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "contract-stub",
+	}); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush handshake ack: %w", err)
+	}
+
+	for idx := 1; idx <= requests; idx++ {
+		request, err := readJSONFrame(reader)
+		if err != nil {
+			return fmt.Errorf("request #%d: %w", idx, err)
+		}
+		if frameType, _ := request["type"].(string); frameType != "request" {
+			return fmt.Errorf("request #%d: expected request frame, got %v", idx, request)
+		}
+		body, _ := request["body"].(map[string]any)
+		question, _ := body["question"].(string)
+		trace, _ := body["trace_id"].(string)
+		correlationID, _ := request["correlation_id"].(string)
+
+		if err := writeJSONFrame(writer, map[string]any{
+			"type":           "response",
+			"status":         200,
+			"correlation_id": correlationID,
+			"body": map[string]any{
+				"summary":    fmt.Sprintf("answer %d: %s", idx, question),
+				"confidence": 0.8,
+				"trace_id":   trace,
+			},
+		}); err != nil {
+			return err
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("failed to flush response #%d: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
 func runStubServer(socketPath string, ready chan<- struct{}) error {
 	_ = os.Remove(socketPath)
 	listener, err := net.Listen("unix", socketPath)
@@ -0,0 +1,131 @@
+package contract_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestEagerHandshakeRejectsMismatchedServerBeforeRequest(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+	ready := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runAckOnlyStubServer(socketPath, 99, ready)
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	client, err := ipc.NewClient(ipc.Config{
+		SocketPath:     socketPath,
+		ClientID:       "contract-tests",
+		EagerHandshake: true,
+	})
+	if err == nil {
+		_ = client.Close()
+		t.Fatal("expected NewClient to fail on an unsupported protocol version")
+	}
+	if !strings.Contains(err.Error(), "protocol version 99 unsupported") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("stub server error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stub server did not observe the client disconnect")
+	}
+}
+
+func TestEagerHandshakeNegotiatesDuringConstruction(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+	ready := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runAckOnlyStubServer(socketPath, 2, ready)
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	client, err := ipc.NewClient(ipc.Config{
+		SocketPath:     socketPath,
+		ClientID:       "contract-tests",
+		EagerHandshake: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create IPC client: %v", err)
+	}
+	if got := client.ProtocolVersion(); got != 2 {
+		t.Fatalf("expected protocol version to be negotiated before any request, got %d", got)
+	}
+	_ = client.Close()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("stub server error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stub server did not observe the client disconnect")
+	}
+}
+
+// runAckOnlyStubServer acknowledges the handshake with the given protocol version and then
+// requires the client to disconnect without sending a request.
+func runAckOnlyStubServer(socketPath string, version int, ready chan<- struct{}) error {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to bind unix socket: %w", err)
+	}
+	defer listener.Close()
+	close(ready)
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("accept failed: %w", err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	if _, err := readFrame(context.Background(), reader, conn); err != nil {
+		return fmt.Errorf("failed to read handshake: %w", err)
+	}
+	if err := writeFrame(writer, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  version,
+		"server":   "eager-contract-stub",
+	}); err != nil {
+		return fmt.Errorf("failed to write handshake ack: %w", err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if data, err := readFrame(context.Background(), reader, conn); err == nil {
+		return fmt.Errorf("expected no request after the handshake, got %s", data)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package contract_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestClientFailsFastWhenHandshakeIsNeverAcknowledged(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+	ready := make(chan struct{})
+	release := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runSilentStubServer(socketPath, ready, release)
+	}()
+	defer close(release)
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	client, err := ipc.NewClient(ipc.Config{
+		SocketPath:       socketPath,
+		ClientID:         "contract-tests",
+		HandshakeTimeout: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create IPC client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	_, err = client.Query(ctx, ipc.QueryRequest{Question: "Is anyone there?", TraceID: "silent-trace"})
+	elapsed := time.Since(started)
+
+	if !errors.Is(err, ipc.ErrHandshakeTimeout) {
+		t.Fatalf("expected handshake timeout error, got %v", err)
+	}
+	if elapsed > 2*time.Second {
+		t.Fatalf("expected the handshake timeout to fire well before the caller deadline, took %s", elapsed)
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("caller context should still be live, got %v", ctx.Err())
+	}
+}
+
+// runSilentStubServer accepts one connection and reads the handshake and first request
+// without ever acknowledging, holding the connection open until release is closed.
+func runSilentStubServer(socketPath string, ready chan<- struct{}, release <-chan struct{}) error {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to bind unix socket: %w", err)
+	}
+	defer listener.Close()
+	close(ready)
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("accept failed: %w", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		reader := bufio.NewReader(conn)
+		for {
+			if _, err := reader.ReadString('\n'); err != nil {
+				return
+			}
+		}
+	}()
+	<-release
+	return nil
+}
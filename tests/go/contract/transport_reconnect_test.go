@@ -0,0 +1,284 @@
+package contract_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestClientAutoReconnectRetriesAfterBackendRestart(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+
+	ready := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runRestartingStubServer(socketPath, ready)
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	client, err := ipc.NewClient(ipc.Config{
+		SocketPath:    socketPath,
+		ClientID:      "contract-tests",
+		RetrySchedule: []time.Duration{50 * time.Millisecond},
+		AutoReconnect: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create IPC client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := client.Query(ctx, ipc.QueryRequest{
+		Question: "How do I restart sshd?",
+		TraceID:  "reconnect-trace",
+	})
+	if err != nil {
+		t.Fatalf("expected query to succeed after reconnect, got error: %v", err)
+	}
+	if resp.Summary != "Use systemctl restart sshd." {
+		t.Fatalf("unexpected summary: %q", resp.Summary)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("stub server error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stub server did not finish expectations")
+	}
+}
+
+// runRestartingStubServer drops the first connection right after its handshake, mimicking a
+// backend restart, then serves the retried request on a freshly bound listener.
+func runRestartingStubServer(socketPath string, ready chan<- struct{}) error {
+	_ = os.Remove(socketPath)
+	first, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to bind unix socket: %w", err)
+	}
+	close(ready)
+
+	conn, err := first.Accept()
+	if err != nil {
+		first.Close()
+		return fmt.Errorf("failed to accept first connection: %w", err)
+	}
+	if _, err := readJSONFrame(bufio.NewReader(conn)); err != nil {
+		conn.Close()
+		first.Close()
+		return fmt.Errorf("first connection handshake: %w", err)
+	}
+	conn.Close()
+	first.Close()
+
+	_ = os.Remove(socketPath)
+	second, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to rebind unix socket: %w", err)
+	}
+	defer second.Close()
+
+	conn, err = second.Accept()
+	if err != nil {
+		return fmt.Errorf("failed to accept reconnection: %w", err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	handshake, err := readJSONFrame(reader)
+	if err != nil {
+		return err
+	}
+	if handshakeType, _ := handshake["type"].(string); handshakeType != "handshake" {
+		return fmt.Errorf("expected handshake frame on reconnection, got %v", handshake)
+	}
+	if err := writeJSONFrame(writer, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "contract-stub",
+	}); err != nil {
+		return err
+	}
+
+	request, err := readJSONFrame(reader)
+	if err != nil {
+		return fmt.Errorf("retried request: %w", err)
+	}
+	body, _ := request["body"].(map[string]any)
+	if trace, _ := body["trace_id"].(string); trace != "reconnect-trace" {
+		return fmt.Errorf("expected retried request to keep trace id, got %v", body["trace_id"])
+	}
+	correlationID, _ := request["correlation_id"].(string)
+
+	if err := writeJSONFrame(writer, map[string]any{
+		"type":           "response",
+		"status":         200,
+		"correlation_id": correlationID,
+		"body": map[string]any{
+			"summary":    "Use systemctl restart sshd.",
+			"confidence": 0.9,
+			"trace_id":   "reconnect-trace",
+		},
+	}); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func TestClientAutoReconnectRecoversAfterTimedOutCall(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+
+	ready := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runStallingStubServer(socketPath, ready)
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	client, err := ipc.NewClient(ipc.Config{
+		SocketPath:    socketPath,
+		ClientID:      "contract-tests",
+		RetrySchedule: []time.Duration{50 * time.Millisecond},
+		AutoReconnect: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create IPC client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	stalledCtx, cancelStalled := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancelStalled()
+	if _, err := client.Query(stalledCtx, ipc.QueryRequest{Question: "Will this time out?"}); err == nil {
+		t.Fatal("expected the stalled query to time out")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	resp, err := client.Query(ctx, ipc.QueryRequest{
+		Question: "How do I restart sshd?",
+		TraceID:  "reconnect-trace",
+	})
+	if err != nil {
+		t.Fatalf("expected the next query to redial and succeed, got error: %v", err)
+	}
+	if resp.Summary != "Use systemctl restart sshd." {
+		t.Fatalf("unexpected summary: %q", resp.Summary)
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("stub server error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stub server did not finish expectations")
+	}
+}
+
+// runStallingStubServer never answers the request on the first connection, so the client's
+// call times out, then serves the next request on the connection the client redials.
+func runStallingStubServer(socketPath string, ready chan<- struct{}) error {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to bind unix socket: %w", err)
+	}
+	defer listener.Close()
+	close(ready)
+
+	stalled, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("failed to accept first connection: %w", err)
+	}
+	defer stalled.Close()
+
+	stalledReader := bufio.NewReader(stalled)
+	stalledWriter := bufio.NewWriter(stalled)
+	if _, err := readJSONFrame(stalledReader); err != nil {
+		return fmt.Errorf("first connection handshake: %w", err)
+	}
+	if err := writeJSONFrame(stalledWriter, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "contract-stub",
+	}); err != nil {
+		return err
+	}
+	if err := stalledWriter.Flush(); err != nil {
+		return err
+	}
+	if _, err := readJSONFrame(stalledReader); err != nil {
+		return fmt.Errorf("stalled request: %w", err)
+	}
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("failed to accept reconnection: %w", err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	if _, err := readJSONFrame(reader); err != nil {
+		return fmt.Errorf("reconnection handshake: %w", err)
+	}
+	if err := writeJSONFrame(writer, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "contract-stub",
+	}); err != nil {
+		return err
+	}
+
+	request, err := readJSONFrame(reader)
+	if err != nil {
+		return fmt.Errorf("request after reconnect: %w", err)
+	}
+	correlationID, _ := request["correlation_id"].(string)
+	if err := writeJSONFrame(writer, map[string]any{
+		"type":           "response",
+		"status":         200,
+		"correlation_id": correlationID,
+		"body": map[string]any{
+			"summary":    "Use systemctl restart sshd.",
+			"confidence": 0.9,
+			"trace_id":   "reconnect-trace",
+		},
+	}); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,131 @@
+package contract_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestClientRequestFrameCarriesContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+	ready := make(chan struct{})
+	frames := make(chan map[string]any, 2)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runDeadlineRecordingStub(socketPath, 2, ready, frames)
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	client, err := ipc.NewClient(ipc.Config{
+		SocketPath: socketPath,
+		ClientID:   "contract-tests",
+	})
+	if err != nil {
+		t.Fatalf("failed to create IPC client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	if _, err := client.Query(ctx, ipc.QueryRequest{Question: "with deadline", MaxContextTokens: 512}); err != nil {
+		t.Fatalf("query with deadline failed: %v", err)
+	}
+	if _, err := client.Query(context.Background(), ipc.QueryRequest{Question: "without deadline", MaxContextTokens: 512}); err != nil {
+		t.Fatalf("query without deadline failed: %v", err)
+	}
+
+	withDeadline := <-frames
+	raw, _ := withDeadline["deadline"].(string)
+	got, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		t.Fatalf("expected an RFC3339 deadline in the request frame, got %q: %v", raw, err)
+	}
+	if drift := got.Sub(want); drift > time.Millisecond || drift < -time.Millisecond {
+		t.Fatalf("expected deadline %s, got %s", want.UTC(), got)
+	}
+
+	withoutDeadline := <-frames
+	if _, present := withoutDeadline["deadline"]; present {
+		t.Fatalf("expected no deadline for a context without one, got %v", withoutDeadline["deadline"])
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("stub server error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stub server did not finish expectations")
+	}
+}
+
+// runDeadlineRecordingStub answers the given number of queries and forwards every request
+// frame to frames so the test can inspect the envelope.
+func runDeadlineRecordingStub(socketPath string, requests int, ready chan<- struct{}, frames chan<- map[string]any) error {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to bind unix socket: %w", err)
+	}
+	defer listener.Close()
+	close(ready)
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("failed to accept connection: %w", err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	if _, err := readJSONFrame(reader); err != nil {
+		return fmt.Errorf("failed to read handshake: %w", err)
+	}
+	if err := writeFrame(writer, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "deadline-contract-stub",
+	}); err != nil {
+		return fmt.Errorf("failed to write handshake ack: %w", err)
+	}
+
+	for idx := 1; idx <= requests; idx++ {
+		request, err := readJSONFrame(reader)
+		if err != nil {
+			return fmt.Errorf("request #%d: %w", idx, err)
+		}
+		frames <- request
+
+		correlationID, _ := request["correlation_id"].(string)
+		if err := writeFrame(writer, map[string]any{
+			"type":           "response",
+			"status":         200,
+			"correlation_id": correlationID,
+			"body": map[string]any{
+				"summary":    fmt.Sprintf("answer %d", idx),
+				"confidence": 0.8,
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to write response #%d: %w", idx, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package contract_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestClientShutdownDrainsInFlightReindexStream(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+	ready := make(chan struct{})
+	release := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runReindexStreamStub(socketPath, ready, release)
+	}()
+
+	client := dialShutdownStub(t, socketPath, ready)
+
+	firstUpdate := make(chan struct{})
+	type streamResult struct {
+		job ipc.IngestionJob
+		err error
+	}
+	streamDone := make(chan streamResult, 1)
+	go func() {
+		job, err := client.StartReindexStream(context.Background(), ipc.ReindexRequest{TraceID: "drain-trace"}, func(job ipc.IngestionJob) error {
+			if job.Status == "running" {
+				close(firstUpdate)
+			}
+			return nil
+		})
+		streamDone <- streamResult{job: job, err: err}
+	}()
+
+	select {
+	case <-firstUpdate:
+	case <-time.After(2 * time.Second):
+		t.Fatal("did not receive the first reindex update")
+	}
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownDone <- client.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-shutdownDone:
+		t.Fatalf("Shutdown returned before the stream drained: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(release)
+
+	result := <-streamDone
+	if result.err != nil {
+		t.Fatalf("expected the stream to finish cleanly, got %v", result.err)
+	}
+	if result.job.Status != "succeeded" {
+		t.Fatalf("expected terminal status succeeded, got %q", result.job.Status)
+	}
+	if err := <-shutdownDone; err != nil {
+		t.Fatalf("expected Shutdown to succeed after draining, got %v", err)
+	}
+	if _, err := client.GetReindexStatus(context.Background(), "", ipc.ReindexStatusRequest{}); !errors.Is(err, ipc.ErrClientShutdown) {
+		t.Fatalf("expected requests after Shutdown to fail with ErrClientShutdown, got %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("stub server error: %v", err)
+	}
+}
+
+func TestClientShutdownClosesStalledStreamAtDeadline(t *testing.T) {
+	t.Parallel()
+
+	socketPath := filepath.Join(t.TempDir(), "backend.sock")
+	ready := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	stubErr := make(chan error, 1)
+	go func() {
+		stubErr <- runReindexStreamStub(socketPath, ready, release)
+	}()
+
+	client := dialShutdownStub(t, socketPath, ready)
+
+	firstUpdate := make(chan struct{})
+	streamErr := make(chan error, 1)
+	go func() {
+		_, err := client.StartReindexStream(context.Background(), ipc.ReindexRequest{TraceID: "stall-trace"}, func(job ipc.IngestionJob) error {
+			if job.Status == "running" {
+				close(firstUpdate)
+			}
+			return nil
+		})
+		streamErr <- err
+	}()
+
+	select {
+	case <-firstUpdate:
+	case err := <-streamErr:
+		t.Fatalf("stream ended before the first update: %v", err)
+	case err := <-stubErr:
+		t.Fatalf("stub server stopped before the first update: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("did not receive the first reindex update")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Shutdown to report the drain deadline, got %v", err)
+	}
+	select {
+	case err := <-streamErr:
+		if err == nil {
+			t.Fatal("expected the stalled stream to fail once the connection was closed")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stalled stream did not return after Shutdown closed the connection")
+	}
+}
+
+func dialShutdownStub(t *testing.T, socketPath string, ready <-chan struct{}) *ipc.Client {
+	t.Helper()
+
+	select {
+	case <-ready:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("stub server did not start listening on %s", socketPath)
+	}
+
+	client, err := ipc.NewClient(ipc.Config{SocketPath: socketPath, ClientID: "contract-tests"})
+	if err != nil {
+		t.Fatalf("failed to create IPC client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+// runReindexStreamStub answers one reindex request with a running snapshot, then waits for
+// release before sending the terminal snapshot.
+func runReindexStreamStub(socketPath string, ready chan<- struct{}, release <-chan struct{}) error {
+	_ = os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to bind unix socket: %w", err)
+	}
+	defer listener.Close()
+	close(ready)
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("accept failed: %w", err)
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	if _, err := readJSONFrame(reader); err != nil {
+		return fmt.Errorf("failed to read handshake: %w", err)
+	}
+	if err := writeFrame(writer, map[string]any{
+		"type":     "handshake_ack",
+		"protocol": "rag-cli-ipc",
+		"version":  1,
+		"server":   "shutdown-contract-stub",
+	}); err != nil {
+		return fmt.Errorf("failed to write handshake ack: %w", err)
+	}
+
+	request, err := readJSONFrame(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	correlationID, _ := request["correlation_id"].(string)
+
+	snapshot := func(status string) map[string]any {
+		return map[string]any{
+			"type":           "response",
+			"status":         202,
+			"correlation_id": correlationID,
+			"body": map[string]any{
+				"job": map[string]any{
+					"job_id":       "job-drain",
+					"status":       status,
+					"requested_at": "2026-01-01T00:00:00Z",
+					"stage":        "indexing",
+					"trigger":      "manual",
+				},
+			},
+		}
+	}
+	if err := writeFrame(writer, snapshot("running")); err != nil {
+		return fmt.Errorf("failed to write running snapshot: %w", err)
+	}
+
+	<-release
+	if err := writeFrame(writer, snapshot("succeeded")); err != nil {
+		return fmt.Errorf("failed to write terminal snapshot: %w", err)
+	}
+	return nil
+}
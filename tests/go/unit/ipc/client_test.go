@@ -75,6 +75,41 @@ func TestBuildQueryRequestRejectsEmptyQuestion(t *testing.T) {
 	}
 }
 
+func TestBuildQueryRequestValidatesContextTokens(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		tokens  int
+		want    int
+		wantErr bool
+	}{
+		{name: "zero defaults", tokens: 0, want: 4096},
+		{name: "at limit", tokens: ipc.MaxContextTokensLimit, want: ipc.MaxContextTokensLimit},
+		{name: "negative", tokens: -1, wantErr: true},
+		{name: "over limit", tokens: ipc.MaxContextTokensLimit + 1, wantErr: true},
+	}
+	for _, tc := range cases {
+		envelope, err := ipc.BuildQueryRequest(ipc.QueryRequestInput{
+			Question:         "List all sources",
+			MaxContextTokens: tc.tokens,
+		})
+		if tc.wantErr {
+			if !errors.Is(err, ipc.ErrInvalidQueryRequest) {
+				t.Fatalf("%s: expected ErrInvalidQueryRequest, got %v", tc.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: BuildQueryRequest returned error: %v", tc.name, err)
+		}
+		body := marshalEnvelopeBody(t, envelope)
+		if got := body["max_context_tokens"]; got != float64(tc.want) {
+			t.Fatalf("%s: max_context_tokens = %v, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
 func TestDecodeQueryResponseParsesStructuredPayload(t *testing.T) {
 	t.Parallel()
 
@@ -153,6 +188,30 @@ func TestDecodeQueryResponseValidatesRequiredFields(t *testing.T) {
 	}
 }
 
+func TestDecodeQueryResponseClampsConfidence(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		confidence string
+		want       float64
+	}{
+		{name: "over range", confidence: "1.7", want: 1},
+		{name: "under range", confidence: "-0.2", want: 0},
+		{name: "in range", confidence: "0.45", want: 0.45},
+	}
+	for _, tc := range cases {
+		raw := []byte(`{"summary": "Use chmod.", "confidence": ` + tc.confidence + `}`)
+		resp, err := ipc.DecodeQueryResponse(raw)
+		if err != nil {
+			t.Fatalf("%s: DecodeQueryResponse returned error: %v", tc.name, err)
+		}
+		if resp.Confidence != tc.want {
+			t.Fatalf("%s: confidence = %v, want %v", tc.name, resp.Confidence, tc.want)
+		}
+	}
+}
+
 func marshalEnvelopeBody(t *testing.T, envelope ipc.RequestEnvelope) map[string]any {
 	t.Helper()
 
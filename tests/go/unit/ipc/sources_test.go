@@ -0,0 +1,48 @@
+package ipc_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/linux-rag-t2/cli/shared/ipc"
+)
+
+func TestValidateSourceLocationBlocksRemoteURLs(t *testing.T) {
+	t.Parallel()
+
+	for _, location := range []string{
+		"http://example.com/linuxwiki_en.zim",
+		"HTTPS://download.kiwix.org/zim/wikipedia_en_all.zim",
+		"ftp://mirror.example.org/pub/man-pages.tar.gz",
+		"https://192.0.2.10:8080/library.zim",
+		"https://download.kiwix.org/zim/linux%wiki.zim",
+		"ftp://[mirror.example.org/pub",
+	} {
+		err := ipc.ValidateSourceLocation(location)
+		if !errors.Is(err, ipc.ErrRemoteSourceLocation) {
+			t.Fatalf("ValidateSourceLocation(%q) = %v, want ErrRemoteSourceLocation", location, err)
+		}
+		if !strings.Contains(err.Error(), "download the content locally first") {
+			t.Fatalf("expected guidance in error, got %v", err)
+		}
+	}
+}
+
+func TestValidateSourceLocationAllowsLocalReferences(t *testing.T) {
+	t.Parallel()
+
+	for _, location := range []string{
+		"/data/linuxwiki_en.zim",
+		"relative/path/manpages",
+		"file:///usr/share/info/coreutils.info.gz",
+		"man:ls(1)",
+		"info:coreutils",
+		"http://localhost:8080/linuxwiki_en.zim",
+		"http://127.0.0.1/library.zim",
+	} {
+		if err := ipc.ValidateSourceLocation(location); err != nil {
+			t.Fatalf("ValidateSourceLocation(%q) error = %v", location, err)
+		}
+	}
+}